@@ -45,6 +45,15 @@ const (
 	// ValidationErrorTypeNotSupported is used to report valid (as per formatting rules)
 	// values that can not be handled (e.g. an enumerated string).
 	ValidationErrorTypeNotSupported ValidationErrorType = "FieldValueNotSupported"
+	// ValidationErrorTypeTooLong is used to report that a value exceeds a configured
+	// maximum size (e.g. a label map serialized past a byte limit).
+	ValidationErrorTypeTooLong ValidationErrorType = "FieldValueTooLong"
+	// ValidationErrorTypeTooMany is used to report that a value exceeds a configured
+	// maximum count (e.g. too many containers in a pod).
+	ValidationErrorTypeTooMany ValidationErrorType = "FieldValueTooMany"
+	// ValidationErrorTypeNotRecognized is used to report a field present in the input
+	// that the target type has no definition for (e.g. a typo'd field name).
+	ValidationErrorTypeNotRecognized ValidationErrorType = "FieldValueNotRecognized"
 )
 
 func ValueOf(t ValidationErrorType) string {
@@ -59,6 +68,12 @@ func ValueOf(t ValidationErrorType) string {
 		return "invalid value"
 	case ValidationErrorTypeNotSupported:
 		return "unsupported value"
+	case ValidationErrorTypeTooLong:
+		return "too long"
+	case ValidationErrorTypeTooMany:
+		return "too many"
+	case ValidationErrorTypeNotRecognized:
+		return "unknown field"
 	default:
 		glog.Errorf("unrecognized validation type: %#v", t)
 		return ""
@@ -101,6 +116,21 @@ func NewFieldNotFound(field string, value interface{}) ValidationError {
 	return ValidationError{ValidationErrorTypeNotFound, field, value}
 }
 
+// NewFieldTooLong returns a ValidationError indicating "value too long"
+func NewFieldTooLong(field string, value interface{}) ValidationError {
+	return ValidationError{ValidationErrorTypeTooLong, field, value}
+}
+
+// NewFieldTooMany returns a ValidationError indicating "too many values"
+func NewFieldTooMany(field string, value interface{}) ValidationError {
+	return ValidationError{ValidationErrorTypeTooMany, field, value}
+}
+
+// NewFieldNotRecognized returns a ValidationError indicating "unknown field"
+func NewFieldNotRecognized(field string, value interface{}) ValidationError {
+	return ValidationError{ValidationErrorTypeNotRecognized, field, value}
+}
+
 // ErrorList is a collection of errors.  This does not implement the error
 // interface to avoid confusion where an empty ErrorList would still be an
 // error (non-nil).  To produce a single error instance from an ErrorList, use
@@ -47,6 +47,18 @@ func TestMakeFuncs(t *testing.T) {
 			func() ValidationError { return NewFieldRequired("f", "v") },
 			ValidationErrorTypeRequired,
 		},
+		{
+			func() ValidationError { return NewFieldTooLong("f", "v") },
+			ValidationErrorTypeTooLong,
+		},
+		{
+			func() ValidationError { return NewFieldTooMany("f", "v") },
+			ValidationErrorTypeTooMany,
+		},
+		{
+			func() ValidationError { return NewFieldNotRecognized("f", "v") },
+			ValidationErrorTypeNotRecognized,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -24,8 +24,45 @@ import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/capabilities"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+
+	"github.com/golang/glog"
 )
 
+// PodLister is anything that can list pods matching a label selector. It is used only by
+// the optional selector cross-checks below; validators that don't have a lister available
+// simply pass nil and skip the check.
+type PodLister interface {
+	ListPods(ctx api.Context, selector labels.Selector) (*api.PodList, error)
+}
+
+// warnOnEmptySelector logs a warning, but never fails validation, when selector matches no
+// existing pods. A freshly created Service or ReplicationController legitimately has no
+// matching pods yet, so this is advisory only -- it exists to catch the classic case of a
+// typo'd selector that will never match anything.
+func warnOnEmptySelector(ctx api.Context, kind, id string, selector labels.Selector, lister PodLister) {
+	if lister == nil || selector.Empty() {
+		return
+	}
+	pods, err := lister.ListPods(ctx, selector)
+	if err != nil || pods == nil || len(pods.Items) > 0 {
+		return
+	}
+	glog.Warningf("%s %q has a selector that matches no existing pods; check for a typo", kind, id)
+}
+
+// ValidateServiceSelectorMatch performs an optional cross-check of a Service's selector
+// against lister, warning (never failing validation) if it matches no existing pods.
+func ValidateServiceSelectorMatch(ctx api.Context, service *api.Service, lister PodLister) {
+	warnOnEmptySelector(ctx, "service", service.ID, labels.Set(service.Selector).AsSelector(), lister)
+}
+
+// ValidateReplicationControllerSelectorMatch performs an optional cross-check of a
+// ReplicationController's replica selector against lister, warning (never failing
+// validation) if it matches no existing pods.
+func ValidateReplicationControllerSelectorMatch(ctx api.Context, controller *api.ReplicationController, lister PodLister) {
+	warnOnEmptySelector(ctx, "replicationController", controller.ID, labels.Set(controller.DesiredState.ReplicaSelector).AsSelector(), lister)
+}
+
 func validateVolumes(volumes []api.Volume) (util.StringSet, errs.ErrorList) {
 	allErrs := errs.ErrorList{}
 
@@ -134,6 +171,7 @@ func validateEnv(vars []api.EnvVar) errs.ErrorList {
 func validateVolumeMounts(mounts []api.VolumeMount, volumes util.StringSet) errs.ErrorList {
 	allErrs := errs.ErrorList{}
 
+	mountPaths := util.StringSet{}
 	for i := range mounts {
 		mErrs := errs.ErrorList{}
 		mnt := &mounts[i] // so we can set default values
@@ -144,6 +182,10 @@ func validateVolumeMounts(mounts []api.VolumeMount, volumes util.StringSet) errs
 		}
 		if len(mnt.MountPath) == 0 {
 			mErrs = append(mErrs, errs.NewFieldRequired("mountPath", mnt.MountPath))
+		} else if mountPaths.Has(mnt.MountPath) {
+			mErrs = append(mErrs, errs.NewFieldDuplicate("mountPath", mnt.MountPath))
+		} else {
+			mountPaths.Insert(mnt.MountPath)
 		}
 		allErrs = append(allErrs, mErrs.PrefixIndex(i)...)
 	}
@@ -342,6 +384,25 @@ func ValidateService(service *api.Service) errs.ErrorList {
 	if labels.Set(service.Selector).AsSelector().Empty() {
 		allErrs = append(allErrs, errs.NewFieldRequired("selector", service.Selector))
 	}
+	allErrs = append(allErrs, validateServiceContainerPort(service.ContainerPort)...)
+	return allErrs
+}
+
+// validateServiceContainerPort checks that a Service's ContainerPort, which may name a
+// container port instead of numbering it so that services keep working across container
+// port renumbering, is either a valid port number or a valid port name.
+func validateServiceContainerPort(containerPort util.IntOrString) errs.ErrorList {
+	allErrs := errs.ErrorList{}
+	switch containerPort.Kind {
+	case util.IntstrInt:
+		if containerPort.IntVal != 0 && !util.IsValidPortNum(containerPort.IntVal) {
+			allErrs = append(allErrs, errs.NewFieldInvalid("containerPort", containerPort.IntVal))
+		}
+	case util.IntstrString:
+		if len(containerPort.StrVal) > 0 && (len(containerPort.StrVal) > 63 || !util.IsDNSLabel(containerPort.StrVal)) {
+			allErrs = append(allErrs, errs.NewFieldInvalid("containerPort", containerPort.StrVal))
+		}
+	}
 	return allErrs
 }
 
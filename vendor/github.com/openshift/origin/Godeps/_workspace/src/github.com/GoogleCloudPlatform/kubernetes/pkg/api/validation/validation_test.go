@@ -159,7 +159,7 @@ func TestValidateVolumeMounts(t *testing.T) {
 
 	successCase := []api.VolumeMount{
 		{Name: "abc", MountPath: "/foo"},
-		{Name: "123", MountPath: "/foo"},
+		{Name: "123", MountPath: "/baz"},
 		{Name: "abc-123", MountPath: "/bar"},
 	}
 	if errs := validateVolumeMounts(successCase, volumes); len(errs) != 0 {
@@ -170,6 +170,10 @@ func TestValidateVolumeMounts(t *testing.T) {
 		"empty name":      {{Name: "", MountPath: "/foo"}},
 		"name not found":  {{Name: "", MountPath: "/foo"}},
 		"empty mountpath": {{Name: "abc", MountPath: ""}},
+		"duplicate mount path": {
+			{Name: "abc", MountPath: "/foo"},
+			{Name: "123", MountPath: "/foo"},
+		},
 	}
 	for k, v := range errorCases {
 		if errs := validateVolumeMounts(v, volumes); len(errs) == 0 {
@@ -519,6 +523,38 @@ func TestValidateService(t *testing.T) {
 			},
 			numErrs: 0,
 		},
+		{
+			name: "valid named container port",
+			svc: api.Service{
+				JSONBase:      api.JSONBase{ID: "abc123", Namespace: api.NamespaceDefault},
+				Port:          8675,
+				Selector:      map[string]string{"foo": "bar"},
+				ContainerPort: util.NewIntOrStringFromString("http"),
+			},
+			numErrs: 0,
+		},
+		{
+			name: "invalid named container port",
+			svc: api.Service{
+				JSONBase:      api.JSONBase{ID: "abc123", Namespace: api.NamespaceDefault},
+				Port:          8675,
+				Selector:      map[string]string{"foo": "bar"},
+				ContainerPort: util.NewIntOrStringFromString("INVALID NAME"),
+			},
+			// Should fail because the container port name is not a valid DNS label.
+			numErrs: 1,
+		},
+		{
+			name: "invalid numeric container port",
+			svc: api.Service{
+				JSONBase:      api.JSONBase{ID: "abc123", Namespace: api.NamespaceDefault},
+				Port:          8675,
+				Selector:      map[string]string{"foo": "bar"},
+				ContainerPort: util.NewIntOrStringFromInt(65536),
+			},
+			// Should fail because the container port number is out of range.
+			numErrs: 1,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -84,6 +84,13 @@ func NewAPIGroup(storage map[string]RESTStorage, codec runtime.Codec, canonicalP
 	}}
 }
 
+// SetStrict controls whether create and update requests that contain fields the target
+// type doesn't recognize are rejected outright, rather than having those fields silently
+// dropped. It defaults to false to preserve existing lenient behavior.
+func (g *APIGroup) SetStrict(strict bool) {
+	g.handler.strict = strict
+}
+
 // InstallREST registers the REST handlers (storage, watch, and operations) into a mux.
 // It is expected that the provided prefix will serve all operations. Path MUST NOT end
 // in a slash.
@@ -45,6 +45,7 @@ type RESTHandler struct {
 	selfLinker      runtime.SelfLinker
 	ops             *Operations
 	asyncOpWait     time.Duration
+	strict          bool
 }
 
 // ServeHTTP handles requests to all RESTStorage objects.
@@ -168,6 +169,12 @@ func (h *RESTHandler) handleRESTStorage(parts []string, req *http.Request, w htt
 			errorJSON(err, h.codec, w)
 			return
 		}
+		if h.strict {
+			if err := checkUnknownFields(body, obj); err != nil {
+				errorJSON(err, h.codec, w)
+				return
+			}
+		}
 		out, err := storage.Create(ctx, obj)
 		if err != nil {
 			errorJSON(err, h.codec, w)
@@ -205,6 +212,12 @@ func (h *RESTHandler) handleRESTStorage(parts []string, req *http.Request, w htt
 			errorJSON(err, h.codec, w)
 			return
 		}
+		if h.strict {
+			if err := checkUnknownFields(body, obj); err != nil {
+				errorJSON(err, h.codec, w)
+				return
+			}
+		}
 		out, err := storage.Update(ctx, obj)
 		if err != nil {
 			errorJSON(err, h.codec, w)
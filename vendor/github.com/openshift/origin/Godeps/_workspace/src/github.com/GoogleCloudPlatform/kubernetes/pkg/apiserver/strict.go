@@ -0,0 +1,87 @@
+/*
+Copyright 2014 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+)
+
+// checkUnknownFields returns an error naming any top-level key of data that
+// obj's type has no field for. It's used by RESTHandler in strict mode to
+// catch typo'd field names (e.g. "replicaz") that DecodeInto would otherwise
+// silently drop.
+func checkUnknownFields(data []byte, obj runtime.Object) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		// Not a JSON object; DecodeInto will already have accepted or rejected it.
+		return nil
+	}
+	known := jsonFieldNames(reflect.TypeOf(obj))
+	unknown := []string{}
+	for key := range raw {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	kind := reflect.TypeOf(obj).Elem().Name()
+	allErrs := errors.ErrorList{}
+	for _, field := range unknown {
+		allErrs = append(allErrs, errors.NewFieldNotRecognized(field, nil))
+	}
+	return errors.NewInvalid(strings.ToLower(kind), "", allErrs)
+}
+
+// jsonFieldNames returns the set of top-level JSON field names t accepts,
+// following anonymous (inlined) fields the same way encoding/json does.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := map[string]bool{}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return names
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" {
+			for inlined := range jsonFieldNames(field.Type) {
+				names[inlined] = true
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = true
+	}
+	return names
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2014 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+)
+
+func TestCheckUnknownFields(t *testing.T) {
+	if err := checkUnknownFields([]byte(`{"id":"foo","port":80}`), &api.Service{}); err != nil {
+		t.Errorf("unexpected error for known fields: %v", err)
+	}
+	if err := checkUnknownFields([]byte(`{"id":"foo","replicaz":3}`), &api.Service{}); err == nil {
+		t.Errorf("expected an error for the unrecognized field %q", "replicaz")
+	}
+	if err := checkUnknownFields([]byte(`not json`), &api.Service{}); err != nil {
+		t.Errorf("expected malformed input to be left for DecodeInto to reject, got: %v", err)
+	}
+}
@@ -71,6 +71,7 @@ func (rs *REST) Create(ctx api.Context, obj runtime.Object) (<-chan runtime.Obje
 	if errs := validation.ValidateReplicationController(controller); len(errs) > 0 {
 		return nil, errors.NewInvalid("replicationController", controller.ID, errs)
 	}
+	validation.ValidateReplicationControllerSelectorMatch(ctx, controller, rs.podLister)
 
 	controller.CreationTimestamp = util.Now()
 
@@ -138,6 +139,7 @@ func (rs *REST) Update(ctx api.Context, obj runtime.Object) (<-chan runtime.Obje
 	if errs := validation.ValidateReplicationController(controller); len(errs) > 0 {
 		return nil, errors.NewInvalid("replicationController", controller.ID, errs)
 	}
+	validation.ValidateReplicationControllerSelectorMatch(ctx, controller, rs.podLister)
 	return apiserver.MakeAsync(func() (runtime.Object, error) {
 		err := rs.registry.UpdateController(ctx, controller)
 		if err != nil {
@@ -36,17 +36,20 @@ import (
 
 // REST adapts a service registry into apiserver's RESTStorage model.
 type REST struct {
-	registry Registry
-	cloud    cloudprovider.Interface
-	machines minion.Registry
+	registry  Registry
+	cloud     cloudprovider.Interface
+	machines  minion.Registry
+	podLister validation.PodLister
 }
 
-// NewREST returns a new REST.
-func NewREST(registry Registry, cloud cloudprovider.Interface, machines minion.Registry) *REST {
+// NewREST returns a new REST. podLister may be nil, in which case the selector cross-check
+// performed at Create is skipped.
+func NewREST(registry Registry, cloud cloudprovider.Interface, machines minion.Registry, podLister validation.PodLister) *REST {
 	return &REST{
-		registry: registry,
-		cloud:    cloud,
-		machines: machines,
+		registry:  registry,
+		cloud:     cloud,
+		machines:  machines,
+		podLister: podLister,
 	}
 }
 
@@ -58,6 +61,7 @@ func (rs *REST) Create(ctx api.Context, obj runtime.Object) (<-chan runtime.Obje
 	if errs := validation.ValidateService(srv); len(errs) > 0 {
 		return nil, errors.NewInvalid("service", srv.ID, errs)
 	}
+	validation.ValidateServiceSelectorMatch(ctx, srv, rs.podLister)
 
 	srv.CreationTimestamp = util.Now()
 
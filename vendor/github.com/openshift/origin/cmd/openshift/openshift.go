@@ -10,6 +10,8 @@ import (
 	"github.com/openshift/origin/pkg/cmd/client"
 	"github.com/openshift/origin/pkg/cmd/flagtypes"
 	"github.com/openshift/origin/pkg/cmd/server"
+	"github.com/openshift/origin/pkg/cmd/server/migrate"
+	"github.com/openshift/origin/pkg/cmd/server/simulatebuild"
 	"github.com/openshift/origin/pkg/version"
 )
 
@@ -43,6 +45,8 @@ func main() {
 
 	openshiftCmd.AddCommand(server.NewCommandStartServer("start"))
 	openshiftCmd.AddCommand(client.NewCommandKubecfg("kube"))
+	openshiftCmd.AddCommand(migrate.NewCommandMigrate("migrate"))
+	openshiftCmd.AddCommand(simulatebuild.NewCommandSimulateBuild("simulate-build"))
 	flagtypes.GLog(openshiftCmd.PersistentFlags())
 
 	// version information
@@ -4,12 +4,15 @@ import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
 
+	_ "github.com/openshift/origin/pkg/autoscale/api"
 	_ "github.com/openshift/origin/pkg/build/api"
 	_ "github.com/openshift/origin/pkg/config/api"
 	_ "github.com/openshift/origin/pkg/deploy/api"
 	_ "github.com/openshift/origin/pkg/image/api"
+	_ "github.com/openshift/origin/pkg/pipeline/api"
 	_ "github.com/openshift/origin/pkg/project/api"
 	_ "github.com/openshift/origin/pkg/route/api"
+	_ "github.com/openshift/origin/pkg/secret/api"
 	_ "github.com/openshift/origin/pkg/template/api"
 )
 
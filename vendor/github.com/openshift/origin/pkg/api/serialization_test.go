@@ -0,0 +1,151 @@
+package api_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	"github.com/fsouza/go-dockerclient"
+	"github.com/google/gofuzz"
+
+	originapi "github.com/openshift/origin/pkg/api"
+	"github.com/openshift/origin/pkg/api/latest"
+)
+
+// fuzzIters is how many randomized round trips TestOriginTypesRoundTrip runs per kind.
+const fuzzIters = 20
+
+// objectFuzzer randomly populates origin API objects for the round-trip tests below. It
+// follows kubernetes' own pkg/api/serialization_test.go for JSONBase and util.Time, since
+// every origin type embeds those the same way kubernetes' own types do.
+var objectFuzzer = fuzz.New().NilChance(.5).NumElements(1, 1).Funcs(
+	func(j *kapi.JSONBase, c fuzz.Continue) {
+		// APIVersion and Kind must stay blank in memory; they're filled in on encode.
+		j.APIVersion = ""
+		j.Kind = ""
+		j.ID = c.RandString()
+		j.ResourceVersion = c.RandUint64() >> 8
+		j.SelfLink = c.RandString()
+
+		var sec, nsec int64
+		c.Fuzz(&sec)
+		c.Fuzz(&nsec)
+		j.CreationTimestamp = util.Unix(sec, nsec).Rfc3339Copy()
+	},
+	func(t *util.Time, c fuzz.Continue) {
+		var sec, nsec int64
+		c.Fuzz(&sec)
+		c.Fuzz(&nsec)
+		*t = util.Unix(sec, nsec).Rfc3339Copy()
+	},
+	func(u64 *uint64, c fuzz.Continue) {
+		// The high byte doesn't round-trip cleanly through JSON/YAML; see kubernetes'
+		// own serialization_test.go for the same restriction.
+		*u64 = c.RandUint64() >> 8
+	},
+	func(eo *runtime.EmbeddedObject, c fuzz.Continue) {
+		// Leave embedded extension objects (used by config/template) nil. Whatever kind
+		// they'd hold gets its own round-trip coverage directly below; fuzzing one in
+		// here would just mean special-casing every extension point's own quirks twice.
+	},
+	func(intstr *util.IntOrString, c fuzz.Continue) {
+		// util.IntOrString panics if its Kind is set wrong; see kubernetes' own
+		// pkg/api/serialization_test.go for the same restriction.
+		if c.RandBool() {
+			intstr.Kind = util.IntstrInt
+			intstr.IntVal = int(c.RandUint64())
+			intstr.StrVal = ""
+		} else {
+			intstr.Kind = util.IntstrString
+			intstr.IntVal = 0
+			intstr.StrVal = c.RandString()
+		}
+	},
+	func(pb map[docker.Port][]docker.PortBinding, c fuzz.Continue) {
+		// Deployment configs embed a full kubernetes PodState, including docker's own
+		// container inspection structs; these two funcs are copied from kubernetes' own
+		// serialization_test.go, which needs them for the same reason: keys with nil
+		// values get omitted, which breaks the round trip.
+		pb[docker.Port(c.RandString())] = []docker.PortBinding{
+			{HostIp: c.RandString(), HostPort: c.RandString()},
+			{HostIp: c.RandString(), HostPort: c.RandString()},
+		}
+	},
+	func(pm map[string]docker.PortMapping, c fuzz.Continue) {
+		pm[c.RandString()] = docker.PortMapping{
+			c.RandString(): c.RandString(),
+		}
+	},
+)
+
+// isOriginKind reports whether kind was registered by an origin package, as opposed to one
+// of the kubernetes core kinds that share kapi.Scheme with it.
+func isOriginKind(kind string) bool {
+	obj, err := kapi.Scheme.New("", kind)
+	if err != nil {
+		return false
+	}
+	return strings.Contains(reflect.TypeOf(obj).Elem().PkgPath(), "github.com/openshift/origin")
+}
+
+// roundTrip fuzzes source, encodes it with codec, decodes it back both ways, and fails the
+// test if either decode doesn't reproduce the original object -- catching an optional field
+// that got dropped somewhere between the internal and versioned representations.
+func roundTrip(t *testing.T, codec runtime.Codec, source runtime.Object) {
+	name := reflect.TypeOf(source).Elem().Name()
+	objectFuzzer.Fuzz(source)
+
+	j, err := runtime.FindJSONBase(source)
+	if err != nil {
+		t.Fatalf("%s: unexpected error finding JSONBase: %v", name, err)
+	}
+	j.SetKind("")
+	j.SetAPIVersion("")
+
+	data, err := codec.Encode(source)
+	if err != nil {
+		t.Errorf("%s: unexpected error encoding: %v (%#v)", name, err, source)
+		return
+	}
+
+	obj2, err := codec.Decode(data)
+	if err != nil {
+		t.Errorf("%s: unexpected error decoding: %v", name, err)
+		return
+	}
+	if !reflect.DeepEqual(source, obj2) {
+		t.Errorf("%s: diff after decode: %s", name, runtime.ObjectDiff(source, obj2))
+	}
+
+	obj3 := reflect.New(reflect.TypeOf(source).Elem()).Interface().(runtime.Object)
+	if err := codec.DecodeInto(data, obj3); err != nil {
+		t.Errorf("%s: unexpected error decoding into: %v", name, err)
+		return
+	}
+	if !reflect.DeepEqual(source, obj3) {
+		t.Errorf("%s: diff after decode into: %s", name, runtime.ObjectDiff(source, obj3))
+	}
+}
+
+// TestOriginTypesRoundTrip fuzzes every origin API kind and round-trips it through both the
+// internal identity codec and the v1beta1 codec, so a newly added optional field can't
+// silently be dropped during internal<->versioned conversion or JSON/YAML encoding.
+func TestOriginTypesRoundTrip(t *testing.T) {
+	for kind := range kapi.Scheme.KnownTypes("") {
+		if !isOriginKind(kind) {
+			continue
+		}
+		for i := 0; i < fuzzIters; i++ {
+			item, err := kapi.Scheme.New("", kind)
+			if err != nil {
+				t.Errorf("Couldn't make a %s? %v", kind, err)
+				continue
+			}
+			roundTrip(t, originapi.Codec, item)
+			roundTrip(t, latest.Codec, item)
+		}
+	}
+}
@@ -1,3 +1,27 @@
 package api
 
-import ()
+// DeleteOptions carries options for a Delete request, such as whether a resource's
+// dependents (a Build's pod, a Project's provisioned resources) are cleaned up along with
+// it, and how long to wait before doing so.
+//
+// This API server vintage has no request path that can carry these in from a DELETE call --
+// the wire handler only ever sees the id from the URL -- so today only in-process callers
+// can set anything but the zero value. It exists so REST storages have a stable place to add
+// cascade/orphan and grace-period support without changing their exported signatures again
+// once a future API server version can parse a DELETE body.
+type DeleteOptions struct {
+	// Cascade controls whether a resource's dependents are cleaned up along with it.
+	// Defaults to true when nil.
+	Cascade *bool
+
+	// GracePeriodSeconds is how long the server should wait before actually removing
+	// dependents. Accepted for forward compatibility but not yet honored by any REST
+	// storage.
+	GracePeriodSeconds *int64
+}
+
+// ShouldCascade reports whether o requests cascading delete, which is the default when o is
+// nil or its Cascade field is unset.
+func (o *DeleteOptions) ShouldCascade() bool {
+	return o == nil || o.Cascade == nil || *o.Cascade
+}
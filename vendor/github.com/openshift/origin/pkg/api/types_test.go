@@ -0,0 +1,22 @@
+package api
+
+import "testing"
+
+func TestDeleteOptionsShouldCascade(t *testing.T) {
+	falseVal := false
+	trueVal := true
+
+	var nilOptions *DeleteOptions
+	if !nilOptions.ShouldCascade() {
+		t.Errorf("expected nil options to cascade by default")
+	}
+	if !(&DeleteOptions{}).ShouldCascade() {
+		t.Errorf("expected an unset Cascade field to cascade by default")
+	}
+	if (&DeleteOptions{Cascade: &falseVal}).ShouldCascade() {
+		t.Errorf("expected Cascade=false not to cascade")
+	}
+	if !(&DeleteOptions{Cascade: &trueVal}).ShouldCascade() {
+		t.Errorf("expected Cascade=true to cascade")
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
 
+	_ "github.com/openshift/origin/pkg/autoscale/api/v1beta1"
 	_ "github.com/openshift/origin/pkg/build/api/v1beta1"
 	_ "github.com/openshift/origin/pkg/config/api/v1beta1"
 	_ "github.com/openshift/origin/pkg/deploy/api/v1beta1"
 	_ "github.com/openshift/origin/pkg/image/api/v1beta1"
+	_ "github.com/openshift/origin/pkg/pipeline/api/v1beta1"
 	_ "github.com/openshift/origin/pkg/project/api/v1beta1"
 	_ "github.com/openshift/origin/pkg/route/api/v1beta1"
+	_ "github.com/openshift/origin/pkg/secret/api/v1beta1"
 	_ "github.com/openshift/origin/pkg/template/api/v1beta1"
 )
 
@@ -0,0 +1,23 @@
+package impersonation
+
+import (
+	"net/http"
+
+	"github.com/golang/glog"
+
+	"github.com/openshift/origin/pkg/auth/api"
+)
+
+// LogAuditor records impersonation attempts to the process log.
+type LogAuditor struct{}
+
+func (LogAuditor) AuditImpersonation(actor api.UserInfo, target string, allowed bool, req *http.Request) {
+	if allowed {
+		glog.Infof("impersonation: %q is acting as %q for %s %s", actor.GetName(), target, req.Method, req.URL.Path)
+		return
+	}
+	glog.Warningf("impersonation: %q was denied acting as %q for %s %s", actor.GetName(), target, req.Method, req.URL.Path)
+}
+
+// DefaultAuditor logs impersonation attempts via glog.
+var DefaultAuditor = LogAuditor{}
@@ -0,0 +1,63 @@
+// Package impersonation lets an authenticated caller act as another user, via the
+// Impersonate-User header, the same way kubectl's --as flag does against later Kubernetes
+// releases. Only callers an Authorizer approves may impersonate; every attempt, granted or
+// denied, is recorded through an Auditor.
+package impersonation
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/openshift/origin/pkg/auth/api"
+	"github.com/openshift/origin/pkg/auth/authenticator"
+)
+
+// Header is the request header a client sets to the name of the user it wants to act as.
+const Header = "Impersonate-User"
+
+// Authorizer decides whether actor is allowed to impersonate another user.
+type Authorizer interface {
+	CanImpersonate(actor api.UserInfo) bool
+}
+
+// Auditor records an impersonation attempt.
+type Auditor interface {
+	AuditImpersonation(actor api.UserInfo, target string, allowed bool, req *http.Request)
+}
+
+// Authenticator wraps delegate, substituting the identity it authenticates with the user named
+// by the Impersonate-User header when the authenticated caller is allowed to impersonate.
+type Authenticator struct {
+	delegate authenticator.Request
+	authz    Authorizer
+	audit    Auditor
+}
+
+// New returns an Authenticator that authenticates the real caller with delegate, then applies
+// impersonation using authz and audit.
+func New(delegate authenticator.Request, authz Authorizer, audit Auditor) *Authenticator {
+	return &Authenticator{delegate: delegate, authz: authz, audit: audit}
+}
+
+func (a *Authenticator) AuthenticateRequest(req *http.Request) (api.UserInfo, bool, error) {
+	actor, ok, err := a.delegate.AuthenticateRequest(req)
+	if !ok || err != nil {
+		return actor, ok, err
+	}
+
+	target := req.Header.Get(Header)
+	if len(target) == 0 {
+		return actor, true, nil
+	}
+
+	allowed := a.authz.CanImpersonate(actor)
+	a.audit.AuditImpersonation(actor, target, allowed, req)
+	if !allowed {
+		return nil, false, fmt.Errorf("%s is not allowed to impersonate %s", actor.GetName(), target)
+	}
+
+	return &api.DefaultUserInfo{
+		Name:  target,
+		Extra: map[string]string{"impersonator": actor.GetName()},
+	}, true, nil
+}
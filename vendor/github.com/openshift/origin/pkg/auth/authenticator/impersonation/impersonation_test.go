@@ -0,0 +1,96 @@
+package impersonation
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/openshift/origin/pkg/auth/api"
+)
+
+type fakeDelegate struct {
+	user api.UserInfo
+	ok   bool
+	err  error
+}
+
+func (f fakeDelegate) AuthenticateRequest(req *http.Request) (api.UserInfo, bool, error) {
+	return f.user, f.ok, f.err
+}
+
+type fakeAuditor struct {
+	actor   string
+	target  string
+	allowed bool
+	called  bool
+}
+
+func (f *fakeAuditor) AuditImpersonation(actor api.UserInfo, target string, allowed bool, req *http.Request) {
+	f.called = true
+	f.actor = actor.GetName()
+	f.target = target
+	f.allowed = allowed
+}
+
+func TestAuthenticateRequestNoImpersonation(t *testing.T) {
+	delegate := fakeDelegate{user: &api.DefaultUserInfo{Name: "alice"}, ok: true}
+	audit := &fakeAuditor{}
+	auth := New(delegate, NewStaticAuthorizer([]string{"alice"}), audit)
+
+	req, _ := http.NewRequest("GET", "/", nil)
+	user, ok, err := auth.AuthenticateRequest(req)
+	if err != nil || !ok {
+		t.Fatalf("expected success, got ok=%v err=%v", ok, err)
+	}
+	if user.GetName() != "alice" {
+		t.Errorf("expected alice, got %s", user.GetName())
+	}
+	if audit.called {
+		t.Errorf("expected no audit entry without an impersonation attempt")
+	}
+}
+
+func TestAuthenticateRequestAllowedImpersonation(t *testing.T) {
+	delegate := fakeDelegate{user: &api.DefaultUserInfo{Name: "admin"}, ok: true}
+	audit := &fakeAuditor{}
+	auth := New(delegate, NewStaticAuthorizer([]string{"admin"}), audit)
+
+	req, _ := http.NewRequest("GET", "/", nil)
+	req.Header.Set(Header, "bob")
+	user, ok, err := auth.AuthenticateRequest(req)
+	if err != nil || !ok {
+		t.Fatalf("expected success, got ok=%v err=%v", ok, err)
+	}
+	if user.GetName() != "bob" {
+		t.Errorf("expected bob, got %s", user.GetName())
+	}
+	if !audit.called || !audit.allowed || audit.actor != "admin" || audit.target != "bob" {
+		t.Errorf("unexpected audit entry: %+v", audit)
+	}
+}
+
+func TestAuthenticateRequestDeniedImpersonation(t *testing.T) {
+	delegate := fakeDelegate{user: &api.DefaultUserInfo{Name: "mallory"}, ok: true}
+	audit := &fakeAuditor{}
+	auth := New(delegate, NewStaticAuthorizer([]string{"admin"}), audit)
+
+	req, _ := http.NewRequest("GET", "/", nil)
+	req.Header.Set(Header, "bob")
+	_, ok, err := auth.AuthenticateRequest(req)
+	if ok || err == nil {
+		t.Fatalf("expected denial, got ok=%v err=%v", ok, err)
+	}
+	if !audit.called || audit.allowed {
+		t.Errorf("expected a denied audit entry, got %+v", audit)
+	}
+}
+
+func TestAuthenticateRequestDelegateFailure(t *testing.T) {
+	delegate := fakeDelegate{ok: false}
+	auth := New(delegate, NewStaticAuthorizer(nil), DefaultAuditor)
+
+	req, _ := http.NewRequest("GET", "/", nil)
+	_, ok, err := auth.AuthenticateRequest(req)
+	if ok || err != nil {
+		t.Fatalf("expected unauthenticated pass-through, got ok=%v err=%v", ok, err)
+	}
+}
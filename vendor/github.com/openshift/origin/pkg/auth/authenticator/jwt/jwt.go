@@ -0,0 +1,40 @@
+// Package jwt authenticates bearer tokens issued in origin's optional JWT access token format.
+// Unlike registry.TokenAuthenticator, it never consults the access token registry - the token
+// itself carries the user's identity, scope, and expiry, signed by the oauth server's key
+// store, so verification is local.
+package jwt
+
+import (
+	"github.com/openshift/origin/pkg/auth/api"
+	oauthjwt "github.com/openshift/origin/pkg/oauth/jwt"
+)
+
+// Verifier checks a JWT-formatted access token's signature and returns its claims.
+type Verifier interface {
+	Verify(token string) (*oauthjwt.Claims, error)
+}
+
+// Authenticator implements authenticator.Token for JWT-formatted access tokens.
+type Authenticator struct {
+	verifier Verifier
+}
+
+// New returns an Authenticator that verifies tokens against verifier.
+func New(verifier Verifier) *Authenticator {
+	return &Authenticator{verifier: verifier}
+}
+
+func (a *Authenticator) AuthenticateToken(value string) (api.UserInfo, bool, error) {
+	claims, err := a.verifier.Verify(value)
+	if err != nil {
+		return nil, false, nil
+	}
+	if claims.Expired() {
+		return nil, false, nil
+	}
+	return &api.DefaultUserInfo{
+		Name:  claims.UserName,
+		UID:   claims.UserUID,
+		Scope: claims.Scope,
+	}, true, nil
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// NewBasicChallenger returns a handler that responds to a failed authentication with a
+// WWW-Authenticate challenge instead of a browser redirect, for non-browser clients (like the
+// CLI) that cannot follow an interactive login flow.
+func NewBasicChallenger(realm string) http.Handler {
+	header := fmt.Sprintf("Basic realm=%q", realm)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("WWW-Authenticate", header)
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+}
+
+// IsBrowserRequest heuristically determines whether a request came from an interactive browser,
+// as opposed to a non-browser client such as the CLI, based on its Accept header.
+func IsBrowserRequest(req *http.Request) bool {
+	accept := req.Header.Get("Accept")
+	return len(accept) == 0 || accept == "*/*" || containsHTML(accept)
+}
+
+func containsHTML(accept string) bool {
+	return strings.Contains(accept, "text/html") || strings.Contains(accept, "application/xhtml+xml")
+}
+
+// NewBrowserOrChallengeHandler dispatches to browserHandler for requests that look like they
+// came from a browser, and to challengeHandler (typically NewBasicChallenger) otherwise.
+func NewBrowserOrChallengeHandler(browserHandler, challengeHandler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if IsBrowserRequest(req) {
+			browserHandler.ServeHTTP(w, req)
+			return
+		}
+		challengeHandler.ServeHTTP(w, req)
+	})
+}
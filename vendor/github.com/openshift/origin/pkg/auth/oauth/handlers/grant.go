@@ -2,11 +2,13 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/RangelReale/osin"
 
 	"github.com/openshift/origin/pkg/auth/api"
+	"github.com/openshift/origin/pkg/oauth/scope"
 )
 
 type GrantCheck struct {
@@ -29,6 +31,11 @@ func (h *GrantCheck) HandleAuthorize(ar *osin.AuthorizeRequest, w http.ResponseW
 		return true
 	}
 
+	if !scope.IsSupported(scope.Split(ar.Scope)) {
+		h.handler.GrantError(fmt.Errorf("requested scope %q includes one or more scopes this server does not support", ar.Scope), w, req)
+		return true
+	}
+
 	grant := &api.Grant{
 		Client:      ar.Client,
 		Scope:       ar.Scope,
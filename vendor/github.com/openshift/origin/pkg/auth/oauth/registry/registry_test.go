@@ -12,6 +12,7 @@ import (
 	"github.com/openshift/origin/pkg/auth/oauth/handlers"
 	oapi "github.com/openshift/origin/pkg/oauth/api"
 	"github.com/openshift/origin/pkg/oauth/registry/test"
+	"github.com/openshift/origin/pkg/oauth/scope"
 	"github.com/openshift/origin/pkg/oauth/server/osinserver"
 	"github.com/openshift/origin/pkg/oauth/server/osinserver/registrystorage"
 )
@@ -61,6 +62,12 @@ func TestRegistryAndServer(t *testing.T) {
 		UserName:   "user",
 		ClientName: "test",
 	}
+	trustedClient := &oapi.Client{
+		Name:         "test",
+		Secret:       "secret",
+		RedirectURIs: []string{assertServer.URL + "/assert"},
+		Trusted:      true,
+	}
 
 	testCases := map[string]struct {
 		Client      *oapi.Client
@@ -99,9 +106,9 @@ func TestRegistryAndServer(t *testing.T) {
 			ClientAuth: &oapi.ClientAuthorization{
 				UserName:   "user",
 				ClientName: "test",
-				Scopes:     []string{"test"},
+				Scopes:     []string{scope.UserInfo},
 			},
-			Scope: "test other",
+			Scope: scope.UserInfo + " " + scope.UserFull,
 			Check: func(h *testHandlers, req *http.Request) {
 				if h.AuthNeed || !h.GrantNeed || h.AuthErr != nil || h.GrantErr != nil {
 					t.Errorf("expected request to need to grant access because of uncovered scopes: %#v", h)
@@ -117,15 +124,40 @@ func TestRegistryAndServer(t *testing.T) {
 			ClientAuth: &oapi.ClientAuthorization{
 				UserName:   "user",
 				ClientName: "test",
-				Scopes:     []string{"test", "other"},
+				Scopes:     []string{scope.UserInfo, scope.UserFull},
 			},
-			Scope: "test other",
+			Scope: scope.UserInfo + " " + scope.UserFull,
 			Check: func(h *testHandlers, req *http.Request) {
 				if h.AuthNeed || h.GrantNeed || h.AuthErr != nil || h.GrantErr != nil {
 					t.Errorf("unexpected flow: %#v", h)
 				}
 			},
 		},
+		"unsupported scope is rejected": {
+			Client:      validClient,
+			AuthSuccess: true,
+			AuthUser: &api.DefaultUserInfo{
+				Name: "user",
+			},
+			Scope: "bogus",
+			Check: func(h *testHandlers, req *http.Request) {
+				if h.AuthNeed || h.GrantNeed || h.AuthErr != nil || h.GrantErr == nil {
+					t.Errorf("expected an unsupported scope to be rejected with a grant error: %#v", h)
+				}
+			},
+		},
+		"trusted client skips grant": {
+			Client:      trustedClient,
+			AuthSuccess: true,
+			AuthUser: &api.DefaultUserInfo{
+				Name: "user",
+			},
+			Check: func(h *testHandlers, req *http.Request) {
+				if h.AuthNeed || h.GrantNeed || h.AuthErr != nil || h.GrantErr != nil {
+					t.Errorf("expected a trusted client to skip the grant flow: %#v", h)
+				}
+			},
+		},
 		"has auth and grant": {
 			Client:      validClient,
 			AuthSuccess: true,
@@ -3,6 +3,7 @@ package registry
 import (
 	"time"
 
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
 
 	"github.com/openshift/origin/pkg/auth/api"
@@ -21,7 +22,7 @@ func NewTokenAuthenticator(registry accesstoken.Registry) *TokenAuthenticator {
 }
 
 func (a *TokenAuthenticator) AuthenticateToken(value string) (api.UserInfo, bool, error) {
-	token, err := a.registry.GetAccessToken(value)
+	token, err := a.registry.GetAccessToken(kubeapi.NewContext(), value)
 	if errors.IsNotFound(err) {
 		return nil, false, nil
 	}
@@ -0,0 +1,67 @@
+// Package csrf provides a CSRF token whose secret lives in a per-user session, so a login
+// or grant form is only accepted back if it carries the same token the session that
+// rendered the form was given, rather than a token any visitor could reuse.
+package csrf
+
+import (
+	"net/http"
+
+	"code.google.com/p/go-uuid/uuid"
+
+	"github.com/openshift/origin/pkg/auth/server/session"
+)
+
+// sessionKey is where the current CSRF token is kept among the session's values.
+const sessionKey = "csrf.token"
+
+// SessionStore hands out CSRF tokens scoped to the named session, so a token generated for
+// one user's session can't be used to satisfy another session's check.
+type SessionStore struct {
+	session session.Store
+	name    string
+}
+
+// NewCSRF returns a SessionStore that keeps its secret in the named session of store.
+func NewCSRF(store session.Store, name string) *SessionStore {
+	return &SessionStore{session: store, name: name}
+}
+
+// New binds a token to the session carried by req, saving any changes to w.
+func (s *SessionStore) New(w http.ResponseWriter, req *http.Request) *Token {
+	return &Token{store: s, w: w, req: req}
+}
+
+// Token generates and checks a single CSRF value against the session it was bound to.
+type Token struct {
+	store *SessionStore
+	w     http.ResponseWriter
+	req   *http.Request
+}
+
+// Generate creates a new token, saves it to the session, and returns it for embedding in a
+// form.
+func (t *Token) Generate() (string, error) {
+	session, err := t.store.session.Get(t.req, t.store.name)
+	if err != nil {
+		return "", err
+	}
+	token := uuid.NewUUID().String()
+	session.Values()[sessionKey] = token
+	if err := t.store.session.Save(t.w, t.req); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Check reports whether value matches the token most recently generated for this session.
+func (t *Token) Check(value string) (bool, error) {
+	session, err := t.store.session.Get(t.req, t.store.name)
+	if err != nil {
+		return false, err
+	}
+	token, _ := session.Values()[sessionKey].(string)
+	if len(token) == 0 {
+		return false, nil
+	}
+	return token == value, nil
+}
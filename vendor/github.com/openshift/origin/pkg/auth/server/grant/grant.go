@@ -0,0 +1,112 @@
+package grant
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/golang/glog"
+
+	"github.com/openshift/origin/pkg/auth/api"
+	"github.com/openshift/origin/pkg/auth/server/tmpl"
+)
+
+// GrantFormRenderer renders the page shown to a user asked to approve an oauth client's
+// requested scopes.
+type GrantFormRenderer interface {
+	Render(form GrantForm, w http.ResponseWriter, req *http.Request)
+}
+
+// CSRF generates a token to embed in the rendered consent form, binding it to the
+// session the form was rendered for.
+type CSRF interface {
+	Generate() (string, error)
+}
+
+// CSRFStore binds a CSRF to the session carried by a particular request.
+type CSRFStore interface {
+	New(w http.ResponseWriter, req *http.Request) CSRF
+}
+
+type GrantForm struct {
+	Error  string
+	Values GrantFormValues
+}
+
+type GrantFormValues struct {
+	Then     string
+	CSRF     string
+	ClientID string
+	UserName string
+	Scopes   string
+}
+
+// Grant implements handlers.GrantHandler by rendering a templated consent page whenever
+// an oauth client requests scopes the user has not already approved.
+type Grant struct {
+	csrf   CSRFStore
+	render GrantFormRenderer
+}
+
+func NewGrant(csrf CSRFStore, render GrantFormRenderer) *Grant {
+	return &Grant{csrf, render}
+}
+
+// GrantNeeded implements handlers.GrantHandler
+func (g *Grant) GrantNeeded(grant *api.Grant, w http.ResponseWriter, req *http.Request) {
+	csrf, err := g.csrf.New(w, req).Generate()
+	if err != nil {
+		glog.Errorf("Unable to generate CSRF token: %v", err)
+	}
+	form := GrantForm{
+		Values: GrantFormValues{
+			Then:     req.URL.Query().Get("then"),
+			CSRF:     csrf,
+			ClientID: grant.Client.GetId(),
+			Scopes:   grant.Scope,
+		},
+	}
+	g.render.Render(form, w, req)
+}
+
+// GrantError implements handlers.GrantHandler
+func (g *Grant) GrantError(err error, w http.ResponseWriter, req *http.Request) {
+	glog.Errorf("Unable to grant access: %v", err)
+	form := GrantForm{Error: "An unknown error has occured. Please try again."}
+	g.render.Render(form, w, req)
+}
+
+// NewGrantFormRenderer creates a GrantFormRenderer that renders the template at path, or
+// the compiled-in default consent page if path is empty.
+func NewGrantFormRenderer(path string) (GrantFormRenderer, error) {
+	template, err := tmpl.LoadTemplate(path, "grantForm", grantTemplateDefault)
+	if err != nil {
+		return nil, err
+	}
+	return grantTemplateRenderer{template}, nil
+}
+
+var DefaultGrantFormRenderer = grantTemplateRenderer{template.Must(template.New("grantForm").Parse(grantTemplateDefault))}
+
+type grantTemplateRenderer struct {
+	template *template.Template
+}
+
+func (r grantTemplateRenderer) Render(form GrantForm, w http.ResponseWriter, req *http.Request) {
+	w.Header().Add("Content-Type", "text/html")
+	w.WriteHeader(http.StatusOK)
+	if err := r.template.Execute(w, form); err != nil {
+		glog.Errorf("Unable to render grant template: %v", err)
+	}
+}
+
+const grantTemplateDefault = `
+{{ if .Error }}<div class="message">{{ .Error }}</div>{{ end }}
+<p>{{ .Values.ClientID }} is requesting access to your account ({{ .Values.UserName }}).</p>
+<p>Requested permissions: {{ .Values.Scopes }}</p>
+<form action="" method="POST">
+  <input type="hidden" name="then" value="{{ .Values.Then }}">
+  <input type="hidden" name="csrf" value="{{ .Values.CSRF }}">
+  <input type="submit" name="approve" value="Allow">
+  <input type="submit" name="deny" value="Deny">
+</form>
+`
@@ -32,12 +32,12 @@ type ConfirmFormValues struct {
 }
 
 type Confirm struct {
-	csrf   CSRF
+	csrf   CSRFStore
 	auth   RequestAuthenticator
 	render ConfirmFormRenderer
 }
 
-func NewConfirm(csrf CSRF, auth RequestAuthenticator, render ConfirmFormRenderer) *Confirm {
+func NewConfirm(csrf CSRFStore, auth RequestAuthenticator, render ConfirmFormRenderer) *Confirm {
 	return &Confirm{
 		csrf:   csrf,
 		auth:   auth,
@@ -78,7 +78,7 @@ func (c *Confirm) handleConfirmForm(w http.ResponseWriter, req *http.Request) {
 		form.Error = "An unknown error has occured. Please try again."
 	}
 
-	csrf, err := c.csrf.Generate()
+	csrf, err := c.csrf.New(w, req).Generate()
 	if err != nil {
 		glog.Errorf("Unable to generate CSRF token: %v", err)
 	}
@@ -98,7 +98,7 @@ func (c *Confirm) handleConfirmForm(w http.ResponseWriter, req *http.Request) {
 }
 
 func (c *Confirm) handleConfirm(w http.ResponseWriter, req *http.Request) {
-	if ok, err := c.csrf.Check(req.FormValue("csrf")); !ok || err != nil {
+	if ok, err := c.csrf.New(w, req).Check(req.FormValue("csrf")); !ok || err != nil {
 		glog.Errorf("Unable to check CSRF token: %v", err)
 		failed("token expired", w, req)
 		return
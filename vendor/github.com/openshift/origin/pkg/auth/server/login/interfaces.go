@@ -12,3 +12,9 @@ type CSRF interface {
 	Generate() (string, error)
 	Check(string) (bool, error)
 }
+
+// CSRFStore binds a CSRF to the session carried by a particular request, so a token
+// generated for one session's form can't be satisfied by a different session's post.
+type CSRFStore interface {
+	New(w http.ResponseWriter, req *http.Request) CSRF
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/openshift/origin/pkg/auth/api"
 	"github.com/openshift/origin/pkg/auth/authenticator"
+	"github.com/openshift/origin/pkg/auth/server/tmpl"
 )
 
 type PasswordAuthenticator interface {
@@ -34,12 +35,12 @@ type LoginFormValues struct {
 }
 
 type Login struct {
-	csrf   CSRF
+	csrf   CSRFStore
 	auth   PasswordAuthenticator
 	render LoginFormRenderer
 }
 
-func NewLogin(csrf CSRF, auth PasswordAuthenticator, render LoginFormRenderer) *Login {
+func NewLogin(csrf CSRFStore, auth PasswordAuthenticator, render LoginFormRenderer) *Login {
 	return &Login{
 		csrf:   csrf,
 		auth:   auth,
@@ -89,7 +90,7 @@ func (l *Login) handleLoginForm(w http.ResponseWriter, req *http.Request) {
 		form.Error = "An unknown error has occured. Please try again."
 	}
 
-	csrf, err := l.csrf.Generate()
+	csrf, err := l.csrf.New(w, req).Generate()
 	if err != nil {
 		glog.Errorf("Unable to generate CSRF token: %v", err)
 	}
@@ -99,7 +100,7 @@ func (l *Login) handleLoginForm(w http.ResponseWriter, req *http.Request) {
 }
 
 func (l *Login) handleLogin(w http.ResponseWriter, req *http.Request) {
-	if ok, err := l.csrf.Check(req.FormValue("csrf")); !ok || err != nil {
+	if ok, err := l.csrf.New(w, req).Check(req.FormValue("csrf")); !ok || err != nil {
 		glog.Errorf("Unable to check CSRF token: %v", err)
 		failed("token expired", w, req)
 		return
@@ -123,19 +124,31 @@ func (l *Login) handleLogin(w http.ResponseWriter, req *http.Request) {
 	l.auth.AuthenticationSucceeded(context, then, w, req)
 }
 
-var DefaultLoginFormRenderer = loginTemplateRenderer{}
+// NewLoginFormRenderer creates a LoginFormRenderer that renders the template at path, or
+// the compiled-in default login page if path is empty.
+func NewLoginFormRenderer(path string) (LoginFormRenderer, error) {
+	template, err := tmpl.LoadTemplate(path, "loginForm", loginTemplateDefault)
+	if err != nil {
+		return nil, err
+	}
+	return loginTemplateRenderer{template}, nil
+}
 
-type loginTemplateRenderer struct{}
+var DefaultLoginFormRenderer = loginTemplateRenderer{template.Must(template.New("loginForm").Parse(loginTemplateDefault))}
+
+type loginTemplateRenderer struct {
+	template *template.Template
+}
 
 func (r loginTemplateRenderer) Render(form LoginForm, w http.ResponseWriter, req *http.Request) {
 	w.Header().Add("Content-Type", "text/html")
 	w.WriteHeader(http.StatusOK)
-	if err := loginTemplate.Execute(w, form); err != nil {
+	if err := r.template.Execute(w, form); err != nil {
 		glog.Errorf("Unable to render login template: %v", err)
 	}
 }
 
-var loginTemplate = template.Must(template.New("loginForm").Parse(`
+const loginTemplateDefault = `
 {{ if .Error }}<div class="message">{{ .Error }}</div>{{ end }}
 <form action="{{ .Action }}" method="POST">
   <input type="hidden" name="then" value="{{ .Values.Then }}">
@@ -144,4 +157,4 @@ var loginTemplate = template.Must(template.New("loginForm").Parse(`
   <label>Password: <input type="password" name="password" value=""></label>
   <input type="submit" value="Login">
 </form>
-`))
+`
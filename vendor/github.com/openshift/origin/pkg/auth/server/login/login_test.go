@@ -25,6 +25,10 @@ func (t *testCSRF) Check(token string) (bool, error) {
 	return t.Token == token, t.Err
 }
 
+func (t *testCSRF) New(w http.ResponseWriter, req *http.Request) CSRF {
+	return t
+}
+
 type testAuth struct {
 	Username string
 	Password string
@@ -0,0 +1,130 @@
+// Package selectprovider renders a page for choosing among several configured identity
+// providers, so a user configured against one identity provider is not sent to a login form
+// meant for another and left unable to authenticate.
+package selectprovider
+
+import (
+	"html/template"
+	"net/http"
+	"net/url"
+
+	"github.com/golang/glog"
+
+	"github.com/openshift/origin/pkg/auth/server/tmpl"
+)
+
+// HintParam is the query parameter a caller can set to a provider's Name to skip the
+// selection page and go straight to that provider's login flow.
+const HintParam = "idp"
+
+// Provider describes one configured identity provider a user can be sent to authenticate
+// against.
+type Provider struct {
+	// Name is shown to the user on the selection page and matched against HintParam.
+	Name string
+	// LoginURL is the path this provider's own login flow is mounted at.
+	LoginURL string
+}
+
+// SelectProviderFormRenderer renders the provider selection page.
+type SelectProviderFormRenderer interface {
+	Render(form SelectProviderForm, w http.ResponseWriter, req *http.Request)
+}
+
+type SelectProviderForm struct {
+	Providers []ProviderLink
+}
+
+// ProviderLink is a provider's name paired with the URL to send the user to, with every query
+// parameter from the original request - "then", for example - carried along.
+type ProviderLink struct {
+	Name string
+	URL  string
+}
+
+// Handler serves the provider selection page, or skips it entirely when only one provider is
+// configured or the request already names one via HintParam.
+type Handler struct {
+	providers []Provider
+	render    SelectProviderFormRenderer
+}
+
+// New returns a Handler offering providers, rendered with render when a choice is needed.
+func New(providers []Provider, render SelectProviderFormRenderer) *Handler {
+	return &Handler{providers: providers, render: render}
+}
+
+// Install registers the handler into mux at path.
+func (h *Handler) Install(mux Mux, path string) {
+	mux.HandleFunc(path, h.ServeHTTP)
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if hint := req.URL.Query().Get(HintParam); len(hint) > 0 {
+		for _, provider := range h.providers {
+			if provider.Name == hint {
+				http.Redirect(w, req, providerURL(provider, req), http.StatusFound)
+				return
+			}
+		}
+	}
+
+	if len(h.providers) == 1 {
+		http.Redirect(w, req, providerURL(h.providers[0], req), http.StatusFound)
+		return
+	}
+
+	links := make([]ProviderLink, 0, len(h.providers))
+	for _, provider := range h.providers {
+		links = append(links, ProviderLink{Name: provider.Name, URL: providerURL(provider, req)})
+	}
+	h.render.Render(SelectProviderForm{Providers: links}, w, req)
+}
+
+// providerURL points at provider's login flow, carrying forward every query parameter from
+// req except the provider hint itself, which has already served its purpose.
+func providerURL(provider Provider, req *http.Request) string {
+	query := url.Values{}
+	for key, values := range req.URL.Query() {
+		if key == HintParam {
+			continue
+		}
+		query[key] = values
+	}
+	if len(query) == 0 {
+		return provider.LoginURL
+	}
+	return provider.LoginURL + "?" + query.Encode()
+}
+
+// NewSelectProviderFormRenderer creates a SelectProviderFormRenderer that renders the template
+// at path, or the compiled-in default selection page if path is empty.
+func NewSelectProviderFormRenderer(path string) (SelectProviderFormRenderer, error) {
+	tpl, err := tmpl.LoadTemplate(path, "selectProviderForm", selectProviderTemplateDefault)
+	if err != nil {
+		return nil, err
+	}
+	return selectProviderTemplateRenderer{tpl}, nil
+}
+
+var DefaultSelectProviderFormRenderer = selectProviderTemplateRenderer{template.Must(template.New("selectProviderForm").Parse(selectProviderTemplateDefault))}
+
+type selectProviderTemplateRenderer struct {
+	template *template.Template
+}
+
+func (r selectProviderTemplateRenderer) Render(form SelectProviderForm, w http.ResponseWriter, req *http.Request) {
+	w.Header().Add("Content-Type", "text/html")
+	w.WriteHeader(http.StatusOK)
+	if err := r.template.Execute(w, form); err != nil {
+		glog.Errorf("Unable to render provider selection template: %v", err)
+	}
+}
+
+const selectProviderTemplateDefault = `
+<p>Log in with:</p>
+<ul>
+{{ range .Providers }}<li><a href="{{ .URL }}">{{ .Name }}</a></li>
+{{ end }}
+</ul>
+`
@@ -0,0 +1,58 @@
+package selectprovider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServeHTTPSkipsPageForSingleProvider(t *testing.T) {
+	h := New([]Provider{{Name: "github", LoginURL: "/login/github"}}, DefaultSelectProviderFormRenderer)
+
+	req, _ := http.NewRequest("GET", "/select-provider?then=%2Fauthorize", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusFound {
+		t.Fatalf("expected redirect, got %d", w.Code)
+	}
+	if location := w.Header().Get("Location"); location != "/login/github?then=%2Fauthorize" {
+		t.Errorf("unexpected redirect location: %s", location)
+	}
+}
+
+func TestServeHTTPHonorsHint(t *testing.T) {
+	h := New([]Provider{
+		{Name: "github", LoginURL: "/login/github"},
+		{Name: "google", LoginURL: "/login/google"},
+	}, DefaultSelectProviderFormRenderer)
+
+	req, _ := http.NewRequest("GET", "/select-provider?idp=google", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusFound {
+		t.Fatalf("expected redirect, got %d", w.Code)
+	}
+	if location := w.Header().Get("Location"); location != "/login/google" {
+		t.Errorf("unexpected redirect location: %s", location)
+	}
+}
+
+func TestServeHTTPRendersSelectionPage(t *testing.T) {
+	h := New([]Provider{
+		{Name: "github", LoginURL: "/login/github"},
+		{Name: "google", LoginURL: "/login/google"},
+	}, DefaultSelectProviderFormRenderer)
+
+	req, _ := http.NewRequest("GET", "/select-provider", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if w.Body.Len() == 0 {
+		t.Errorf("expected a rendered selection page")
+	}
+}
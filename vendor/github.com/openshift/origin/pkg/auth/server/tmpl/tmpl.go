@@ -0,0 +1,24 @@
+// Package tmpl provides a small helper for loading customizable HTML templates used by
+// the oauth login and grant-approval pages, falling back to a safe compiled-in default
+// when no customization has been configured.
+package tmpl
+
+import (
+	"html/template"
+	"io/ioutil"
+)
+
+// LoadTemplate parses the named template from the file at path, or from defaultText if
+// path is empty. This lets operators override the branding and copy of a page served by
+// the oauth endpoints without losing the working default if they do not.
+func LoadTemplate(path, name, defaultText string) (*template.Template, error) {
+	text := defaultText
+	if len(path) > 0 {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		text = string(data)
+	}
+	return template.New(name).Parse(text)
+}
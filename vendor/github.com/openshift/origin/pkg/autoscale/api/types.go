@@ -0,0 +1,35 @@
+package api
+
+import (
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+)
+
+// A DeploymentConfigAutoscaler keeps the replica count of a DeploymentConfig's template
+// between MinReplicas and MaxReplicas. This snapshot has no CPU metrics source to observe,
+// so the control loop only enforces the Min/MaxReplicas bounds; CPUTargetPercentage is
+// recorded for forward compatibility with a real metrics-backed implementation.
+type DeploymentConfigAutoscaler struct {
+	api.JSONBase `json:",inline" yaml:",inline"`
+	Labels       map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+
+	// DeploymentConfigID is the ID of the DeploymentConfig this autoscaler manages.
+	DeploymentConfigID string `json:"deploymentConfigID,omitempty" yaml:"deploymentConfigID,omitempty"`
+
+	// MinReplicas is the lowest replica count the autoscaler will allow.
+	MinReplicas int `json:"minReplicas,omitempty" yaml:"minReplicas,omitempty"`
+
+	// MaxReplicas is the highest replica count the autoscaler will allow. Must be greater
+	// than or equal to MinReplicas.
+	MaxReplicas int `json:"maxReplicas,omitempty" yaml:"maxReplicas,omitempty"`
+
+	// CPUTargetPercentage is the average CPU utilization, as a percentage of each pod's
+	// request, to hold across the deployment's replicas. It is stored but not acted on:
+	// the control loop does not scale on CPU utilization.
+	CPUTargetPercentage int `json:"cpuTargetPercentage,omitempty" yaml:"cpuTargetPercentage,omitempty"`
+}
+
+// A DeploymentConfigAutoscalerList is a collection of DeploymentConfigAutoscalers.
+type DeploymentConfigAutoscalerList struct {
+	api.JSONBase `json:",inline" yaml:",inline"`
+	Items        []DeploymentConfigAutoscaler `json:"items,omitempty" yaml:"items,omitempty"`
+}
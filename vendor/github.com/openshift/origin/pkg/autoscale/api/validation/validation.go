@@ -0,0 +1,26 @@
+package validation
+
+import (
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	autoscaleapi "github.com/openshift/origin/pkg/autoscale/api"
+)
+
+// ValidateDeploymentConfigAutoscaler tests required fields on a DeploymentConfigAutoscaler.
+func ValidateDeploymentConfigAutoscaler(autoscaler *autoscaleapi.DeploymentConfigAutoscaler) errors.ErrorList {
+	result := errors.ErrorList{}
+
+	if len(autoscaler.DeploymentConfigID) == 0 {
+		result = append(result, errors.NewFieldRequired("DeploymentConfigID", autoscaler.DeploymentConfigID))
+	}
+	if autoscaler.MinReplicas < 0 {
+		result = append(result, errors.NewFieldInvalid("MinReplicas", autoscaler.MinReplicas))
+	}
+	if autoscaler.MaxReplicas < 1 {
+		result = append(result, errors.NewFieldInvalid("MaxReplicas", autoscaler.MaxReplicas))
+	}
+	if autoscaler.MaxReplicas < autoscaler.MinReplicas {
+		result = append(result, errors.NewFieldInvalid("MaxReplicas", autoscaler.MaxReplicas))
+	}
+
+	return result
+}
@@ -0,0 +1,83 @@
+// Package autoscale periodically reconciles each DeploymentConfigAutoscaler against the
+// DeploymentConfig it targets, clamping the config's template replica count to the
+// autoscaler's Min/MaxReplicas bounds. This snapshot has no CPU metrics source to observe,
+// so it cannot yet scale toward CPUTargetPercentage; it only keeps the replica count inside
+// the configured bounds, which is still enough to stop a manual or scripted scale from
+// pushing a config outside the range an operator has approved for it.
+package autoscale
+
+import (
+	"time"
+
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	"github.com/golang/glog"
+
+	autoscaleapi "github.com/openshift/origin/pkg/autoscale/api"
+	osclient "github.com/openshift/origin/pkg/client"
+)
+
+// Controller periodically reconciles every DeploymentConfigAutoscaler's target
+// DeploymentConfig against its Min/MaxReplicas bounds.
+type Controller struct {
+	osClient osclient.Interface
+}
+
+// NewController creates a new autoscale Controller.
+func NewController(osClient osclient.Interface) *Controller {
+	return &Controller{
+		osClient: osClient,
+	}
+}
+
+// Run begins periodically reconciling autoscalers, every period, until the process exits.
+func (c *Controller) Run(period time.Duration) {
+	ctx := kapi.NewContext()
+	go util.Forever(func() { c.synchronize(ctx) }, period)
+}
+
+// synchronize reconciles every DeploymentConfigAutoscaler currently in the registry.
+func (c *Controller) synchronize(ctx kapi.Context) {
+	autoscalers, err := c.osClient.ListDeploymentConfigAutoscalers(ctx, labels.Everything())
+	if err != nil {
+		glog.Errorf("Autoscaler synchronization error: %v (%#v)", err, err)
+		return
+	}
+
+	for i := range autoscalers.Items {
+		c.reconcile(ctx, &autoscalers.Items[i])
+	}
+}
+
+// reconcile clamps autoscaler's target DeploymentConfig's template replica count to
+// [autoscaler.MinReplicas, autoscaler.MaxReplicas].
+func (c *Controller) reconcile(ctx kapi.Context, autoscaler *autoscaleapi.DeploymentConfigAutoscaler) {
+	if len(autoscaler.DeploymentConfigID) == 0 {
+		return
+	}
+
+	config, err := c.osClient.GetDeploymentConfig(ctx, autoscaler.DeploymentConfigID)
+	if err != nil {
+		glog.Errorf("Error retrieving DeploymentConfig %s for autoscaler %s: %#v", autoscaler.DeploymentConfigID, autoscaler.ID, err)
+		return
+	}
+
+	replicas := config.Template.ControllerTemplate.Replicas
+	desired := replicas
+	if desired < autoscaler.MinReplicas {
+		desired = autoscaler.MinReplicas
+	}
+	if desired > autoscaler.MaxReplicas {
+		desired = autoscaler.MaxReplicas
+	}
+	if desired == replicas {
+		return
+	}
+
+	glog.Infof("Autoscaler %s adjusting DeploymentConfig %s replicas from %d to %d", autoscaler.ID, config.ID, replicas, desired)
+	config.Template.ControllerTemplate.Replicas = desired
+	if _, err := c.osClient.UpdateDeploymentConfig(ctx, config); err != nil {
+		glog.Errorf("Error updating DeploymentConfig %s for autoscaler %s: %#v", config.ID, autoscaler.ID, err)
+	}
+}
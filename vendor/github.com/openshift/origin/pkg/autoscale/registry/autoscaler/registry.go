@@ -0,0 +1,15 @@
+package autoscaler
+
+import (
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	api "github.com/openshift/origin/pkg/autoscale/api"
+)
+
+// Registry is an interface for things that know how to store DeploymentConfigAutoscalers.
+type Registry interface {
+	ListDeploymentConfigAutoscalers(selector labels.Selector) (*api.DeploymentConfigAutoscalerList, error)
+	GetDeploymentConfigAutoscaler(id string) (*api.DeploymentConfigAutoscaler, error)
+	CreateDeploymentConfigAutoscaler(autoscaler *api.DeploymentConfigAutoscaler) error
+	UpdateDeploymentConfigAutoscaler(autoscaler *api.DeploymentConfigAutoscaler) error
+	DeleteDeploymentConfigAutoscaler(id string) error
+}
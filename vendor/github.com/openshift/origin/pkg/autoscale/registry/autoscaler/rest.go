@@ -0,0 +1,108 @@
+package autoscaler
+
+import (
+	"fmt"
+
+	"code.google.com/p/go-uuid/uuid"
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	kubeerrors "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/apiserver"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/golang/glog"
+
+	autoscaleapi "github.com/openshift/origin/pkg/autoscale/api"
+	"github.com/openshift/origin/pkg/autoscale/api/validation"
+)
+
+// REST is an implementation of RESTStorage for the api server.
+type REST struct {
+	registry Registry
+}
+
+func NewREST(registry Registry) apiserver.RESTStorage {
+	return &REST{
+		registry: registry,
+	}
+}
+
+// New creates a new DeploymentConfigAutoscaler for use with Create and Update
+func (s *REST) New() runtime.Object {
+	return &autoscaleapi.DeploymentConfigAutoscaler{}
+}
+
+// List obtains a list of DeploymentConfigAutoscalers that match selector.
+func (s *REST) List(ctx kubeapi.Context, selector, fields labels.Selector) (runtime.Object, error) {
+	autoscalers, err := s.registry.ListDeploymentConfigAutoscalers(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	return autoscalers, nil
+}
+
+// Get obtains the DeploymentConfigAutoscaler specified by its id.
+func (s *REST) Get(ctx kubeapi.Context, id string) (runtime.Object, error) {
+	autoscaler, err := s.registry.GetDeploymentConfigAutoscaler(id)
+	if err != nil {
+		return nil, err
+	}
+	return autoscaler, err
+}
+
+// Delete asynchronously deletes the DeploymentConfigAutoscaler specified by its id.
+func (s *REST) Delete(ctx kubeapi.Context, id string) (<-chan runtime.Object, error) {
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		return &kubeapi.Status{Status: kubeapi.StatusSuccess}, s.registry.DeleteDeploymentConfigAutoscaler(id)
+	}), nil
+}
+
+// Create registers a given new DeploymentConfigAutoscaler instance to s.registry.
+func (s *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
+	autoscaler, ok := obj.(*autoscaleapi.DeploymentConfigAutoscaler)
+	if !ok {
+		return nil, fmt.Errorf("not a deploymentConfigAutoscaler: %#v", obj)
+	}
+
+	glog.Infof("Creating deploymentConfigAutoscaler with ID: %v", autoscaler.ID)
+
+	if len(autoscaler.ID) == 0 {
+		autoscaler.ID = uuid.NewUUID().String()
+	}
+
+	if errs := validation.ValidateDeploymentConfigAutoscaler(autoscaler); len(errs) > 0 {
+		return nil, kubeerrors.NewInvalid("deploymentConfigAutoscaler", autoscaler.ID, errs)
+	}
+
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		err := s.registry.CreateDeploymentConfigAutoscaler(autoscaler)
+		if err != nil {
+			return nil, err
+		}
+		return autoscaler, nil
+	}), nil
+}
+
+// Update replaces a given DeploymentConfigAutoscaler instance with an existing instance in
+// s.registry.
+func (s *REST) Update(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
+	autoscaler, ok := obj.(*autoscaleapi.DeploymentConfigAutoscaler)
+	if !ok {
+		return nil, fmt.Errorf("not a deploymentConfigAutoscaler: %#v", obj)
+	}
+	if len(autoscaler.ID) == 0 {
+		return nil, fmt.Errorf("id is unspecified: %#v", autoscaler)
+	}
+
+	if errs := validation.ValidateDeploymentConfigAutoscaler(autoscaler); len(errs) > 0 {
+		return nil, kubeerrors.NewInvalid("deploymentConfigAutoscaler", autoscaler.ID, errs)
+	}
+
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		err := s.registry.UpdateDeploymentConfigAutoscaler(autoscaler)
+		if err != nil {
+			return nil, err
+		}
+		return autoscaler, nil
+	}), nil
+}
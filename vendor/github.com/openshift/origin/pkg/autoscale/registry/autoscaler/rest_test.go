@@ -0,0 +1,160 @@
+package autoscaler
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/openshift/origin/pkg/autoscale/api"
+	"github.com/openshift/origin/pkg/autoscale/registry/test"
+)
+
+func TestListDeploymentConfigAutoscalersError(t *testing.T) {
+	mockRegistry := test.NewDeploymentConfigAutoscalerRegistry()
+	mockRegistry.Err = fmt.Errorf("test error")
+
+	storage := REST{
+		registry: mockRegistry,
+	}
+
+	autoscalers, err := storage.List(nil, nil, nil)
+	if err != mockRegistry.Err {
+		t.Errorf("Expected %#v, Got %#v", mockRegistry.Err, err)
+	}
+
+	if autoscalers != nil {
+		t.Errorf("Unexpected non-nil autoscalers list: %#v", autoscalers)
+	}
+}
+
+func TestListDeploymentConfigAutoscalersEmptyList(t *testing.T) {
+	mockRegistry := test.NewDeploymentConfigAutoscalerRegistry()
+	mockRegistry.Autoscalers = &api.DeploymentConfigAutoscalerList{
+		Items: []api.DeploymentConfigAutoscaler{},
+	}
+
+	storage := REST{
+		registry: mockRegistry,
+	}
+
+	autoscalers, err := storage.List(nil, labels.Everything(), labels.Everything())
+	if err != nil {
+		t.Errorf("Unexpected non-nil error: %#v", err)
+	}
+
+	if len(autoscalers.(*api.DeploymentConfigAutoscalerList).Items) != 0 {
+		t.Errorf("Unexpected non-zero autoscalers list: %#v", autoscalers)
+	}
+}
+
+func TestCreateDeploymentConfigAutoscalerBadObject(t *testing.T) {
+	storage := REST{}
+
+	channel, err := storage.Create(nil, &api.DeploymentConfigAutoscalerList{})
+	if channel != nil {
+		t.Errorf("Expected nil, got %v", channel)
+	}
+	if strings.Index(err.Error(), "not a deploymentConfigAutoscaler") == -1 {
+		t.Errorf("Expected 'not a deploymentConfigAutoscaler' error, got '%v'", err.Error())
+	}
+}
+
+func TestCreateDeploymentConfigAutoscalerMissingDeploymentConfigID(t *testing.T) {
+	storage := REST{registry: test.NewDeploymentConfigAutoscalerRegistry()}
+
+	channel, err := storage.Create(nil, &api.DeploymentConfigAutoscaler{
+		JSONBase:    kubeapi.JSONBase{ID: "foo"},
+		MaxReplicas: 3,
+	})
+	if channel != nil {
+		t.Errorf("Expected nil channel, got %v", channel)
+	}
+	if err == nil {
+		t.Error("Expected a validation error, got nil")
+	}
+}
+
+func TestCreateDeploymentConfigAutoscalerOK(t *testing.T) {
+	mockRegistry := test.NewDeploymentConfigAutoscalerRegistry()
+	storage := REST{registry: mockRegistry}
+
+	channel, err := storage.Create(nil, &api.DeploymentConfigAutoscaler{
+		JSONBase:           kubeapi.JSONBase{ID: "foo"},
+		DeploymentConfigID: "my-config",
+		MinReplicas:        1,
+		MaxReplicas:        3,
+	})
+	if channel == nil {
+		t.Errorf("Expected non-nil channel, got %v", channel)
+	}
+	if err != nil {
+		t.Errorf("Unexpected non-nil error: %#v", err)
+	}
+
+	select {
+	case result := <-channel:
+		autoscaler, ok := result.(*api.DeploymentConfigAutoscaler)
+		if !ok {
+			t.Errorf("Expected DeploymentConfigAutoscaler type, got: %#v", result)
+		}
+		if autoscaler.ID != "foo" {
+			t.Errorf("Unexpected autoscaler: %#v", autoscaler)
+		}
+	case <-time.After(50 * time.Millisecond):
+		t.Errorf("Timed out waiting for result")
+	}
+}
+
+func TestGetDeploymentConfigAutoscalerError(t *testing.T) {
+	mockRegistry := test.NewDeploymentConfigAutoscalerRegistry()
+	mockRegistry.Err = fmt.Errorf("bad")
+	storage := REST{registry: mockRegistry}
+
+	autoscaler, err := storage.Get(nil, "foo")
+	if autoscaler != nil {
+		t.Errorf("Unexpected non-nil autoscaler: %#v", autoscaler)
+	}
+	if err != mockRegistry.Err {
+		t.Errorf("Expected %#v, got %#v", mockRegistry.Err, err)
+	}
+}
+
+func TestUpdateDeploymentConfigAutoscalerMissingID(t *testing.T) {
+	storage := REST{}
+
+	channel, err := storage.Update(nil, &api.DeploymentConfigAutoscaler{})
+	if channel != nil {
+		t.Errorf("Expected nil, got %v", channel)
+	}
+	if strings.Index(err.Error(), "id is unspecified:") == -1 {
+		t.Errorf("Expected 'id is unspecified' error, got %v", err)
+	}
+}
+
+func TestDeleteDeploymentConfigAutoscaler(t *testing.T) {
+	mockRegistry := test.NewDeploymentConfigAutoscalerRegistry()
+	storage := REST{registry: mockRegistry}
+	channel, err := storage.Delete(nil, "foo")
+	if channel == nil {
+		t.Error("Unexpected nil channel")
+	}
+	if err != nil {
+		t.Errorf("Unexpected non-nil error: %#v", err)
+	}
+
+	select {
+	case result := <-channel:
+		status, ok := result.(*kubeapi.Status)
+		if !ok {
+			t.Errorf("Expected status type, got: %#v", result)
+		}
+		if status.Status != kubeapi.StatusSuccess {
+			t.Errorf("Expected status=success, got: %#v", status)
+		}
+	case <-time.After(50 * time.Millisecond):
+		t.Errorf("Timed out waiting for result")
+	}
+}
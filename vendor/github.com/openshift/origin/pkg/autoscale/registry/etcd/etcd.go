@@ -0,0 +1,73 @@
+package etcd
+
+import (
+	etcderr "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors/etcd"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
+
+	"github.com/openshift/origin/pkg/autoscale/api"
+)
+
+// Etcd implements autoscaler.Registry backed by etcd.
+type Etcd struct {
+	tools.EtcdHelper
+}
+
+// New creates an etcd registry.
+func New(helper tools.EtcdHelper) *Etcd {
+	return &Etcd{
+		EtcdHelper: helper,
+	}
+}
+
+func makeDeploymentConfigAutoscalerKey(id string) string {
+	return "/deploymentConfigAutoscalers/" + id
+}
+
+// ListDeploymentConfigAutoscalers obtains a list of DeploymentConfigAutoscalers.
+func (r *Etcd) ListDeploymentConfigAutoscalers(selector labels.Selector) (*api.DeploymentConfigAutoscalerList, error) {
+	autoscalers := api.DeploymentConfigAutoscalerList{}
+	err := r.ExtractList("/deploymentConfigAutoscalers", &autoscalers.Items, &autoscalers.ResourceVersion)
+	if err != nil {
+		return nil, err
+	}
+	filtered := []api.DeploymentConfigAutoscaler{}
+	for _, item := range autoscalers.Items {
+		if selector.Matches(labels.Set(item.Labels)) {
+			filtered = append(filtered, item)
+		}
+	}
+
+	autoscalers.Items = filtered
+	return &autoscalers, err
+}
+
+// GetDeploymentConfigAutoscaler gets a specific DeploymentConfigAutoscaler specified by its id.
+func (r *Etcd) GetDeploymentConfigAutoscaler(id string) (*api.DeploymentConfigAutoscaler, error) {
+	var autoscaler api.DeploymentConfigAutoscaler
+	key := makeDeploymentConfigAutoscalerKey(id)
+	err := r.ExtractObj(key, &autoscaler, false)
+	if err != nil {
+		return nil, etcderr.InterpretGetError(err, "deploymentConfigAutoscaler", id)
+	}
+	return &autoscaler, nil
+}
+
+// CreateDeploymentConfigAutoscaler creates a new DeploymentConfigAutoscaler.
+func (r *Etcd) CreateDeploymentConfigAutoscaler(autoscaler *api.DeploymentConfigAutoscaler) error {
+	err := r.CreateObj(makeDeploymentConfigAutoscalerKey(autoscaler.ID), autoscaler, 0)
+	return etcderr.InterpretCreateError(err, "deploymentConfigAutoscaler", autoscaler.ID)
+}
+
+// UpdateDeploymentConfigAutoscaler replaces an existing DeploymentConfigAutoscaler.
+func (r *Etcd) UpdateDeploymentConfigAutoscaler(autoscaler *api.DeploymentConfigAutoscaler) error {
+	err := r.SetObj(makeDeploymentConfigAutoscalerKey(autoscaler.ID), autoscaler)
+	return etcderr.InterpretUpdateError(err, "deploymentConfigAutoscaler", autoscaler.ID)
+}
+
+// DeleteDeploymentConfigAutoscaler deletes a DeploymentConfigAutoscaler specified by its id.
+func (r *Etcd) DeleteDeploymentConfigAutoscaler(id string) error {
+	key := makeDeploymentConfigAutoscalerKey(id)
+	err := r.Delete(key, false)
+	return etcderr.InterpretDeleteError(err, "deploymentConfigAutoscaler", id)
+}
@@ -0,0 +1,165 @@
+package etcd
+
+import (
+	"fmt"
+	"testing"
+
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
+	"github.com/coreos/go-etcd/etcd"
+
+	"github.com/openshift/origin/pkg/api/latest"
+	"github.com/openshift/origin/pkg/autoscale/api"
+)
+
+func NewTestEtcd(client tools.EtcdClient) *Etcd {
+	return New(tools.EtcdHelper{Client: client, Codec: latest.Codec, ResourceVersioner: latest.ResourceVersioner})
+}
+
+func TestEtcdListEmptyDeploymentConfigAutoscalers(t *testing.T) {
+	fakeClient := tools.NewFakeEtcdClient(t)
+	key := "/deploymentConfigAutoscalers"
+	fakeClient.Data[key] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Nodes: []*etcd.Node{},
+			},
+		},
+		E: nil,
+	}
+	registry := NewTestEtcd(fakeClient)
+	autoscalers, err := registry.ListDeploymentConfigAutoscalers(labels.Everything())
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if len(autoscalers.Items) != 0 {
+		t.Errorf("Unexpected autoscalers list: %#v", autoscalers)
+	}
+}
+
+func TestEtcdListErrorDeploymentConfigAutoscalers(t *testing.T) {
+	fakeClient := tools.NewFakeEtcdClient(t)
+	key := "/deploymentConfigAutoscalers"
+	fakeClient.Data[key] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: nil,
+		},
+		E: fmt.Errorf("some error"),
+	}
+	registry := NewTestEtcd(fakeClient)
+	autoscalers, err := registry.ListDeploymentConfigAutoscalers(labels.Everything())
+	if err == nil {
+		t.Error("unexpected nil error")
+	}
+
+	if autoscalers != nil {
+		t.Errorf("Unexpected non-nil autoscalers: %#v", autoscalers)
+	}
+}
+
+func TestEtcdListEverythingDeploymentConfigAutoscalers(t *testing.T) {
+	fakeClient := tools.NewFakeEtcdClient(t)
+	key := "/deploymentConfigAutoscalers"
+	fakeClient.Data[key] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Nodes: []*etcd.Node{
+					{
+						Value: runtime.EncodeOrDie(latest.Codec, &api.DeploymentConfigAutoscaler{JSONBase: kubeapi.JSONBase{ID: "foo"}}),
+					},
+					{
+						Value: runtime.EncodeOrDie(latest.Codec, &api.DeploymentConfigAutoscaler{JSONBase: kubeapi.JSONBase{ID: "bar"}}),
+					},
+				},
+			},
+		},
+		E: nil,
+	}
+	registry := NewTestEtcd(fakeClient)
+	autoscalers, err := registry.ListDeploymentConfigAutoscalers(labels.Everything())
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if len(autoscalers.Items) != 2 || autoscalers.Items[0].ID != "foo" || autoscalers.Items[1].ID != "bar" {
+		t.Errorf("Unexpected autoscalers list: %#v", autoscalers)
+	}
+}
+
+func TestEtcdGetDeploymentConfigAutoscalers(t *testing.T) {
+	fakeClient := tools.NewFakeEtcdClient(t)
+	fakeClient.Set("/deploymentConfigAutoscalers/foo", runtime.EncodeOrDie(latest.Codec, &api.DeploymentConfigAutoscaler{JSONBase: kubeapi.JSONBase{ID: "foo"}}), 0)
+	registry := NewTestEtcd(fakeClient)
+	autoscaler, err := registry.GetDeploymentConfigAutoscaler("foo")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if autoscaler.ID != "foo" {
+		t.Errorf("Unexpected autoscaler: %#v", autoscaler)
+	}
+}
+
+func TestEtcdCreateDeploymentConfigAutoscaler(t *testing.T) {
+	fakeClient := tools.NewFakeEtcdClient(t)
+	fakeClient.TestIndex = true
+	registry := NewTestEtcd(fakeClient)
+	err := registry.CreateDeploymentConfigAutoscaler(&api.DeploymentConfigAutoscaler{JSONBase: kubeapi.JSONBase{ID: "foo"}})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	resp, err := fakeClient.Get("/deploymentConfigAutoscalers/foo", false, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var autoscaler api.DeploymentConfigAutoscaler
+	err = latest.Codec.DecodeInto([]byte(resp.Node.Value), &autoscaler)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if autoscaler.ID != "foo" {
+		t.Errorf("Unexpected autoscaler: %#v %s", autoscaler, resp.Node.Value)
+	}
+}
+
+func TestEtcdUpdateOkDeploymentConfigAutoscaler(t *testing.T) {
+	fakeClient := tools.NewFakeEtcdClient(t)
+	registry := NewTestEtcd(fakeClient)
+	err := registry.UpdateDeploymentConfigAutoscaler(&api.DeploymentConfigAutoscaler{JSONBase: kubeapi.JSONBase{ID: "foo"}, MaxReplicas: 5})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	resp, err := fakeClient.Get("/deploymentConfigAutoscalers/foo", false, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var autoscaler api.DeploymentConfigAutoscaler
+	err = latest.Codec.DecodeInto([]byte(resp.Node.Value), &autoscaler)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if autoscaler.MaxReplicas != 5 {
+		t.Errorf("Unexpected autoscaler: %#v", autoscaler)
+	}
+}
+
+func TestEtcdDeleteDeploymentConfigAutoscaler(t *testing.T) {
+	fakeClient := tools.NewFakeEtcdClient(t)
+	fakeClient.Set("/deploymentConfigAutoscalers/foo", runtime.EncodeOrDie(latest.Codec, &api.DeploymentConfigAutoscaler{JSONBase: kubeapi.JSONBase{ID: "foo"}}), 0)
+	registry := NewTestEtcd(fakeClient)
+	err := registry.DeleteDeploymentConfigAutoscaler("foo")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(fakeClient.DeletedKeys) != 1 {
+		t.Errorf("Expected 1 delete, found %#v", fakeClient.DeletedKeys)
+	}
+	if fakeClient.DeletedKeys[0] != "/deploymentConfigAutoscalers/foo" {
+		t.Errorf("Unexpected key: %s, expected %s", fakeClient.DeletedKeys[0], "/deploymentConfigAutoscalers/foo")
+	}
+}
@@ -0,0 +1,56 @@
+package test
+
+import (
+	"sync"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/openshift/origin/pkg/autoscale/api"
+)
+
+type DeploymentConfigAutoscalerRegistry struct {
+	Err         error
+	Autoscaler  *api.DeploymentConfigAutoscaler
+	Autoscalers *api.DeploymentConfigAutoscalerList
+	sync.Mutex
+}
+
+func NewDeploymentConfigAutoscalerRegistry() *DeploymentConfigAutoscalerRegistry {
+	return &DeploymentConfigAutoscalerRegistry{}
+}
+
+func (r *DeploymentConfigAutoscalerRegistry) ListDeploymentConfigAutoscalers(selector labels.Selector) (*api.DeploymentConfigAutoscalerList, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.Autoscalers, r.Err
+}
+
+func (r *DeploymentConfigAutoscalerRegistry) GetDeploymentConfigAutoscaler(id string) (*api.DeploymentConfigAutoscaler, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.Autoscaler, r.Err
+}
+
+func (r *DeploymentConfigAutoscalerRegistry) CreateDeploymentConfigAutoscaler(autoscaler *api.DeploymentConfigAutoscaler) error {
+	r.Lock()
+	defer r.Unlock()
+
+	r.Autoscaler = autoscaler
+	return r.Err
+}
+
+func (r *DeploymentConfigAutoscalerRegistry) UpdateDeploymentConfigAutoscaler(autoscaler *api.DeploymentConfigAutoscaler) error {
+	r.Lock()
+	defer r.Unlock()
+
+	r.Autoscaler = autoscaler
+	return r.Err
+}
+
+func (r *DeploymentConfigAutoscalerRegistry) DeleteDeploymentConfigAutoscaler(id string) error {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.Err
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
 )
 
 // Build encapsulates the inputs needed to produce a new deployable image, as well as
@@ -18,6 +19,80 @@ type Build struct {
 
 	// PodID is the id of the pod that is used to execute the build
 	PodID string `json:"podID,omitempty" yaml:"podID,omitempty"`
+
+	// PodRetryCount is incremented by BuildController each time it retries creating this
+	// build's pod after a transient failure, and LastPodRetry records when that most
+	// recent attempt was made. Together they drive the controller's exponential backoff
+	// between retries.
+	PodRetryCount int       `json:"podRetryCount,omitempty" yaml:"podRetryCount,omitempty"`
+	LastPodRetry  util.Time `json:"lastPodRetry,omitempty" yaml:"lastPodRetry,omitempty"`
+
+	// StartTimestamp records when this Build's pod was created and it first moved out of
+	// Pending. It is nil until then, so CreationTimestamp to StartTimestamp is how long the
+	// build queued and StartTimestamp to CompletionTimestamp is how long it ran.
+	StartTimestamp *util.Time `json:"startTimestamp,omitempty" yaml:"startTimestamp,omitempty"`
+
+	// CompletionTimestamp records when this Build first reached a terminal status
+	// (Complete, Failed, or Error). It is nil until then, and is what the garbage
+	// collector's build pod sweep measures its retention period against.
+	CompletionTimestamp *util.Time `json:"completionTimestamp,omitempty" yaml:"completionTimestamp,omitempty"`
+
+	// Finalizers is the list of names registered by controllers that must run cleanup
+	// before this build can actually be removed. Delete only takes effect once this list
+	// is empty; until then, DeletionTimestamp marks it as pending.
+	Finalizers []string `json:"finalizers,omitempty" yaml:"finalizers,omitempty"`
+
+	// DeletionTimestamp is set when a Delete is requested on a build that still has
+	// pending Finalizers, and is unset otherwise.
+	DeletionTimestamp util.Time `json:"deletionTimestamp,omitempty" yaml:"deletionTimestamp,omitempty"`
+
+	// Artifacts holds build-produced output, such as test reports or coverage results,
+	// keyed by a caller-chosen name. The builder pod deposits these by calling the
+	// buildArtifacts endpoint, so results don't have to be fished out of pod logs.
+	Artifacts map[string]string `json:"artifacts,omitempty" yaml:"artifacts,omitempty"`
+
+	// BuildConfigID is the ID of the BuildConfig this Build was created from, if any. It
+	// is set by whatever creates the Build on the BuildConfig's behalf, such as the
+	// webhook controller, and lets the build controller maintain that BuildConfig's
+	// status summary as this Build progresses.
+	BuildConfigID string `json:"buildConfigID,omitempty" yaml:"buildConfigID,omitempty"`
+
+	// GenerateName, if specified on Create and ID is empty, instructs the server to fill in
+	// ID with this value followed by a random suffix, retrying on a collision, so a caller
+	// such as BuildConfig instantiation doesn't have to invent a unique ID itself.
+	GenerateName string `json:"generateName,omitempty" yaml:"generateName,omitempty"`
+
+	// UID is set by the server on creation and never changes for the lifetime of this
+	// Build, even if its ID is later reused by a different Build. Owner references that
+	// need to survive ID reuse, such as the pod-to-Build labels the garbage collector
+	// checks, should compare UID rather than ID.
+	UID string `json:"uid,omitempty" yaml:"uid,omitempty"`
+
+	// Events records notable occurrences in this build's lifecycle, such as pod creation,
+	// a state transition, or a failure reason, so `describe`-style tooling can show a
+	// build's history without scraping controller logs. BuildController caps the number
+	// of entries it keeps; see MaxBuildEvents.
+	Events []BuildEvent `json:"events,omitempty" yaml:"events,omitempty"`
+}
+
+// PodCleanupFinalizer is set on every new Build by default. The garbage collector
+// clears it once the Build's pod has been removed, allowing a pending Delete to
+// complete.
+const PodCleanupFinalizer = "openshift.io/pod-cleanup"
+
+// BuildEvent is a single notable occurrence in a Build's lifecycle, recorded by
+// BuildController against the Build's Events list.
+type BuildEvent struct {
+	// Reason is a short, machine-readable identifier for what happened, such as
+	// "PodCreated" or "BuildTimedOut".
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+
+	// Message is an optional human-readable detail, such as the error that caused
+	// a failure.
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+
+	// Timestamp is when the event occurred.
+	Timestamp util.Time `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
 }
 
 // BuildInput defines the type of build and input parameters for a given build
@@ -32,6 +107,11 @@ type BuildInput struct {
 	// SourceRef is the branch/tag/ref to build.
 	SourceRef string `json:"sourceRef,omitempty" yaml:"sourceRef,omitempty"`
 
+	// Revision identifies the specific source control commit this build was triggered
+	// from, populated by a build trigger such as the GitHub webhook plugin when the
+	// payload it receives identifies one. It is nil for a Build with no such trigger.
+	Revision *SourceRevision `json:"revision,omitempty" yaml:"revision,omitempty"`
+
 	// ImageTag is the tag to give to the image resulting from the build
 	ImageTag string `json:"imageTag,omitempty" yaml:"imageTag,omitempty"`
 
@@ -40,6 +120,40 @@ type BuildInput struct {
 
 	// BuilderImage is the image used to execute the build when running STI builds
 	BuilderImage string `json:"builderImage,omitempty" yaml:"builderImage,omitempty"`
+
+	// JenkinsInfo configures the Jenkins job to trigger when running Jenkins builds.
+	// It is only consulted when Type is JenkinsBuildType.
+	JenkinsInfo *JenkinsBuildInput `json:"jenkinsInfo,omitempty" yaml:"jenkinsInfo,omitempty"`
+
+	// PushSecretName is the ID of the Secret holding the credentials used to push the
+	// resulting image to Registry. There is no separate output type to hang this off
+	// of, so it lives alongside the other input parameters.
+	PushSecretName string `json:"pushSecretName,omitempty" yaml:"pushSecretName,omitempty"`
+
+	// PullSecretName is the ID of the Secret holding the credentials used to pull
+	// BuilderImage, for STI builds that use a private builder image.
+	PullSecretName string `json:"pullSecretName,omitempty" yaml:"pullSecretName,omitempty"`
+}
+
+// SourceRevision identifies the source control commit a Build was triggered from.
+type SourceRevision struct {
+	// Commit is the source control commit ID, such as a git SHA.
+	Commit string `json:"commit,omitempty" yaml:"commit,omitempty"`
+
+	// Author is the name and email of the commit's author, if known.
+	Author string `json:"author,omitempty" yaml:"author,omitempty"`
+
+	// Message is the commit message, if known.
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// JenkinsBuildInput identifies the Jenkins job an external build delegates to.
+type JenkinsBuildInput struct {
+	// JenkinsURL is the base URL of the Jenkins server that runs JobName.
+	JenkinsURL string `json:"jenkinsURL,omitempty" yaml:"jenkinsURL,omitempty"`
+
+	// JobName is the name of the Jenkins job to trigger for this build.
+	JobName string `json:"jobName,omitempty" yaml:"jobName,omitempty"`
 }
 
 // BuildConfig contains the inputs needed to produce a new deployable image
@@ -52,6 +166,43 @@ type BuildConfig struct {
 
 	// Secret used to validate requests.
 	Secret string `json:"secret,omitempty" yaml:"secret,omitempty"`
+
+	// PreviousSecret, if set, is accepted alongside Secret so a webhook secret can be
+	// rotated without a window where a hook still configured with the old secret is
+	// rejected.
+	PreviousSecret string `json:"previousSecret,omitempty" yaml:"previousSecret,omitempty"`
+
+	// PreviousSecretExpiry is when PreviousSecret stops being accepted. A nil expiry means
+	// PreviousSecret, if set, is accepted indefinitely.
+	PreviousSecretExpiry *util.Time `json:"previousSecretExpiry,omitempty" yaml:"previousSecretExpiry,omitempty"`
+
+	// LastBuildID is the ID of the most recently synced Build created from this
+	// BuildConfig.
+	LastBuildID string `json:"lastBuildID,omitempty" yaml:"lastBuildID,omitempty"`
+
+	// LastBuildStatus is the status LastBuildID was most recently observed in.
+	LastBuildStatus BuildStatus `json:"lastBuildStatus,omitempty" yaml:"lastBuildStatus,omitempty"`
+
+	// LastSuccessfulBuildID is the ID of the most recent Build created from this
+	// BuildConfig to reach BuildComplete.
+	LastSuccessfulBuildID string `json:"lastSuccessfulBuildID,omitempty" yaml:"lastSuccessfulBuildID,omitempty"`
+
+	// ConsecutiveFailures counts the Builds created from this BuildConfig that have
+	// finished as BuildFailed or BuildError since the last BuildComplete, so dashboards
+	// can flag a config that's stopped succeeding without listing and sorting its builds.
+	ConsecutiveFailures int `json:"consecutiveFailures,omitempty" yaml:"consecutiveFailures,omitempty"`
+
+	// Paused stops the webhook from triggering new Builds from this BuildConfig without
+	// removing its Secret. It is set automatically once ConsecutiveFailures reaches
+	// MaxConsecutiveBuildFailures, to break a loop of a broken base image or Dockerfile
+	// endlessly retriggering failing builds, and can be cleared once the underlying
+	// problem is fixed.
+	Paused bool `json:"paused,omitempty" yaml:"paused,omitempty"`
+
+	// Serial forces Builds created from this BuildConfig to run one at a time, in the
+	// order they were created, so a burst of triggered builds can't complete out of order
+	// and leave ImageTag pointing at an image older than what an earlier build produced.
+	Serial bool `json:"serial,omitempty" yaml:"serial,omitempty"`
 }
 
 // BuildType is a type of build (docker, sti, etc)
@@ -65,6 +216,12 @@ const (
 	// STIBuildType is a build using Source to Image using a git repository
 	// and a builder image
 	STIBuildType BuildType = "sti"
+
+	// JenkinsBuildType delegates the build to a Jenkins job identified by
+	// BuildInput.JenkinsInfo, for existing Jenkins jobs that aren't being rewritten as
+	// docker or sti builds. The build controller triggers the job and then waits for
+	// the job to report status back via UpdateBuildStatus, rather than watching a pod.
+	JenkinsBuildType BuildType = "jenkins"
 )
 
 // BuildStatus represents the status of a Build at a point in time.
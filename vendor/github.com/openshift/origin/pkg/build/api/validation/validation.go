@@ -23,17 +23,44 @@ func ValidateBuildConfig(config *api.BuildConfig) errs.ErrorList {
 	if len(config.ID) == 0 {
 		allErrs = append(allErrs, errs.NewFieldRequired("id", config.ID))
 	}
+	allErrs = append(allErrs, validateConfigIDLength(config.ID)...)
 	allErrs = append(allErrs, validateBuildInput(&config.DesiredInput).Prefix("desiredInput")...)
 	return allErrs
 }
 
+// maxBuildSuffixLength reserves room for the suffix a Build generated from this
+// BuildConfig's ID would carry. This snapshot gives each Build its own independent
+// generated ID rather than deriving one from the config, but rejecting a config ID
+// that wouldn't leave room for such a suffix now avoids the failure once it does.
+const maxBuildSuffixLength = 10
+
+// dnsLabelMaxLength mirrors the DNS label length limit (RFC 1035/1123) enforced
+// elsewhere in validation; it isn't exported by the upstream util package.
+const dnsLabelMaxLength = 63
+
+// validateConfigIDLength rejects a BuildConfig ID that is already too long to have
+// maxBuildSuffixLength characters appended to it and still fit within a DNS label.
+func validateConfigIDLength(id string) errs.ErrorList {
+	result := errs.ErrorList{}
+	if len(id) > dnsLabelMaxLength-maxBuildSuffixLength {
+		result = append(result, errs.NewFieldTooLong("id", id))
+	}
+	return result
+}
+
 func validateBuildInput(input *api.BuildInput) errs.ErrorList {
 	allErrs := errs.ErrorList{}
-	if len(input.SourceURI) == 0 {
+
+	// Jenkins builds are driven by a Jenkins job rather than a source checkout, so
+	// SourceURI isn't applicable to them.
+	if input.Type == api.JenkinsBuildType {
+		allErrs = append(allErrs, validateJenkinsInfo(input.JenkinsInfo).Prefix("jenkinsInfo")...)
+	} else if len(input.SourceURI) == 0 {
 		allErrs = append(allErrs, errs.NewFieldRequired("sourceURI", input.SourceURI))
 	} else if !isValidURL(input.SourceURI) {
 		allErrs = append(allErrs, errs.NewFieldInvalid("sourceURI", input.SourceURI))
 	}
+
 	if len(input.ImageTag) == 0 {
 		allErrs = append(allErrs, errs.NewFieldRequired("imageTag", input.ImageTag))
 	}
@@ -49,6 +76,23 @@ func validateBuildInput(input *api.BuildInput) errs.ErrorList {
 	return allErrs
 }
 
+func validateJenkinsInfo(info *api.JenkinsBuildInput) errs.ErrorList {
+	allErrs := errs.ErrorList{}
+	if info == nil {
+		allErrs = append(allErrs, errs.NewFieldRequired("jenkinsInfo", info))
+		return allErrs
+	}
+	if len(info.JenkinsURL) == 0 {
+		allErrs = append(allErrs, errs.NewFieldRequired("jenkinsURL", info.JenkinsURL))
+	} else if !isValidURL(info.JenkinsURL) {
+		allErrs = append(allErrs, errs.NewFieldInvalid("jenkinsURL", info.JenkinsURL))
+	}
+	if len(info.JobName) == 0 {
+		allErrs = append(allErrs, errs.NewFieldRequired("jobName", info.JobName))
+	}
+	return allErrs
+}
+
 func isValidURL(uri string) bool {
 	_, err := url.Parse(uri)
 	return err == nil
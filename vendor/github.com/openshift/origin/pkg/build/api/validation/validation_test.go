@@ -65,6 +65,20 @@ func TestBuildConfigValidationFailure(t *testing.T) {
 	}
 }
 
+func TestBuildConfigValidationIDTooLong(t *testing.T) {
+	buildConfig := &api.BuildConfig{
+		JSONBase: kubeapi.JSONBase{ID: "this-config-id-is-far-too-long-to-leave-any-room-for-a-generated-build-suffix"},
+		DesiredInput: api.BuildInput{
+			Type:      api.DockerBuildType,
+			SourceURI: "http://github.com/my/repository",
+			ImageTag:  "repository/data",
+		},
+	}
+	if result := ValidateBuildConfig(buildConfig); len(result) != 1 {
+		t.Errorf("Unexpected validation result: %v", result)
+	}
+}
+
 func TestValidateBuildInput(t *testing.T) {
 	errorCases := map[string]*api.BuildInput{
 		"No source URI": &api.BuildInput{
@@ -104,3 +118,36 @@ func TestValidateBuildInput(t *testing.T) {
 		// TODO: Verify we got the right type of validation error.
 	}
 }
+
+func BenchmarkValidateBuild(b *testing.B) {
+	b.ReportAllocs()
+	build := &api.Build{
+		JSONBase: kubeapi.JSONBase{ID: "buildId"},
+		Input: api.BuildInput{
+			Type:      api.DockerBuildType,
+			SourceURI: "http://github.com/my/repository",
+			ImageTag:  "repository/data",
+		},
+		Status: api.BuildNew,
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ValidateBuild(build)
+	}
+}
+
+func BenchmarkValidateBuildConfig(b *testing.B) {
+	b.ReportAllocs()
+	buildConfig := &api.BuildConfig{
+		JSONBase: kubeapi.JSONBase{ID: "configId"},
+		DesiredInput: api.BuildInput{
+			Type:      api.DockerBuildType,
+			SourceURI: "http://github.com/my/repository",
+			ImageTag:  "repository/data",
+		},
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ValidateBuildConfig(buildConfig)
+	}
+}
@@ -2,23 +2,43 @@ package build
 
 import (
 	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
 	kubeclient "github.com/GoogleCloudPlatform/kubernetes/pkg/client"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
 
-	"github.com/golang/glog"
 	"github.com/openshift/origin/pkg/build/api"
 	osclient "github.com/openshift/origin/pkg/client"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+	projectapi "github.com/openshift/origin/pkg/project/api"
+	secretapi "github.com/openshift/origin/pkg/secret/api"
+	"github.com/openshift/origin/pkg/util/clog"
+	"github.com/openshift/origin/pkg/util/concurrent"
+	"github.com/openshift/origin/pkg/webhook/notify"
 )
 
+// log is the build controller's component logger. Its verbosity can be raised at runtime
+// through the clog admin endpoint, e.g. to see per-build sync detail while debugging a stuck
+// build without restarting the master at a higher glog -v level.
+var log = clog.New("build")
+
 // BuildJobStrategy represents a strategy for executing a build by
-// creating a pod definition that will execute the build
+// creating a pod definition that will execute the build. pushSecret and
+// pullSecret are resolved by the controller from build.Input.PushSecretName
+// and PullSecretName, and are nil when no such secret was named. sourceCacheURL
+// is the project's git mirror/cache, if it has one configured, and is empty
+// otherwise.
 type BuildJobStrategy interface {
-	CreateBuildPod(build *api.Build) (*kapi.Pod, error)
+	CreateBuildPod(build *api.Build, pushSecret, pullSecret *secretapi.Secret, sourceCacheURL string) (*kapi.Pod, error)
 }
 
 // BuildController watches build resources and manages their state
@@ -27,59 +47,353 @@ type BuildController struct {
 	kubeClient      kubeclient.Interface
 	buildStrategies map[api.BuildType]BuildJobStrategy
 	timeout         int
+	// notifier delivers a "build.complete" or "build.failed" event whenever a build
+	// reaches a terminal status. May be nil, in which case no notifications are sent.
+	notifier *notify.Notifier
+
+	// healthLock guards watchFailures and resyncFailures, which are written from the
+	// watchBuilds and resyncBuilds goroutines and read from Healthy, possibly by a
+	// third goroutine such as an HTTP health check handler.
+	healthLock     sync.Mutex
+	watchFailures  int
+	resyncFailures int
+
+	// maxInFlight caps how many builds this controller will move from Pending to Running
+	// at once; 0 means unlimited. inFlight tracks the IDs of builds it currently believes
+	// are Running, so a burst of builds created at once is throttled instead of all having
+	// their pods created simultaneously. A build kept at Pending by this limit is picked up
+	// again, in roughly the order it was first seen, the next time it's synced.
+	maxInFlight int
+	inFlight    *concurrent.StringSet
 }
 
-// NewBuildController creates a new build controller
+// NewBuildController creates a new build controller. maxInFlight caps how many builds are
+// moved from Pending to Running at once; pass 0 for no limit.
 func NewBuildController(kc kubeclient.Interface,
 	oc osclient.Interface,
 	strategies map[api.BuildType]BuildJobStrategy,
-	timeout int) *BuildController {
+	timeout int,
+	notifier *notify.Notifier,
+	maxInFlight int) *BuildController {
 
-	glog.Infof("Creating build controller with timeout=%d", timeout)
+	log.Infof(2, "Creating build controller with timeout=%d, maxInFlight=%d", timeout, maxInFlight)
 
 	bc := &BuildController{
 		kubeClient:      kc,
 		osClient:        oc,
 		buildStrategies: strategies,
 		timeout:         timeout,
+		notifier:        notifier,
+		maxInFlight:     maxInFlight,
+		inFlight:        concurrent.NewStringSet(),
 	}
 	return bc
 
 }
 
-// Run begins watching and syncing build jobs onto the cluster.
+// Run begins watching and syncing build jobs onto the cluster. Builds transition as soon as
+// they're created or updated, driven by watchBuilds below; resyncBuilds runs a full list on
+// every period purely as a fallback, to pick up a build whose watch event was missed (a
+// watch reconnecting after a dropped connection, for example) or a running build whose pod
+// changed state without the build object itself changing.
 func (bc *BuildController) Run(period time.Duration) {
 	ctx := kapi.NewContext()
-	syncTime := time.Tick(period)
-	go util.Forever(func() { bc.watchBuilds(ctx, syncTime) }, period)
+	go util.Forever(func() { bc.watchBuilds(ctx) }, period)
+	go util.Forever(func() { bc.resyncBuilds(ctx) }, period)
 }
 
-// The main sync loop. Iterates over current builds and delegates syncing.
-func (bc *BuildController) watchBuilds(ctx kapi.Context, syncTime <-chan time.Time) {
-	for {
-		select {
-		case <-syncTime:
-			builds, err := bc.osClient.ListBuilds(ctx, labels.Everything())
-			if err != nil {
-				glog.Errorf("Error listing builds: %v (%#v)", err, err)
-				return
-			}
-			for _, build := range builds.Items {
-				nextStatus, err := bc.synchronize(ctx, &build)
-				if err != nil {
-					glog.Errorf("Error synchronizing build ID %v: %#v", build.ID, err)
-				}
-
-				if nextStatus != build.Status {
-					build.Status = nextStatus
-					if _, err := bc.osClient.UpdateBuild(ctx, &build); err != nil {
-						glog.Errorf("Error updating build ID %v to status %v: %#v", build.ID, nextStatus, err)
-					}
-				}
-			}
+// watchBuilds opens a watch on builds and syncs each one as soon as its Added or Modified
+// event arrives. If the watch ends, for any reason, it returns so util.Forever can open a
+// new one; a build already in progress is picked up again in the meantime by resyncBuilds.
+// If opening the watch itself fails, e.g. because the API server is unreachable, it backs
+// off before returning so a sustained outage isn't retried every sync period.
+func (bc *BuildController) watchBuilds(ctx kapi.Context) {
+	w, err := bc.osClient.WatchBuilds(ctx, labels.Everything(), labels.Everything(), 0)
+	failures := bc.recordWatchResult(err)
+	if err != nil {
+		log.Errorf("Error watching builds: %v (%#v)", err, err)
+		backoff := apiRetryBackoff(failures)
+		log.Errorf("Waiting %v before reopening the build watch", backoff)
+		time.Sleep(backoff)
+		return
+	}
+	defer w.Stop()
+	for event := range w.ResultChan() {
+		build, ok := event.Object.(*api.Build)
+		if !ok {
+			log.Errorf("Unexpected object from build watch: %#v", event.Object)
+			continue
+		}
+		if event.Type == watch.Error {
+			log.Errorf("Error event from build watch for build ID %v: %#v", build.ID, build)
+			continue
+		}
+		bc.syncBuild(ctx, build)
+	}
+}
+
+// resyncBuilds lists every build once and syncs it (Run calls it every sync period), as a fallback for
+// whatever watchBuilds might have missed. If the list itself fails, e.g. because the API
+// server is unreachable, it backs off before returning so a sustained outage is retried
+// with increasing patience instead of hot-looping every sync period.
+func (bc *BuildController) resyncBuilds(ctx kapi.Context) {
+	builds, err := bc.osClient.ListBuilds(ctx, labels.Everything())
+	failures := bc.recordResyncResult(err)
+	if err != nil {
+		log.Errorf("Error listing builds: %v (%#v)", err, err)
+		backoff := apiRetryBackoff(failures)
+		log.Errorf("Waiting %v before the next build resync attempt", backoff)
+		time.Sleep(backoff)
+		return
+	}
+	for i := range builds.Items {
+		bc.syncBuild(ctx, &builds.Items[i])
+	}
+}
+
+// apiRetryBaseBackoff and apiRetryMaxBackoff bound the exponential backoff applied to
+// watchBuilds and resyncBuilds after a failure talking to the API server, so a sustained
+// outage is retried with increasing patience instead of hot-looping every sync period.
+const (
+	apiRetryBaseBackoff = time.Second
+	apiRetryMaxBackoff  = time.Minute
+)
+
+// apiRetryBackoff returns how long to wait before the consecutiveFailures'th retry of a
+// build list or watch, with up to 50% jitter so many controllers backing off at once don't
+// all retry in lockstep.
+func apiRetryBackoff(consecutiveFailures int) time.Duration {
+	backoff := apiRetryBaseBackoff * time.Duration(1<<uint(consecutiveFailures))
+	if backoff <= 0 || backoff > apiRetryMaxBackoff {
+		backoff = apiRetryMaxBackoff
+	}
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff)/2))
+}
+
+// unhealthyFailureThreshold is how many consecutive watch or resync failures
+// BuildController tolerates before Healthy reports false.
+const unhealthyFailureThreshold = 3
+
+// Healthy reports whether builds are still being synced, so a caller such as a readiness
+// probe can detect a sustained API server outage instead of only seeing repeated log
+// lines. It returns false once either the watch or the resync loop has failed
+// unhealthyFailureThreshold times in a row, and recovers automatically as soon as that
+// loop succeeds again.
+func (bc *BuildController) Healthy() bool {
+	bc.healthLock.Lock()
+	defer bc.healthLock.Unlock()
+	return bc.watchFailures < unhealthyFailureThreshold && bc.resyncFailures < unhealthyFailureThreshold
+}
+
+// recordWatchResult updates watchFailures based on err and returns the updated count.
+func (bc *BuildController) recordWatchResult(err error) int {
+	bc.healthLock.Lock()
+	defer bc.healthLock.Unlock()
+	if err != nil {
+		bc.watchFailures++
+	} else {
+		bc.watchFailures = 0
+	}
+	return bc.watchFailures
+}
+
+// recordResyncResult updates resyncFailures based on err and returns the updated count.
+func (bc *BuildController) recordResyncResult(err error) int {
+	bc.healthLock.Lock()
+	defer bc.healthLock.Unlock()
+	if err != nil {
+		bc.resyncFailures++
+	} else {
+		bc.resyncFailures = 0
+	}
+	return bc.resyncFailures
+}
+
+// syncBuild synchronizes a single build and, if its status changed, persists the new status
+// and reports it to the build's BuildConfig and to any registered notifier.
+func (bc *BuildController) syncBuild(ctx kapi.Context, build *api.Build) {
+	previousStatus := build.Status
+	previousPodRetryCount := build.PodRetryCount
+
+	nextStatus, err := bc.synchronize(ctx, build)
+	if err != nil {
+		log.Errorf("Error synchronizing build ID %v: %#v", build.ID, err)
+	}
+	build.Status = nextStatus
+
+	if nextStatus == api.BuildRunning {
+		bc.inFlight.Insert(build.ID)
+	} else {
+		bc.inFlight.Delete(build.ID)
+	}
+
+	if nextStatus == api.BuildRunning && build.StartTimestamp == nil {
+		now := util.Now()
+		build.StartTimestamp = &now
+	}
+
+	if isTerminal(nextStatus) && build.CompletionTimestamp == nil {
+		now := util.Now()
+		build.CompletionTimestamp = &now
+	}
+
+	// A retried pod creation doesn't change the build's status, but its PodRetryCount and
+	// LastPodRetry still need to be persisted so the backoff in synchronize survives past
+	// this sync. Likewise, StartTimestamp and CompletionTimestamp are stamped as part of
+	// this same status transition and must be persisted alongside it.
+	if nextStatus != previousStatus || build.PodRetryCount != previousPodRetryCount {
+		if _, err := bc.osClient.UpdateBuildStatus(ctx, build); err != nil {
+			log.Errorf("Error updating build ID %v to status %v: %#v", build.ID, nextStatus, err)
+		}
+	}
+
+	if nextStatus != previousStatus {
+		bc.notifyOnTerminalStatus(build.ID, nextStatus)
+		bc.updateBuildConfigStatus(ctx, build)
+	}
+}
+
+// MaxBuildEvents bounds how many BuildEvent entries recordEvent keeps on a Build, so a
+// long-lived or frequently-retried build doesn't grow its Events list without limit.
+// Once the limit is reached, the oldest event is dropped to make room for the newest.
+const MaxBuildEvents = 25
+
+// recordEvent appends a BuildEvent to build.Events with the given reason and message, so
+// `describe`-style tooling can show a build's history without scraping controller logs.
+func recordEvent(build *api.Build, reason, message string) {
+	build.Events = append(build.Events, api.BuildEvent{
+		Reason:    reason,
+		Message:   message,
+		Timestamp: util.Now(),
+	})
+	if len(build.Events) > MaxBuildEvents {
+		build.Events = build.Events[len(build.Events)-MaxBuildEvents:]
+	}
+}
+
+// isTerminal reports whether status is one a build never transitions out of.
+func isTerminal(status api.BuildStatus) bool {
+	switch status {
+	case api.BuildComplete, api.BuildFailed, api.BuildError:
+		return true
+	default:
+		return false
+	}
+}
+
+// notifyOnTerminalStatus sends a build.complete or build.failed event once a build
+// reaches a status a caller might be waiting to hear about. Statuses that aren't
+// terminal (new, pending, running) don't warrant a notification.
+func (bc *BuildController) notifyOnTerminalStatus(buildID string, status api.BuildStatus) {
+	if bc.notifier == nil {
+		return
+	}
+	var eventType string
+	switch status {
+	case api.BuildComplete:
+		eventType = "build.complete"
+	case api.BuildFailed, api.BuildError:
+		eventType = "build.failed"
+	default:
+		return
+	}
+	bc.notifier.Notify(notify.Event{Type: eventType, ID: buildID, Timestamp: util.Now()})
+}
+
+// projectDefaults returns the ProjectDefaults for namespace, or nil if namespace is empty
+// or has none configured.
+func (bc *BuildController) projectDefaults(ctx kapi.Context, namespace string) *projectapi.ProjectDefaults {
+	if len(namespace) == 0 {
+		return nil
+	}
+	defaults, err := bc.osClient.GetProjectDefaults(ctx, namespace)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			log.Errorf("Error retrieving project defaults for namespace %s: %#v", namespace, err)
+		}
+		return nil
+	}
+	return defaults
+}
+
+// MaxConsecutiveBuildFailures is the number of consecutive build failures a BuildConfig
+// tolerates before its webhook trigger is automatically paused, to keep a broken base
+// image or Dockerfile from being retriggered into an endless string of failing builds.
+const MaxConsecutiveBuildFailures = 5
+
+// updateBuildConfigStatus refreshes build's originating BuildConfig with a summary of
+// build's outcome, so dashboards can show a config's last build and success rate without
+// listing and sorting all of its builds. Once ConsecutiveFailures reaches
+// MaxConsecutiveBuildFailures it also pauses the BuildConfig's trigger and emits a
+// buildConfig.paused event. It is a no-op for builds not created from a BuildConfig.
+func (bc *BuildController) updateBuildConfigStatus(ctx kapi.Context, build *api.Build) {
+	if len(build.BuildConfigID) == 0 {
+		return
+	}
+	buildCfg, err := bc.osClient.GetBuildConfig(ctx, build.BuildConfigID)
+	if err != nil {
+		log.Errorf("Error retrieving BuildConfig %s to update its status for build ID %v: %#v", build.BuildConfigID, build.ID, err)
+		return
+	}
+
+	buildCfg.LastBuildID = build.ID
+	buildCfg.LastBuildStatus = build.Status
+	switch build.Status {
+	case api.BuildComplete:
+		buildCfg.LastSuccessfulBuildID = build.ID
+		buildCfg.ConsecutiveFailures = 0
+	case api.BuildFailed, api.BuildError:
+		buildCfg.ConsecutiveFailures++
+		if buildCfg.ConsecutiveFailures >= MaxConsecutiveBuildFailures && !buildCfg.Paused {
+			buildCfg.Paused = true
+			log.Errorf("Pausing BuildConfig %s after %d consecutive failures", buildCfg.ID, buildCfg.ConsecutiveFailures)
+			bc.notifyBuildConfigPaused(buildCfg.ID)
 		}
+	}
+
+	if _, err := bc.osClient.UpdateBuildConfig(ctx, buildCfg); err != nil {
+		log.Errorf("Error updating status on BuildConfig %s for build ID %v: %#v", build.BuildConfigID, build.ID, err)
+	}
+}
+
+// notifyBuildConfigPaused sends a buildConfig.paused event when the circuit breaker in
+// updateBuildConfigStatus trips.
+func (bc *BuildController) notifyBuildConfigPaused(buildConfigID string) {
+	if bc.notifier == nil {
+		return
+	}
+	bc.notifier.Notify(notify.Event{Type: "buildConfig.paused", ID: buildConfigID, Timestamp: util.Now()})
+}
+
+// serialBuildBlocked reports whether build must wait because its BuildConfig has Serial
+// set and an earlier build from that same BuildConfig hasn't finished yet -- either still
+// Running, or still Pending and created before build was.
+func (bc *BuildController) serialBuildBlocked(ctx kapi.Context, build *api.Build) (bool, error) {
+	buildCfg, err := bc.osClient.GetBuildConfig(ctx, build.BuildConfigID)
+	if err != nil {
+		return false, err
+	}
+	if !buildCfg.Serial {
+		return false, nil
+	}
 
+	builds, err := bc.osClient.ListBuilds(ctx, labels.Everything())
+	if err != nil {
+		return false, err
+	}
+	for i := range builds.Items {
+		sibling := &builds.Items[i]
+		if sibling.ID == build.ID || sibling.BuildConfigID != build.BuildConfigID {
+			continue
+		}
+		if sibling.Status == api.BuildRunning {
+			return true, nil
+		}
+		if sibling.Status == api.BuildPending && sibling.CreationTimestamp.Time.Before(build.CreationTimestamp.Time) {
+			return true, nil
+		}
 	}
+	return false, nil
 }
 
 func hasTimeoutElapsed(build *api.Build, timeout int) bool {
@@ -88,29 +402,175 @@ func hasTimeoutElapsed(build *api.Build, timeout int) bool {
 	return int(elapsed.Seconds()) > timeout
 }
 
+// MaxPodCreationRetries bounds how many times BuildController retries creating a build's
+// pod after a transient failure before giving up and marking the build BuildFailed. A
+// failure that looks like the pod already exists doesn't count against this limit, since
+// it means another sync already succeeded.
+const MaxPodCreationRetries = 5
+
+// podCreationBaseBackoff and podCreationMaxBackoff bound the exponential backoff applied
+// between pod creation retries, so a transient apiserver or kubelet error is retried with
+// increasing patience instead of hot-looping every sync period.
+const (
+	podCreationBaseBackoff = 5 * time.Second
+	podCreationMaxBackoff  = 5 * time.Minute
+)
+
+// podCreationBackoff returns how long to wait before the retryCount'th retry of pod
+// creation for a build.
+func podCreationBackoff(retryCount int) time.Duration {
+	backoff := podCreationBaseBackoff * time.Duration(1<<uint(retryCount))
+	if backoff <= 0 || backoff > podCreationMaxBackoff {
+		return podCreationMaxBackoff
+	}
+	return backoff
+}
+
+// diagnosticLogTailLines and maxDiagnosticMessageLen bound the per-container log tail and
+// overall message diagnosePodFailure builds, so a failed build's Events entry stays small
+// enough to be worth persisting on the Build itself instead of only living in the pod that's
+// about to be garbage collected.
+const (
+	diagnosticLogTailLines  = 20
+	maxDiagnosticMessageLen = 4000
+
+	// kubeletPort is the default port the kubelet listens on, used to reach it directly for
+	// a container's logs. Matches the port apiserver's minion proxy falls back to.
+	kubeletPort = "10250"
+)
+
+// diagnosePodFailure summarizes why pod's containers failed -- their exit codes and
+// termination reasons, plus a best-effort tail of each failed container's log -- into a
+// single string suitable for a BuildEvent message. It never returns an error; a container
+// whose log can't be fetched (e.g. its node is already gone) is just noted as such.
+func (bc *BuildController) diagnosePodFailure(pod *kapi.Pod) string {
+	var diagnosis []string
+	for name, status := range pod.CurrentState.Info {
+		term := status.State.Termination
+		if term == nil || term.ExitCode == 0 {
+			continue
+		}
+		detail := fmt.Sprintf("container %s exited %d", name, term.ExitCode)
+		if term.Reason != "" {
+			detail += fmt.Sprintf(" (%s)", term.Reason)
+		}
+		if tail, err := fetchContainerLogTail(pod.CurrentState.Host, pod.ID, name, diagnosticLogTailLines); err != nil {
+			detail += fmt.Sprintf("; could not retrieve log: %v", err)
+		} else if len(tail) > 0 {
+			detail += ": " + tail
+		}
+		diagnosis = append(diagnosis, detail)
+	}
+
+	message := strings.Join(diagnosis, "; ")
+	if len(message) > maxDiagnosticMessageLen {
+		message = message[:maxDiagnosticMessageLen]
+	}
+	return message
+}
+
+// fetchContainerLogTail retrieves the last tailLines lines of containerName's log directly
+// from the kubelet on host, bypassing the apiserver so it still works if the Build's pod is
+// about to be deleted.
+func fetchContainerLogTail(host, podID, containerName string, tailLines int) (string, error) {
+	if len(host) == 0 {
+		return "", fmt.Errorf("pod has no assigned host")
+	}
+	client := http.Client{Timeout: 10 * time.Second}
+	url := fmt.Sprintf("http://%s:%s/containerLogs/%s/%s?tail=%d", host, kubeletPort, podID, containerName, tailLines)
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
 // Determine the next status of a build given its current state and the state
 // of its associated pod.
 // TODO: improve handling of illegal state transitions
 func (bc *BuildController) synchronize(ctx kapi.Context, build *api.Build) (api.BuildStatus, error) {
-	glog.Infof("Syncing build %s", build.ID)
+	log.Infof(3, "Syncing build %s", build.ID)
 
 	switch build.Status {
 	case api.BuildNew:
 		build.PodID = "build-" + string(build.Input.Type) + "-" + build.ID // TODO: better naming
 		return api.BuildPending, nil
 	case api.BuildPending:
+		if bc.maxInFlight > 0 && bc.inFlight.Len() >= bc.maxInFlight && !bc.inFlight.Has(build.ID) {
+			log.Infof(4, "Deferring build %s: %d/%d builds already running", build.ID, bc.inFlight.Len(), bc.maxInFlight)
+			return api.BuildPending, nil
+		}
+
+		if len(build.BuildConfigID) > 0 {
+			blocked, err := bc.serialBuildBlocked(ctx, build)
+			if err != nil {
+				log.Errorf("Error checking serial build policy for build ID %v: %#v", build.ID, err)
+			} else if blocked {
+				log.Infof(4, "Deferring build %s: an earlier build from BuildConfig %s hasn't finished", build.ID, build.BuildConfigID)
+				return api.BuildPending, nil
+			}
+		}
+
 		buildStrategy, ok := bc.buildStrategies[build.Input.Type]
 		if !ok {
 			return api.BuildError, fmt.Errorf("No build type for %s", build.Input.Type)
 		}
 
-		podSpec, err := buildStrategy.CreateBuildPod(build)
+		defaults := bc.projectDefaults(ctx, build.Namespace)
+		if defaults != nil && !imageapi.RegistryAllowed(build.Input.ImageTag, defaults.AllowedRegistries) {
+			return api.BuildError, fmt.Errorf("Image tag %s for build ID %v is not from a registry allowed by project %s", build.Input.ImageTag, build.ID, build.Namespace)
+		}
+
+		var pushSecret, pullSecret *secretapi.Secret
+		var err error
+		if len(build.Input.PushSecretName) > 0 {
+			pushSecret, err = bc.osClient.GetSecret(ctx, build.Input.PushSecretName)
+			if err != nil {
+				return api.BuildError, fmt.Errorf("Error retrieving push secret %s for build ID %v: %#v", build.Input.PushSecretName, build.ID, err)
+			}
+		}
+		pullSecretName := build.Input.PullSecretName
+		if len(pullSecretName) == 0 && defaults != nil {
+			pullSecretName = defaults.PullSecretName
+		}
+		if len(pullSecretName) > 0 {
+			pullSecret, err = bc.osClient.GetSecret(ctx, pullSecretName)
+			if err != nil {
+				return api.BuildError, fmt.Errorf("Error retrieving pull secret %s for build ID %v: %#v", pullSecretName, build.ID, err)
+			}
+		}
+
+		var sourceCacheURL string
+		if defaults != nil {
+			sourceCacheURL = defaults.SourceCacheURL
+		}
+
+		if build.PodRetryCount > 0 {
+			if wait := podCreationBackoff(build.PodRetryCount) - time.Since(build.LastPodRetry.Time); wait > 0 {
+				// still backing off from the last failed attempt; try again on a later sync
+				return build.Status, nil
+			}
+		}
+
+		podSpec, err := buildStrategy.CreateBuildPod(build, pushSecret, pullSecret, sourceCacheURL)
 		if err != nil {
-			glog.Errorf("Unable to create build pod: %v", err)
+			log.Errorf("Unable to create build pod: %v", err)
 			return api.BuildFailed, err
 		}
 
-		glog.Infof("Attempting to create pod: %#v", podSpec)
+		if defaults != nil {
+			if err := defaults.Resources.ApplyLimits(podSpec); err != nil {
+				log.Errorf("Build pod for build ID %v exceeds project limits: %v", build.ID, err)
+				return api.BuildError, err
+			}
+		}
+
+		log.Infof(4, "Attempting to create pod: %#v", podSpec)
 		_, err = bc.kubeClient.CreatePod(ctx, podSpec)
 
 		// TODO: strongly typed error checking
@@ -119,13 +579,29 @@ func (bc *BuildController) synchronize(ctx kapi.Context, build *api.Build) (api.
 				return build.Status, err // no transition, already handled by someone else
 			}
 
+			if build.PodRetryCount < MaxPodCreationRetries {
+				build.PodRetryCount++
+				build.LastPodRetry = util.Now()
+				log.Errorf("Error creating pod for build ID %v, will retry (%d/%d): %v", build.ID, build.PodRetryCount, MaxPodCreationRetries, err)
+				return build.Status, err // no transition; synchronize retries after backoff
+			}
+
+			recordEvent(build, "PodCreationFailed", err.Error())
 			return api.BuildFailed, err
 		}
 
+		recordEvent(build, "PodCreated", fmt.Sprintf("Created pod %s to run the build", build.PodID))
+		recordEvent(build, "BuildStarted", "")
 		return api.BuildRunning, nil
 	case api.BuildRunning:
-		if timedOut := hasTimeoutElapsed(build, bc.timeout); timedOut {
-			return api.BuildFailed, fmt.Errorf("Build timed out")
+		// Jenkins builds are driven by the Jenkins job, which reports the build's
+		// outcome back via UpdateBuildStatus, so they aren't subject to the pod
+		// timeout applied to builds this controller watches to completion itself.
+		if build.Input.Type != api.JenkinsBuildType {
+			if timedOut := hasTimeoutElapsed(build, bc.timeout); timedOut {
+				recordEvent(build, "BuildTimedOut", fmt.Sprintf("Build did not complete within %d seconds", bc.timeout))
+				return api.BuildFailed, fmt.Errorf("Build timed out")
+			}
 		}
 
 		pod, err := bc.kubeClient.GetPod(ctx, build.PodID)
@@ -138,15 +614,30 @@ func (bc *BuildController) synchronize(ctx kapi.Context, build *api.Build) (api.
 			return build.Status, nil
 		}
 
-		var nextStatus = api.BuildComplete
-
+		podFailed := false
 		// check the exit codes of all the containers in the pod
 		for _, info := range pod.CurrentState.Info {
 			if info.State.Termination != nil && info.State.Termination.ExitCode != 0 {
-				nextStatus = api.BuildFailed
+				podFailed = true
+			}
+		}
+
+		if build.Input.Type == api.JenkinsBuildType {
+			// The pod only triggers the Jenkins job and exits; a non-zero exit means
+			// the trigger itself failed. A clean exit just means the job was
+			// triggered -- the build stays Running until the job calls back.
+			if podFailed {
+				recordEvent(build, "BuildFailed", "Failed to trigger Jenkins job")
+				return api.BuildFailed, fmt.Errorf("Failed to trigger Jenkins job for build ID %v", build.ID)
 			}
+			return build.Status, nil
+		}
+
+		if podFailed {
+			recordEvent(build, "BuildFailed", "Build pod exited with a non-zero status: "+bc.diagnosePodFailure(pod))
+			return api.BuildFailed, nil
 		}
-		return nextStatus, nil
+		return api.BuildComplete, nil
 	case api.BuildComplete, api.BuildFailed, api.BuildError:
 		return build.Status, nil
 	default:
@@ -2,13 +2,18 @@ package build
 
 import (
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	kubeclient "github.com/GoogleCloudPlatform/kubernetes/pkg/client"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
 	"github.com/openshift/origin/pkg/build/api"
+	osclient "github.com/openshift/origin/pkg/client"
+	secretapi "github.com/openshift/origin/pkg/secret/api"
+	"github.com/openshift/origin/pkg/util/concurrent"
 )
 
 type okOsClient struct{}
@@ -33,7 +38,7 @@ func (_ *errOsClient) UpdateBuild(ctx kapi.Context, build *api.Build) (*api.Buil
 
 type okStrategy struct{}
 
-func (_ *okStrategy) CreateBuildPod(build *api.Build) (*kapi.Pod, error) {
+func (_ *okStrategy) CreateBuildPod(build *api.Build, pushSecret, pullSecret *secretapi.Secret, sourceCacheURL string) (*kapi.Pod, error) {
 	return &kapi.Pod{}, nil
 }
 
@@ -59,6 +64,25 @@ func (_ *okKubeClient) GetPod(ctx kapi.Context, name string) (*kapi.Pod, error)
 	}, nil
 }
 
+type failedKubeClient struct {
+	kubeclient.Fake
+}
+
+func (_ *failedKubeClient) GetPod(ctx kapi.Context, name string) (*kapi.Pod, error) {
+	return &kapi.Pod{
+		CurrentState: kapi.PodState{
+			Status: kapi.PodTerminated,
+			Info: map[string]kapi.ContainerStatus{
+				"jenkins-trigger": {
+					State: kapi.ContainerState{
+						Termination: &kapi.ContainerStateTerminated{ExitCode: 1},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
 func TestSynchronizeBuildNew(t *testing.T) {
 	ctrl, build, ctx := setup()
 	build.Status = api.BuildNew
@@ -92,8 +116,43 @@ func TestSynchronizeBuildPendingFailedCreatePod(t *testing.T) {
 	if err == nil {
 		t.Error("Expected error, but none happened!")
 	}
+	if status != api.BuildPending {
+		t.Errorf("Expected BuildPending (retry pending), got %s!", status)
+	}
+	if build.PodRetryCount != 1 {
+		t.Errorf("Expected PodRetryCount 1, got %d!", build.PodRetryCount)
+	}
+}
+
+func TestSynchronizeBuildPendingRetryBackoff(t *testing.T) {
+	ctrl, build, ctx := setup()
+	ctrl.kubeClient = &errKubeClient{}
+	build.Status = api.BuildPending
+	build.PodRetryCount = 1
+	build.LastPodRetry = util.Now()
+	status, err := ctrl.synchronize(ctx, build)
+	if err != nil {
+		t.Errorf("Unexpected error, still backing off: %s!", err.Error())
+	}
+	if status != api.BuildPending {
+		t.Errorf("Expected BuildPending, got %s!", status)
+	}
+	if build.PodRetryCount != 1 {
+		t.Errorf("Expected PodRetryCount to stay at 1 while backing off, got %d!", build.PodRetryCount)
+	}
+}
+
+func TestSynchronizeBuildPendingFailedCreatePodExhaustsRetries(t *testing.T) {
+	ctrl, build, ctx := setup()
+	ctrl.kubeClient = &errKubeClient{}
+	build.Status = api.BuildPending
+	build.PodRetryCount = MaxPodCreationRetries
+	status, err := ctrl.synchronize(ctx, build)
+	if err == nil {
+		t.Error("Expected error, but none happened!")
+	}
 	if status != api.BuildFailed {
-		t.Errorf("Expected BuildFailed, got %s!", status)
+		t.Errorf("Expected BuildFailed after exhausting retries, got %s!", status)
 	}
 }
 
@@ -107,6 +166,112 @@ func TestSynchronizeBuildPending(t *testing.T) {
 	if status != api.BuildRunning {
 		t.Errorf("Expected BuildRunning, got %s!", status)
 	}
+	if len(build.Events) != 2 {
+		t.Fatalf("Expected 2 events recorded, got %d: %#v", len(build.Events), build.Events)
+	}
+	if build.Events[0].Reason != "PodCreated" || build.Events[1].Reason != "BuildStarted" {
+		t.Errorf("Unexpected events recorded: %#v", build.Events)
+	}
+}
+
+func TestSynchronizeBuildPendingDeferredWhenMaxInFlightReached(t *testing.T) {
+	ctrl, build, ctx := setup()
+	ctrl.maxInFlight = 1
+	ctrl.inFlight.Insert("some-other-build")
+	build.Status = api.BuildPending
+
+	status, err := ctrl.synchronize(ctx, build)
+	if err != nil {
+		t.Errorf("Unexpected error: %s!", err.Error())
+	}
+	if status != api.BuildPending {
+		t.Errorf("Expected build to stay Pending while at the in-flight limit, got %s!", status)
+	}
+
+	ctrl.inFlight.Delete("some-other-build")
+	status, err = ctrl.synchronize(ctx, build)
+	if err != nil {
+		t.Errorf("Unexpected error: %s!", err.Error())
+	}
+	if status != api.BuildRunning {
+		t.Errorf("Expected build to run once a slot freed up, got %s!", status)
+	}
+}
+
+type serialOsClient struct {
+	osclient.Fake
+	buildCfg *api.BuildConfig
+	siblings []api.Build
+}
+
+func (c *serialOsClient) GetBuildConfig(ctx kapi.Context, id string) (*api.BuildConfig, error) {
+	return c.buildCfg, nil
+}
+
+func (c *serialOsClient) ListBuilds(ctx kapi.Context, selector labels.Selector) (*api.BuildList, error) {
+	return &api.BuildList{Items: c.siblings}, nil
+}
+
+func TestSynchronizeBuildPendingDeferredBySerialPolicy(t *testing.T) {
+	ctrl, build, ctx := setup()
+	build.Status = api.BuildPending
+	build.BuildConfigID = "serial-config"
+	build.CreationTimestamp = util.Now()
+
+	older := api.Build{
+		JSONBase:      kapi.JSONBase{ID: "older-build"},
+		BuildConfigID: "serial-config",
+		Status:        api.BuildPending,
+	}
+	older.CreationTimestamp.Time = build.CreationTimestamp.Time.Add(-time.Minute)
+
+	osClient := &serialOsClient{
+		buildCfg: &api.BuildConfig{JSONBase: kapi.JSONBase{ID: "serial-config"}, Serial: true},
+		siblings: []api.Build{older},
+	}
+	ctrl.osClient = osClient
+
+	status, err := ctrl.synchronize(ctx, build)
+	if err != nil {
+		t.Errorf("Unexpected error: %s!", err.Error())
+	}
+	if status != api.BuildPending {
+		t.Errorf("Expected build to stay Pending behind an older sibling build, got %s!", status)
+	}
+
+	osClient.siblings = nil
+	status, err = ctrl.synchronize(ctx, build)
+	if err != nil {
+		t.Errorf("Unexpected error: %s!", err.Error())
+	}
+	if status != api.BuildRunning {
+		t.Errorf("Expected build to run once its older sibling was gone, got %s!", status)
+	}
+}
+
+func TestSynchronizeBuildPendingFailedCreatePodExhaustsRetriesRecordsEvent(t *testing.T) {
+	ctrl, build, ctx := setup()
+	ctrl.kubeClient = &errKubeClient{}
+	build.Status = api.BuildPending
+	build.PodRetryCount = MaxPodCreationRetries
+	if _, err := ctrl.synchronize(ctx, build); err == nil {
+		t.Error("Expected error, but none happened!")
+	}
+	if len(build.Events) != 1 || build.Events[0].Reason != "PodCreationFailed" {
+		t.Errorf("Expected a single PodCreationFailed event, got %#v", build.Events)
+	}
+}
+
+func TestSynchronizeBuildRunningTimedOutRecordsEvent(t *testing.T) {
+	ctrl, build, ctx := setup()
+	build.Status = api.BuildRunning
+	build.CreationTimestamp.Time = time.Date(0, 0, 0, 0, 0, 0, 0, time.UTC)
+	if _, err := ctrl.synchronize(ctx, build); err == nil {
+		t.Error("Expected error, but none happened!")
+	}
+	if len(build.Events) != 1 || build.Events[0].Reason != "BuildTimedOut" {
+		t.Errorf("Expected a single BuildTimedOut event, got %#v", build.Events)
+	}
 }
 
 func TestSynchronizeBuildRunningTimedOut(t *testing.T) {
@@ -163,6 +328,57 @@ func TestSynchronizeBuildRunningPodTerminated(t *testing.T) {
 	}
 }
 
+func TestSynchronizeBuildRunningPodFailedRecordsDiagnosis(t *testing.T) {
+	ctrl, build, ctx := setup()
+	ctrl.kubeClient = &failedKubeClient{}
+	build.Status = api.BuildRunning
+	build.CreationTimestamp.Time = time.Now()
+
+	status, err := ctrl.synchronize(ctx, build)
+	if err != nil {
+		t.Errorf("Unexpected error, got %s!", err.Error())
+	}
+	if status != api.BuildFailed {
+		t.Errorf("Expected BuildFailed, got %s!", status)
+	}
+	if len(build.Events) != 1 || build.Events[0].Reason != "BuildFailed" {
+		t.Fatalf("Expected a single BuildFailed event, got %#v", build.Events)
+	}
+	if !strings.Contains(build.Events[0].Message, "jenkins-trigger exited 1") {
+		t.Errorf("Expected the event message to include the failed container's exit code, got %q", build.Events[0].Message)
+	}
+}
+
+func TestSynchronizeBuildRunningJenkinsPodTerminated(t *testing.T) {
+	ctrl, build, ctx := setup()
+	ctrl.kubeClient = &okKubeClient{}
+	build.Status = api.BuildRunning
+	build.Input.Type = api.JenkinsBuildType
+	build.CreationTimestamp.Time = time.Now()
+	status, err := ctrl.synchronize(ctx, build)
+	if err != nil {
+		t.Errorf("Unexpected error, got %s!", err.Error())
+	}
+	if status != api.BuildRunning {
+		t.Errorf("Expected BuildRunning, got %s!", status)
+	}
+}
+
+func TestSynchronizeBuildRunningJenkinsTriggerFailed(t *testing.T) {
+	ctrl, build, ctx := setup()
+	ctrl.kubeClient = &failedKubeClient{}
+	build.Status = api.BuildRunning
+	build.Input.Type = api.JenkinsBuildType
+	build.CreationTimestamp.Time = time.Now()
+	status, err := ctrl.synchronize(ctx, build)
+	if err == nil {
+		t.Error("Expected error, but none happened!")
+	}
+	if status != api.BuildFailed {
+		t.Errorf("Expected BuildFailed, got %s!", status)
+	}
+}
+
 func TestSynchronizeBuildComplete(t *testing.T) {
 	ctrl, build, ctx := setup()
 	build.Status = api.BuildComplete
@@ -211,6 +427,44 @@ func TestSynchronizeBuildUnknownStatus(t *testing.T) {
 	}
 }
 
+func TestAPIRetryBackoff(t *testing.T) {
+	for _, failures := range []int{0, 1, 2, 5, 10} {
+		backoff := apiRetryBackoff(failures)
+		if backoff < apiRetryBaseBackoff/2 {
+			t.Errorf("backoff for %d failures too small: %v", failures, backoff)
+		}
+		if backoff > apiRetryMaxBackoff {
+			t.Errorf("backoff for %d failures exceeds max: %v", failures, backoff)
+		}
+	}
+}
+
+func TestHealthyTracksConsecutiveFailures(t *testing.T) {
+	ctrl, _, _ := setup()
+	if !ctrl.Healthy() {
+		t.Error("expected a freshly created controller to be healthy")
+	}
+
+	for i := 0; i < unhealthyFailureThreshold; i++ {
+		ctrl.recordWatchResult(errors.New("watch error"))
+	}
+	if ctrl.Healthy() {
+		t.Error("expected controller to be unhealthy after repeated watch failures")
+	}
+
+	ctrl.recordWatchResult(nil)
+	if !ctrl.Healthy() {
+		t.Error("expected controller to recover as soon as the watch succeeds again")
+	}
+
+	for i := 0; i < unhealthyFailureThreshold; i++ {
+		ctrl.recordResyncResult(errors.New("resync error"))
+	}
+	if ctrl.Healthy() {
+		t.Error("expected controller to be unhealthy after repeated resync failures")
+	}
+}
+
 func setup() (buildController *BuildController, build *api.Build, ctx kapi.Context) {
 	buildController = &BuildController{
 		buildStrategies: map[api.BuildType]BuildJobStrategy{
@@ -218,6 +472,7 @@ func setup() (buildController *BuildController, build *api.Build, ctx kapi.Conte
 		},
 		kubeClient: &kubeclient.Fake{},
 		timeout:    1000,
+		inFlight:   concurrent.NewStringSet(),
 	}
 	build = &api.Build{
 		JSONBase: kapi.JSONBase{
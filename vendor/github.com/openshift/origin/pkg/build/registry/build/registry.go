@@ -2,6 +2,7 @@ package build
 
 import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
 	"github.com/openshift/origin/pkg/build/api"
 )
 
@@ -11,10 +12,26 @@ type Registry interface {
 	ListBuilds(labels labels.Selector) (*api.BuildList, error)
 	// GetBuild retrieves a specific build.
 	GetBuild(id string) (*api.Build, error)
+	// WatchBuilds watches for new/changed/deleted builds starting at resourceVersion, so a
+	// caller can list builds, note the list's ResourceVersion, and watch from there without
+	// missing or duplicating events across the transition.
+	WatchBuilds(resourceVersion uint64, filter func(build *api.Build) bool) (watch.Interface, error)
 	// CreateBuild creates a new build.
 	CreateBuild(build *api.Build) error
 	// UpdateBuild updates a build.
 	UpdateBuild(build *api.Build) error
+	// UpdateBuildStatus stores build, whole, like UpdateBuild. It exists so the controller's
+	// frequent status transitions don't need the same authorization as user edits made
+	// through UpdateBuild.
+	UpdateBuildStatus(build *api.Build) error
+	// UpdateBuildArtifacts stores build, whole, like UpdateBuild, to record the Artifacts
+	// collected for it. It exists so the builder pod can deposit
+	// artifacts without needing the same authorization as user edits made through
+	// UpdateBuild.
+	UpdateBuildArtifacts(build *api.Build) error
 	// DeleteBuild deletes a build.
 	DeleteBuild(id string) error
+	// FinalizeBuild persists build's Finalizers list, completing a pending deletion by
+	// calling DeleteBuild once the list becomes empty and DeletionTimestamp is set.
+	FinalizeBuild(build *api.Build) error
 }
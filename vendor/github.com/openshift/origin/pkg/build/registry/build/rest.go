@@ -10,9 +10,13 @@ import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
 
+	originapi "github.com/openshift/origin/pkg/api"
 	"github.com/openshift/origin/pkg/build/api"
 	"github.com/openshift/origin/pkg/build/api/validation"
+	"github.com/openshift/origin/pkg/util/apierrors"
+	"github.com/openshift/origin/pkg/util/generatename"
 )
 
 // REST implements the RESTStorage interface in terms of an Registry.
@@ -49,10 +53,60 @@ func (r *REST) Get(ctx kubeapi.Context, id string) (runtime.Object, error) {
 	return build, err
 }
 
-// Delete asynchronously deletes the Build specified by its id.
+// Watch returns Build events via a watch.Interface, starting at resourceVersion, so a
+// controller can list builds and then watch from the list's ResourceVersion without missing
+// or duplicating events. It implements apiserver.ResourceWatcher.
+func (r *REST) Watch(ctx kubeapi.Context, label, field labels.Selector, resourceVersion uint64) (watch.Interface, error) {
+	return r.registry.WatchBuilds(resourceVersion, func(build *api.Build) bool {
+		fields := labels.Set{
+			"ID":     build.ID,
+			"status": string(build.Status),
+		}
+		return label.Matches(labels.Set(build.Labels)) && field.Matches(fields)
+	})
+}
+
+// Delete asynchronously deletes the Build specified by its id, cascading to its pod. It
+// implements apiserver.RESTStorage and is equivalent to calling DeleteWithOptions with nil
+// options.
 func (r *REST) Delete(ctx kubeapi.Context, id string) (<-chan runtime.Object, error) {
+	return r.DeleteWithOptions(ctx, id, nil)
+}
+
+// DeleteWithOptions deletes the Build specified by its id, honoring options.
+//
+// Cascading (options.ShouldCascade(), the default) preserves the original Delete behavior:
+// if the Build still has pending Finalizers, the delete is deferred by setting
+// DeletionTimestamp instead of removing the Build, and the actual delete happens once a
+// controller clears the last finalizer through the buildFinalize resource -- which for a
+// fresh Build means waiting for its pod to be cleaned up first.
+//
+// Requesting Cascade=false clears any pending Finalizers and removes the Build immediately
+// without waiting on its pod. The pod itself isn't force-deleted here; it's simply orphaned
+// from this call's perspective, left for the garbage collector to notice on its own schedule.
+//
+// GracePeriodSeconds is accepted for forward compatibility but has no effect yet; see
+// api.DeleteOptions.
+func (r *REST) DeleteWithOptions(ctx kubeapi.Context, id string, options *originapi.DeleteOptions) (<-chan runtime.Object, error) {
 	return apiserver.MakeAsync(func() (runtime.Object, error) {
-		return &kubeapi.Status{Status: kubeapi.StatusSuccess}, r.registry.DeleteBuild(id)
+		build, err := r.registry.GetBuild(id)
+		if err != nil {
+			return nil, err
+		}
+		if !options.ShouldCascade() {
+			build.Finalizers = nil
+			return &kubeapi.Status{Status: kubeapi.StatusSuccess}, r.registry.DeleteBuild(id)
+		}
+		if len(build.Finalizers) == 0 {
+			return &kubeapi.Status{Status: kubeapi.StatusSuccess}, r.registry.DeleteBuild(id)
+		}
+		if build.DeletionTimestamp.IsZero() {
+			build.DeletionTimestamp = util.Now()
+			if err := r.registry.UpdateBuild(build); err != nil {
+				return nil, err
+			}
+		}
+		return build, nil
 	}), nil
 }
 
@@ -60,21 +114,40 @@ func (r *REST) Delete(ctx kubeapi.Context, id string) (<-chan runtime.Object, er
 func (r *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
 	build, ok := obj.(*api.Build)
 	if !ok {
-		return nil, fmt.Errorf("not a build: %#v", obj)
+		return nil, apierrors.NewBadRequest("build", fmt.Sprintf("not a build: %#v", obj))
+	}
+	if !kubeapi.ValidNamespace(ctx, &build.JSONBase) {
+		return nil, errors.NewConflict("build", build.Namespace, fmt.Errorf("Build.Namespace does not match the provided context"))
 	}
 	if len(build.ID) == 0 {
-		build.ID = uuid.NewUUID().String()
+		if len(build.GenerateName) > 0 {
+			build.ID = build.GenerateName
+		} else {
+			build.ID = uuid.NewUUID().String()
+		}
 	}
+	build.UID = uuid.NewUUID().String()
 	if len(build.Status) == 0 {
 		build.Status = api.BuildNew
 	}
+	if len(build.Finalizers) == 0 {
+		build.Finalizers = []string{api.PodCleanupFinalizer}
+	}
 	build.CreationTimestamp = util.Now()
 	if errs := validation.ValidateBuild(build); len(errs) > 0 {
 		return nil, errors.NewInvalid("build", build.ID, errs)
 	}
 	return apiserver.MakeAsync(func() (runtime.Object, error) {
-		err := r.registry.CreateBuild(build)
-		if err != nil {
+		if len(build.GenerateName) > 0 {
+			if err := generatename.Retry(build.GenerateName, func(name string) error {
+				build.ID = name
+				return r.registry.CreateBuild(build)
+			}); err != nil {
+				return nil, err
+			}
+			return build, nil
+		}
+		if err := r.registry.CreateBuild(build); err != nil {
 			return nil, err
 		}
 		return build, nil
@@ -85,7 +158,10 @@ func (r *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.O
 func (r *REST) Update(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
 	build, ok := obj.(*api.Build)
 	if !ok {
-		return nil, fmt.Errorf("not a build: %#v", obj)
+		return nil, apierrors.NewBadRequest("build", fmt.Sprintf("not a build: %#v", obj))
+	}
+	if !kubeapi.ValidNamespace(ctx, &build.JSONBase) {
+		return nil, errors.NewConflict("build", build.Namespace, fmt.Errorf("Build.Namespace does not match the provided context"))
 	}
 	if errs := validation.ValidateBuild(build); len(errs) > 0 {
 		return nil, errors.NewInvalid("build", build.ID, errs)
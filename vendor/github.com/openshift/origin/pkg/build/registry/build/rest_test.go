@@ -58,8 +58,8 @@ func TestGetBuildError(t *testing.T) {
 }
 
 func TestDeleteBuild(t *testing.T) {
-	mockRegistry := test.BuildRegistry{}
 	buildId := "test-build-id"
+	mockRegistry := test.BuildRegistry{Build: &api.Build{JSONBase: kubeapi.JSONBase{ID: buildId}}}
 	storage := REST{&mockRegistry}
 	channel, err := storage.Delete(nil, buildId)
 	if err != nil {
@@ -84,8 +84,11 @@ func TestDeleteBuild(t *testing.T) {
 }
 
 func TestDeleteBuildError(t *testing.T) {
-	mockRegistry := test.BuildRegistry{Err: fmt.Errorf("Delete error")}
 	buildId := "test-build-id"
+	mockRegistry := test.BuildRegistry{
+		Err:   fmt.Errorf("Delete error"),
+		Build: &api.Build{JSONBase: kubeapi.JSONBase{ID: buildId}},
+	}
 	storage := REST{&mockRegistry}
 	channel, _ := storage.Delete(nil, buildId)
 	select {
@@ -233,7 +236,7 @@ func TestCreateBuild(t *testing.T) {
 	mockRegistry := test.BuildRegistry{}
 	storage := REST{&mockRegistry}
 	build := mockBuild()
-	channel, err := storage.Create(nil, build)
+	channel, err := storage.Create(kubeapi.NewDefaultContext(), build)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -256,11 +259,25 @@ func TestCreateBuild(t *testing.T) {
 	}
 }
 
+func TestCreateBuildNamespaceConflict(t *testing.T) {
+	mockRegistry := test.BuildRegistry{}
+	storage := REST{&mockRegistry}
+	build := mockBuild()
+	build.Namespace = "foo"
+	_, err := storage.Create(kubeapi.WithNamespace(kubeapi.NewContext(), "bar"), build)
+	if err == nil {
+		t.Fatal("expected an error, got none")
+	}
+	if !errors.IsConflict(err) {
+		t.Errorf("expected a conflict error, got %v", err)
+	}
+}
+
 func TestUpdateBuild(t *testing.T) {
 	mockRegistry := test.BuildRegistry{}
 	storage := REST{&mockRegistry}
 	build := mockBuild()
-	channel, err := storage.Update(nil, build)
+	channel, err := storage.Update(kubeapi.NewDefaultContext(), build)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -287,7 +304,7 @@ func TestUpdateBuildError(t *testing.T) {
 	mockRegistry := test.BuildRegistry{Err: fmt.Errorf("Update error")}
 	storage := REST{&mockRegistry}
 	build := mockBuild()
-	channel, err := storage.Update(nil, build)
+	channel, err := storage.Update(kubeapi.NewDefaultContext(), build)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -316,7 +333,7 @@ func TestBuildRESTValidatesCreate(t *testing.T) {
 		},
 	}
 	for desc, failureCase := range failureCases {
-		c, err := storage.Create(nil, &failureCase)
+		c, err := storage.Create(kubeapi.NewDefaultContext(), &failureCase)
 		if c != nil {
 			t.Errorf("%s: Expected nil channel", desc)
 		}
@@ -344,7 +361,7 @@ func TestBuildRESTValidatesUpdate(t *testing.T) {
 		},
 	}
 	for desc, failureCase := range failureCases {
-		c, err := storage.Update(nil, &failureCase)
+		c, err := storage.Update(kubeapi.NewDefaultContext(), &failureCase)
 		if c != nil {
 			t.Errorf("%s: Expected nil channel", desc)
 		}
@@ -0,0 +1,61 @@
+package buildartifacts
+
+import (
+	"fmt"
+
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/apiserver"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/build/api"
+	"github.com/openshift/origin/pkg/build/registry/build"
+	"github.com/openshift/origin/pkg/util/apierrors"
+)
+
+// REST implements the RESTStorage interface for updating a Build's collected
+// artifacts. It is registered as its own top-level "buildArtifacts" resource, the
+// same way buildStatus and buildFinalize are, rather than as a literal nested
+// /builds/{id}/artifacts path, so the builder pod can deposit artifacts without
+// needing the same authorization as full Build edits made through build.REST.
+type REST struct {
+	registry build.Registry
+}
+
+func NewREST(registry build.Registry) apiserver.RESTStorage {
+	return &REST{registry}
+}
+
+func (r *REST) New() runtime.Object {
+	return &api.Build{}
+}
+
+func (r *REST) Get(ctx kubeapi.Context, id string) (runtime.Object, error) {
+	return r.registry.GetBuild(id)
+}
+
+func (r *REST) List(ctx kubeapi.Context, selector, fields labels.Selector) (runtime.Object, error) {
+	return nil, apierrors.NewBadRequest("buildArtifacts", "BuildArtifacts can't be listed")
+}
+
+func (r *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
+	return nil, apierrors.NewBadRequest("buildArtifacts", "BuildArtifacts can't be created")
+}
+
+func (r *REST) Delete(ctx kubeapi.Context, id string) (<-chan runtime.Object, error) {
+	return nil, apierrors.NewBadRequest("buildArtifacts", "BuildArtifacts can't be deleted")
+}
+
+func (r *REST) Update(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
+	build, ok := obj.(*api.Build)
+	if !ok {
+		return nil, apierrors.NewBadRequest("build", fmt.Sprintf("not a build: %#v", obj))
+	}
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		err := r.registry.UpdateBuildArtifacts(build)
+		if err != nil {
+			return nil, err
+		}
+		return build, nil
+	}), nil
+}
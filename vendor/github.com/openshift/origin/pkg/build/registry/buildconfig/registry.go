@@ -11,6 +11,9 @@ type Registry interface {
 	ListBuildConfigs(labels labels.Selector) (*api.BuildConfigList, error)
 	// GetBuildConfig retrieves a specific buildConfig.
 	GetBuildConfig(id string) (*api.BuildConfig, error)
+	// GetBuildConfigs retrieves the buildConfigs named by ids in a single call, so
+	// callers resolving many references don't have to issue one GetBuildConfig per id.
+	GetBuildConfigs(ids []string) (*api.BuildConfigList, error)
 	// CreateBuildConfig creates a new buildConfig.
 	CreateBuildConfig(buildConfig *api.BuildConfig) error
 	// UpdateBuildConfig updates a buildConfig.
@@ -13,6 +13,7 @@ import (
 
 	"github.com/openshift/origin/pkg/build/api"
 	"github.com/openshift/origin/pkg/build/api/validation"
+	"github.com/openshift/origin/pkg/util/apierrors"
 )
 
 // REST is an implementation of RESTStorage for the api server.
@@ -59,7 +60,10 @@ func (r *REST) Delete(ctx kubeapi.Context, id string) (<-chan runtime.Object, er
 func (r *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
 	buildConfig, ok := obj.(*api.BuildConfig)
 	if !ok {
-		return nil, fmt.Errorf("not a buildConfig: %#v", obj)
+		return nil, apierrors.NewBadRequest("buildConfig", fmt.Sprintf("not a buildConfig: %#v", obj))
+	}
+	if !kubeapi.ValidNamespace(ctx, &buildConfig.JSONBase) {
+		return nil, errors.NewConflict("buildConfig", buildConfig.Namespace, fmt.Errorf("BuildConfig.Namespace does not match the provided context"))
 	}
 	if len(buildConfig.ID) == 0 {
 		buildConfig.ID = uuid.NewUUID().String()
@@ -81,7 +85,10 @@ func (r *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.O
 func (r *REST) Update(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
 	buildConfig, ok := obj.(*api.BuildConfig)
 	if !ok {
-		return nil, fmt.Errorf("not a buildConfig: %#v", obj)
+		return nil, apierrors.NewBadRequest("buildConfig", fmt.Sprintf("not a buildConfig: %#v", obj))
+	}
+	if !kubeapi.ValidNamespace(ctx, &buildConfig.JSONBase) {
+		return nil, errors.NewConflict("buildConfig", buildConfig.Namespace, fmt.Errorf("BuildConfig.Namespace does not match the provided context"))
 	}
 	if errs := validation.ValidateBuildConfig(buildConfig); len(errs) > 0 {
 		return nil, errors.NewInvalid("buildConfig", buildConfig.ID, errs)
@@ -232,7 +232,7 @@ func TestCreateBuildConfig(t *testing.T) {
 	mockRegistry := test.BuildConfigRegistry{}
 	storage := REST{&mockRegistry}
 	buildConfig := mockBuildConfig()
-	channel, err := storage.Create(nil, buildConfig)
+	channel, err := storage.Create(kubeapi.NewDefaultContext(), buildConfig)
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
@@ -248,6 +248,20 @@ func TestCreateBuildConfig(t *testing.T) {
 	}
 }
 
+func TestCreateBuildConfigNamespaceConflict(t *testing.T) {
+	mockRegistry := test.BuildConfigRegistry{}
+	storage := REST{&mockRegistry}
+	buildConfig := mockBuildConfig()
+	buildConfig.Namespace = "foo"
+	_, err := storage.Create(kubeapi.WithNamespace(kubeapi.NewContext(), "bar"), buildConfig)
+	if err == nil {
+		t.Fatal("expected an error, got none")
+	}
+	if !errors.IsConflict(err) {
+		t.Errorf("expected a conflict error, got %v", err)
+	}
+}
+
 func mockBuildConfig() *api.BuildConfig {
 	return &api.BuildConfig{
 		JSONBase: kubeapi.JSONBase{
@@ -268,7 +282,7 @@ func TestUpdateBuildConfig(t *testing.T) {
 	mockRegistry := test.BuildConfigRegistry{}
 	storage := REST{&mockRegistry}
 	buildConfig := mockBuildConfig()
-	channel, err := storage.Update(nil, buildConfig)
+	channel, err := storage.Update(kubeapi.NewDefaultContext(), buildConfig)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -295,7 +309,7 @@ func TestUpdateBuildConfigError(t *testing.T) {
 	mockRegistry := test.BuildConfigRegistry{Err: fmt.Errorf("Update error")}
 	storage := REST{&mockRegistry}
 	buildConfig := mockBuildConfig()
-	channel, err := storage.Update(nil, buildConfig)
+	channel, err := storage.Update(kubeapi.NewDefaultContext(), buildConfig)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -346,7 +360,7 @@ func TestBuildConfigRESTValidatesCreate(t *testing.T) {
 		},
 	}
 	for desc, failureCase := range failureCases {
-		c, err := storage.Create(nil, &failureCase)
+		c, err := storage.Create(kubeapi.NewDefaultContext(), &failureCase)
 		if c != nil {
 			t.Errorf("%s: Expected nil channel", desc)
 		}
@@ -405,7 +419,7 @@ func TestBuildRESTValidatesUpdate(t *testing.T) {
 		},
 	}
 	for desc, failureCase := range failureCases {
-		c, err := storage.Update(nil, &failureCase)
+		c, err := storage.Update(kubeapi.NewDefaultContext(), &failureCase)
 		if c != nil {
 			t.Errorf("%s: Expected nil channel", desc)
 		}
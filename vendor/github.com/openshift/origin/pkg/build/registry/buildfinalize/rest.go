@@ -0,0 +1,67 @@
+package buildfinalize
+
+import (
+	"fmt"
+
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/apiserver"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/build/api"
+	"github.com/openshift/origin/pkg/build/registry/build"
+	"github.com/openshift/origin/pkg/util/apierrors"
+)
+
+// REST implements the RESTStorage interface for clearing a Build's finalizers. It is
+// registered as its own top-level resource so a controller that has finished its
+// cleanup can clear its own finalizer without needing full Build update authorization.
+type REST struct {
+	registry build.Registry
+}
+
+// NewREST creates a new REST for build finalization.
+func NewREST(registry build.Registry) apiserver.RESTStorage {
+	return &REST{registry}
+}
+
+// New creates a new Build object.
+func (r *REST) New() runtime.Object {
+	return &api.Build{}
+}
+
+// Get obtains the build specified by its id.
+func (r *REST) Get(ctx kubeapi.Context, id string) (runtime.Object, error) {
+	return r.registry.GetBuild(id)
+}
+
+// List is not supported for build finalization.
+func (r *REST) List(ctx kubeapi.Context, selector, fields labels.Selector) (runtime.Object, error) {
+	return nil, apierrors.NewBadRequest("buildFinalize", "BuildFinalize can't be listed")
+}
+
+// Create is not supported for build finalization.
+func (r *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
+	return nil, apierrors.NewBadRequest("buildFinalize", "BuildFinalize can't be created")
+}
+
+// Delete is not supported for build finalization.
+func (r *REST) Delete(ctx kubeapi.Context, id string) (<-chan runtime.Object, error) {
+	return nil, apierrors.NewBadRequest("buildFinalize", "BuildFinalize can't be deleted")
+}
+
+// Update persists the Finalizers list already edited by the caller, completing the
+// Build's pending deletion once none remain.
+func (r *REST) Update(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
+	build, ok := obj.(*api.Build)
+	if !ok {
+		return nil, apierrors.NewBadRequest("build", fmt.Sprintf("not a build: %#v", obj))
+	}
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		err := r.registry.FinalizeBuild(build)
+		if err != nil {
+			return nil, err
+		}
+		return build, nil
+	}), nil
+}
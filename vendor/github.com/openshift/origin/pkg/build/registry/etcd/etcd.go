@@ -3,7 +3,10 @@ package etcd
 import (
 	etcderr "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors/etcd"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+	"github.com/golang/glog"
 
 	"github.com/openshift/origin/pkg/build/api"
 )
@@ -41,6 +44,18 @@ func (r *Etcd) ListBuilds(selector labels.Selector) (*api.BuildList, error) {
 	return &allBuilds, nil
 }
 
+// WatchBuilds begins watching for new, changed, or deleted Builds starting at resourceVersion.
+func (r *Etcd) WatchBuilds(resourceVersion uint64, filter func(build *api.Build) bool) (watch.Interface, error) {
+	return r.WatchList("/registry/builds", resourceVersion, func(obj runtime.Object) bool {
+		build, ok := obj.(*api.Build)
+		if !ok {
+			glog.Errorf("Unexpected object during build watch: %#v", obj)
+			return false
+		}
+		return filter(build)
+	})
+}
+
 // GetBuild gets a specific Build specified by its ID.
 func (r *Etcd) GetBuild(id string) (*api.Build, error) {
 	var build api.Build
@@ -63,6 +78,20 @@ func (r *Etcd) UpdateBuild(build *api.Build) error {
 	return etcderr.InterpretUpdateError(err, "build", build.ID)
 }
 
+// UpdateBuildStatus writes build, whole, over the stored Build; it differs from UpdateBuild
+// only in the resource it is served through.
+func (r *Etcd) UpdateBuildStatus(build *api.Build) error {
+	err := r.SetObj(makeBuildKey(build.ID), build)
+	return etcderr.InterpretUpdateError(err, "build", build.ID)
+}
+
+// UpdateBuildArtifacts writes build, whole, over the stored Build; it differs from
+// UpdateBuild only in the resource it is served through.
+func (r *Etcd) UpdateBuildArtifacts(build *api.Build) error {
+	err := r.SetObj(makeBuildKey(build.ID), build)
+	return etcderr.InterpretUpdateError(err, "build", build.ID)
+}
+
 // DeleteBuild deletes a Build specified by its ID.
 func (r *Etcd) DeleteBuild(id string) error {
 	key := makeBuildKey(id)
@@ -70,6 +99,16 @@ func (r *Etcd) DeleteBuild(id string) error {
 	return etcderr.InterpretDeleteError(err, "build", id)
 }
 
+// FinalizeBuild persists build's Finalizers list, completing a pending deletion via
+// DeleteBuild once the list becomes empty and DeletionTimestamp is set.
+func (r *Etcd) FinalizeBuild(build *api.Build) error {
+	if len(build.Finalizers) == 0 && !build.DeletionTimestamp.IsZero() {
+		return r.DeleteBuild(build.ID)
+	}
+	err := r.SetObj(makeBuildKey(build.ID), build)
+	return etcderr.InterpretUpdateError(err, "build", build.ID)
+}
+
 func makeBuildConfigKey(id string) string {
 	return "/registry/build-configs/" + id
 }
@@ -101,6 +140,19 @@ func (r *Etcd) GetBuildConfig(id string) (*api.BuildConfig, error) {
 	return &config, nil
 }
 
+// GetBuildConfigs gets the BuildConfigs specified by their IDs.
+func (r *Etcd) GetBuildConfigs(ids []string) (*api.BuildConfigList, error) {
+	configs := api.BuildConfigList{Items: make([]api.BuildConfig, 0, len(ids))}
+	for _, id := range ids {
+		config, err := r.GetBuildConfig(id)
+		if err != nil {
+			return nil, err
+		}
+		configs.Items = append(configs.Items, *config)
+	}
+	return &configs, nil
+}
+
 // CreateBuildConfig creates a new BuildConfig.
 func (r *Etcd) CreateBuildConfig(config *api.BuildConfig) error {
 	err := r.CreateObj(makeBuildConfigKey(config.ID), config, 0)
@@ -0,0 +1,168 @@
+// Package memory implements build.Registry and buildconfig.Registry entirely in memory,
+// using pkg/util/memstore instead of etcd. It's meant for standalone/demo servers and
+// hermetic integration tests, where a real etcd isn't available or desired.
+package memory
+
+import (
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+
+	"github.com/openshift/origin/pkg/build/api"
+	"github.com/openshift/origin/pkg/util/memstore"
+)
+
+// Registry implements build.Registry and buildconfig.Registry in memory.
+type Registry struct {
+	builds       *memstore.Store
+	buildConfigs *memstore.Store
+}
+
+// buildStatusIndex is the name of the secondary index that lets ListBuildsByStatus
+// avoid scanning every build in the store.
+const buildStatusIndex = "status"
+
+// New creates an empty in-memory Registry.
+func New() *Registry {
+	builds := memstore.NewStore("build")
+	builds.AddIndex(buildStatusIndex, func(obj runtime.Object) string {
+		return string(obj.(*api.Build).Status)
+	})
+	return &Registry{
+		builds:       builds,
+		buildConfigs: memstore.NewStore("buildConfig"),
+	}
+}
+
+// ListBuilds obtains a list of Builds that match selector.
+func (r *Registry) ListBuilds(selector labels.Selector) (*api.BuildList, error) {
+	list := &api.BuildList{}
+	for _, obj := range r.builds.List() {
+		build := obj.(*api.Build)
+		if selector.Matches(labels.Set(build.Labels)) {
+			list.Items = append(list.Items, *build)
+		}
+	}
+	list.ResourceVersion = r.builds.ResourceVersion()
+	return list, nil
+}
+
+// WatchBuilds begins watching for new, changed, or deleted Builds starting at
+// resourceVersion, so a caller can list builds and then watch from the list's
+// ResourceVersion without missing or duplicating events.
+func (r *Registry) WatchBuilds(resourceVersion uint64, filter func(build *api.Build) bool) (watch.Interface, error) {
+	w, err := r.builds.Watch(resourceVersion)
+	if err != nil {
+		return nil, err
+	}
+	return watch.Filter(w, func(e watch.Event) (watch.Event, bool) {
+		return e, filter(e.Object.(*api.Build))
+	}), nil
+}
+
+// ListBuildsByStatus obtains the Builds currently in the given status without scanning
+// builds in any other status.
+func (r *Registry) ListBuildsByStatus(status api.BuildStatus) (*api.BuildList, error) {
+	objs, err := r.builds.ListByIndex(buildStatusIndex, string(status))
+	if err != nil {
+		return nil, err
+	}
+	list := &api.BuildList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*api.Build))
+	}
+	return list, nil
+}
+
+// GetBuild retrieves a specific Build.
+func (r *Registry) GetBuild(id string) (*api.Build, error) {
+	obj, err := r.builds.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*api.Build), nil
+}
+
+// CreateBuild creates a new Build.
+func (r *Registry) CreateBuild(build *api.Build) error {
+	return r.builds.Create(build.ID, build)
+}
+
+// UpdateBuild updates an existing Build.
+func (r *Registry) UpdateBuild(build *api.Build) error {
+	return r.builds.Update(build.ID, build)
+}
+
+// UpdateBuildStatus updates the status of an existing Build.
+func (r *Registry) UpdateBuildStatus(build *api.Build) error {
+	return r.builds.Update(build.ID, build)
+}
+
+// UpdateBuildArtifacts updates the Artifacts of an existing Build.
+func (r *Registry) UpdateBuildArtifacts(build *api.Build) error {
+	return r.builds.Update(build.ID, build)
+}
+
+// DeleteBuild deletes a Build.
+func (r *Registry) DeleteBuild(id string) error {
+	return r.builds.Delete(id)
+}
+
+// FinalizeBuild persists build's Finalizers list, completing a pending deletion once
+// the list becomes empty and DeletionTimestamp is set.
+func (r *Registry) FinalizeBuild(build *api.Build) error {
+	if len(build.Finalizers) == 0 && !build.DeletionTimestamp.IsZero() {
+		return r.builds.Delete(build.ID)
+	}
+	return r.builds.Update(build.ID, build)
+}
+
+// ListBuildConfigs obtains a list of BuildConfigs that match selector.
+func (r *Registry) ListBuildConfigs(selector labels.Selector) (*api.BuildConfigList, error) {
+	list := &api.BuildConfigList{}
+	for _, obj := range r.buildConfigs.List() {
+		config := obj.(*api.BuildConfig)
+		if selector.Matches(labels.Set(config.Labels)) {
+			list.Items = append(list.Items, *config)
+		}
+	}
+	list.ResourceVersion = r.buildConfigs.ResourceVersion()
+	return list, nil
+}
+
+// GetBuildConfig retrieves a specific BuildConfig.
+func (r *Registry) GetBuildConfig(id string) (*api.BuildConfig, error) {
+	obj, err := r.buildConfigs.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*api.BuildConfig), nil
+}
+
+// GetBuildConfigs retrieves the BuildConfigs specified by their IDs.
+func (r *Registry) GetBuildConfigs(ids []string) (*api.BuildConfigList, error) {
+	list := &api.BuildConfigList{}
+	for _, id := range ids {
+		config, err := r.GetBuildConfig(id)
+		if err != nil {
+			return nil, err
+		}
+		list.Items = append(list.Items, *config)
+	}
+	return list, nil
+}
+
+// CreateBuildConfig creates a new BuildConfig.
+func (r *Registry) CreateBuildConfig(config *api.BuildConfig) error {
+	return r.buildConfigs.Create(config.ID, config)
+}
+
+// UpdateBuildConfig updates an existing BuildConfig.
+func (r *Registry) UpdateBuildConfig(config *api.BuildConfig) error {
+	return r.buildConfigs.Update(config.ID, config)
+}
+
+// DeleteBuildConfig deletes a BuildConfig.
+func (r *Registry) DeleteBuildConfig(id string) error {
+	return r.buildConfigs.Delete(id)
+}
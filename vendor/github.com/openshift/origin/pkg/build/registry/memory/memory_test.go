@@ -0,0 +1,167 @@
+package memory
+
+import (
+	"strconv"
+	"testing"
+
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+
+	"github.com/openshift/origin/pkg/build/api"
+)
+
+func TestCreateGetListBuild(t *testing.T) {
+	registry := New()
+	build := &api.Build{JSONBase: kubeapi.JSONBase{ID: "foo"}}
+
+	if err := registry.CreateBuild(build); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := registry.GetBuild("foo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ID != "foo" {
+		t.Errorf("unexpected build: %#v", got)
+	}
+
+	list, err := registry.ListBuilds(labels.Everything())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list.Items) != 1 {
+		t.Errorf("expected 1 build, got %d", len(list.Items))
+	}
+}
+
+func TestUpdateBuildStatusAndDelete(t *testing.T) {
+	registry := New()
+	build := &api.Build{JSONBase: kubeapi.JSONBase{ID: "foo"}, Status: api.BuildNew}
+	if err := registry.CreateBuild(build); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	build.Status = api.BuildRunning
+	if err := registry.UpdateBuildStatus(build); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := registry.GetBuild("foo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Status != api.BuildRunning {
+		t.Errorf("expected status %s, got %s", api.BuildRunning, got.Status)
+	}
+
+	if err := registry.DeleteBuild("foo"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := registry.GetBuild("foo"); err == nil {
+		t.Errorf("expected an error getting a deleted build")
+	}
+}
+
+func TestListBuildsByStatus(t *testing.T) {
+	registry := New()
+	builds := []*api.Build{
+		{JSONBase: kubeapi.JSONBase{ID: "a"}, Status: api.BuildRunning},
+		{JSONBase: kubeapi.JSONBase{ID: "b"}, Status: api.BuildRunning},
+		{JSONBase: kubeapi.JSONBase{ID: "c"}, Status: api.BuildComplete},
+	}
+	for _, build := range builds {
+		if err := registry.CreateBuild(build); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	list, err := registry.ListBuildsByStatus(api.BuildRunning)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list.Items) != 2 {
+		t.Errorf("expected 2 running builds, got %d", len(list.Items))
+	}
+
+	updated := &api.Build{JSONBase: kubeapi.JSONBase{ID: "a"}, Status: api.BuildComplete}
+	if err := registry.UpdateBuildStatus(updated); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	list, err = registry.ListBuildsByStatus(api.BuildRunning)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list.Items) != 1 {
+		t.Errorf("expected 1 running build after the status change, got %d", len(list.Items))
+	}
+}
+
+func TestGetBuildConfigs(t *testing.T) {
+	registry := New()
+	for _, id := range []string{"a", "b"} {
+		if err := registry.CreateBuildConfig(&api.BuildConfig{JSONBase: kubeapi.JSONBase{ID: id}}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	list, err := registry.GetBuildConfigs([]string{"a", "b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list.Items) != 2 {
+		t.Errorf("expected 2 buildConfigs, got %d", len(list.Items))
+	}
+
+	if _, err := registry.GetBuildConfigs([]string{"a", "missing"}); err == nil {
+		t.Errorf("expected an error resolving a missing buildConfig")
+	}
+}
+
+// buildCount is how many builds benchmarkRegistry seeds the registry with, roughly the
+// scale BuildController's resyncBuilds lists on every sync period.
+const buildCount = 1000
+
+func benchmarkRegistry(b *testing.B) *Registry {
+	registry := New()
+	for i := 0; i < buildCount; i++ {
+		status := api.BuildPending
+		if i%2 == 0 {
+			status = api.BuildRunning
+		}
+		build := &api.Build{
+			JSONBase: kubeapi.JSONBase{ID: strconv.Itoa(i)},
+			Status:   status,
+		}
+		if err := registry.CreateBuild(build); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+	return registry
+}
+
+// BenchmarkListBuilds measures a full, unindexed scan of the registry, the same path
+// BuildController.resyncBuilds takes on every sync period.
+func BenchmarkListBuilds(b *testing.B) {
+	b.ReportAllocs()
+	registry := benchmarkRegistry(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := registry.ListBuilds(labels.Everything()); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+// BenchmarkListBuildsByStatus measures the indexed lookup BuildController could use
+// instead of a full scan to find, for example, every currently Running build.
+func BenchmarkListBuildsByStatus(b *testing.B) {
+	b.ReportAllocs()
+	registry := benchmarkRegistry(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := registry.ListBuildsByStatus(api.BuildRunning); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
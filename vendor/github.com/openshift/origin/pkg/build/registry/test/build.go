@@ -2,6 +2,7 @@ package test
 
 import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
 	"github.com/openshift/origin/pkg/build/api"
 )
 
@@ -20,6 +21,10 @@ func (r *BuildRegistry) GetBuild(id string) (*api.Build, error) {
 	return r.Build, r.Err
 }
 
+func (r *BuildRegistry) WatchBuilds(resourceVersion uint64, filter func(build *api.Build) bool) (watch.Interface, error) {
+	return nil, r.Err
+}
+
 func (r *BuildRegistry) CreateBuild(build *api.Build) error {
 	return r.Err
 }
@@ -28,7 +33,19 @@ func (r *BuildRegistry) UpdateBuild(build *api.Build) error {
 	return r.Err
 }
 
+func (r *BuildRegistry) UpdateBuildStatus(build *api.Build) error {
+	return r.Err
+}
+
+func (r *BuildRegistry) UpdateBuildArtifacts(build *api.Build) error {
+	return r.Err
+}
+
 func (r *BuildRegistry) DeleteBuild(id string) error {
 	r.DeletedBuildId = id
 	return r.Err
 }
+
+func (r *BuildRegistry) FinalizeBuild(build *api.Build) error {
+	return r.Err
+}
@@ -20,6 +20,10 @@ func (r *BuildConfigRegistry) GetBuildConfig(id string) (*api.BuildConfig, error
 	return r.BuildConfig, r.Err
 }
 
+func (r *BuildConfigRegistry) GetBuildConfigs(ids []string) (*api.BuildConfigList, error) {
+	return r.BuildConfigs, r.Err
+}
+
 func (r *BuildConfigRegistry) CreateBuildConfig(config *api.BuildConfig) error {
 	return r.Err
 }
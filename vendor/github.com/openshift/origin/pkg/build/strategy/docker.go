@@ -3,25 +3,31 @@ package strategy
 import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	buildapi "github.com/openshift/origin/pkg/build/api"
+	secretapi "github.com/openshift/origin/pkg/secret/api"
+	"github.com/openshift/origin/pkg/util/ownerref"
 )
 
 // DockerBuildStrategy creates Docker build using a docker builder image
 type DockerBuildStrategy struct {
 	dockerBuilderImage string
+	override           PodTemplateOverride
 }
 
-// NewDockerBuildStrategy creates a new DockerBuildStrategy
-func NewDockerBuildStrategy(dockerBuilderImage string) *DockerBuildStrategy {
-	return &DockerBuildStrategy{dockerBuilderImage}
+// NewDockerBuildStrategy creates a new DockerBuildStrategy. override is merged into
+// every pod this strategy generates.
+func NewDockerBuildStrategy(dockerBuilderImage string, override PodTemplateOverride) *DockerBuildStrategy {
+	return &DockerBuildStrategy{dockerBuilderImage, override}
 }
 
-// CreateBuildPod creates the pod to be used for the Docker build
-// TODO: Make the Pod definition configurable
-func (bs *DockerBuildStrategy) CreateBuildPod(build *buildapi.Build) (*api.Pod, error) {
+// CreateBuildPod creates the pod to be used for the Docker build. When sourceCacheURL is set,
+// the build clones from it instead of from DOCKER_CONTEXT_URL directly, falling back to
+// DOCKER_CONTEXT_URL if the mirror doesn't have the ref.
+func (bs *DockerBuildStrategy) CreateBuildPod(build *buildapi.Build, pushSecret, pullSecret *secretapi.Secret, sourceCacheURL string) (*api.Pod, error) {
 	pod := &api.Pod{
 		JSONBase: api.JSONBase{
 			ID: build.PodID,
 		},
+		Labels: ownerref.Set(nil, "Build", build.ID, build.UID),
 		DesiredState: api.PodState{
 			Manifest: api.ContainerManifest{
 				Version: "v1beta1",
@@ -33,6 +39,7 @@ func (bs *DockerBuildStrategy) CreateBuildPod(build *buildapi.Build) (*api.Pod,
 							{Name: "BUILD_TAG", Value: build.Input.ImageTag},
 							{Name: "DOCKER_CONTEXT_URL", Value: build.Input.SourceURI},
 							{Name: "DOCKER_REGISTRY", Value: build.Input.Registry},
+							{Name: "SOURCE_CACHE_URL", Value: sourceCacheURL},
 						},
 					},
 				},
@@ -45,5 +52,9 @@ func (bs *DockerBuildStrategy) CreateBuildPod(build *buildapi.Build) (*api.Pod,
 
 	setupDockerSocket(pod)
 	setupDockerConfig(pod)
+	if err := setupSecretVolume(pod, "push-secret", "PUSH_DOCKERCFG_PATH", "/var/run/secrets/openshift.io/push", pushSecret); err != nil {
+		return nil, err
+	}
+	bs.override.apply(pod)
 	return pod, nil
 }
@@ -8,9 +8,9 @@ import (
 )
 
 func TestDockerCreateBuildPod(t *testing.T) {
-	strategy := NewDockerBuildStrategy("docker-test-image")
+	strategy := NewDockerBuildStrategy("docker-test-image", PodTemplateOverride{})
 	expected := mockDockerBuild()
-	actual, _ := strategy.CreateBuildPod(expected)
+	actual, _ := strategy.CreateBuildPod(expected, nil, nil, "")
 
 	if actual.JSONBase.ID != expected.PodID {
 		t.Errorf("Expected %s, but got %s!", expected.PodID, actual.JSONBase.ID)
@@ -0,0 +1,65 @@
+package strategy
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	secretapi "github.com/openshift/origin/pkg/secret/api"
+	"github.com/openshift/origin/pkg/util/ownerref"
+)
+
+// JenkinsBuildStrategy creates a pod that triggers a Jenkins job and then exits,
+// leaving the build controller to wait for the job to report the build's outcome
+// back through UpdateBuildStatus rather than watching the pod run to completion.
+type JenkinsBuildStrategy struct {
+	jenkinsTriggerImage string
+	masterAddr          string
+	override            PodTemplateOverride
+}
+
+// NewJenkinsBuildStrategy creates a new JenkinsBuildStrategy. masterAddr is passed to
+// the trigger pod so the Jenkins job it starts knows where to report status back to.
+// override is merged into every pod this strategy generates.
+func NewJenkinsBuildStrategy(jenkinsTriggerImage, masterAddr string, override PodTemplateOverride) *JenkinsBuildStrategy {
+	return &JenkinsBuildStrategy{jenkinsTriggerImage, masterAddr, override}
+}
+
+// CreateBuildPod creates the pod that triggers build's Jenkins job. The Jenkins job
+// itself is responsible for any credentials it needs and for its own source checkout,
+// so pushSecret, pullSecret, and sourceCacheURL are unused here.
+func (bs *JenkinsBuildStrategy) CreateBuildPod(build *buildapi.Build, pushSecret, pullSecret *secretapi.Secret, sourceCacheURL string) (*api.Pod, error) {
+	if build.Input.JenkinsInfo == nil {
+		return nil, fmt.Errorf("build %s is a Jenkins build but has no JenkinsInfo", build.ID)
+	}
+
+	pod := &api.Pod{
+		JSONBase: api.JSONBase{
+			ID: build.PodID,
+		},
+		Labels: ownerref.Set(nil, "Build", build.ID, build.UID),
+		DesiredState: api.PodState{
+			Manifest: api.ContainerManifest{
+				Version: "v1beta1",
+				Containers: []api.Container{
+					{
+						Name:  "jenkins-trigger",
+						Image: bs.jenkinsTriggerImage,
+						Env: []api.EnvVar{
+							{Name: "BUILD_ID", Value: build.ID},
+							{Name: "JENKINS_URL", Value: build.Input.JenkinsInfo.JenkinsURL},
+							{Name: "JENKINS_JOB_NAME", Value: build.Input.JenkinsInfo.JobName},
+							{Name: "KUBERNETES_MASTER", Value: bs.masterAddr},
+						},
+					},
+				},
+				RestartPolicy: api.RestartPolicy{
+					Never: &api.RestartPolicyNever{},
+				},
+			},
+		},
+	}
+
+	bs.override.apply(pod)
+	return pod, nil
+}
@@ -0,0 +1,64 @@
+package strategy
+
+import (
+	"testing"
+
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/openshift/origin/pkg/build/api"
+)
+
+func TestJenkinsCreateBuildPod(t *testing.T) {
+	strategy := NewJenkinsBuildStrategy("jenkins-trigger-test-image", "master.example.com", PodTemplateOverride{})
+	expected := mockJenkinsBuild()
+	actual, err := strategy.CreateBuildPod(expected, nil, nil, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if actual.JSONBase.ID != expected.PodID {
+		t.Errorf("Expected %s, but got %s!", expected.PodID, actual.JSONBase.ID)
+	}
+	container := actual.DesiredState.Manifest.Containers[0]
+	if container.Name != "jenkins-trigger" {
+		t.Errorf("Expected jenkins-trigger, but got %s!", container.Name)
+	}
+	if container.Image != strategy.jenkinsTriggerImage {
+		t.Errorf("Expected %s image, got %s!", strategy.jenkinsTriggerImage, container.Image)
+	}
+	if e := container.Env[0]; e.Name != "BUILD_ID" || e.Value != expected.ID {
+		t.Errorf("Expected BUILD_ID=%s, got %s=%s!", expected.ID, e.Name, e.Value)
+	}
+	if e := container.Env[1]; e.Name != "JENKINS_URL" || e.Value != expected.Input.JenkinsInfo.JenkinsURL {
+		t.Errorf("Expected JENKINS_URL=%s, got %s=%s!", expected.Input.JenkinsInfo.JenkinsURL, e.Name, e.Value)
+	}
+	if e := container.Env[2]; e.Name != "JENKINS_JOB_NAME" || e.Value != expected.Input.JenkinsInfo.JobName {
+		t.Errorf("Expected JENKINS_JOB_NAME=%s, got %s=%s!", expected.Input.JenkinsInfo.JobName, e.Name, e.Value)
+	}
+}
+
+func TestJenkinsCreateBuildPodMissingInfo(t *testing.T) {
+	strategy := NewJenkinsBuildStrategy("jenkins-trigger-test-image", "master.example.com", PodTemplateOverride{})
+	build := mockJenkinsBuild()
+	build.Input.JenkinsInfo = nil
+	if _, err := strategy.CreateBuildPod(build, nil, nil, ""); err == nil {
+		t.Error("Expected error, but none happened!")
+	}
+}
+
+func mockJenkinsBuild() *api.Build {
+	return &api.Build{
+		JSONBase: kubeapi.JSONBase{
+			ID: "jenkinsBuild",
+		},
+		Input: api.BuildInput{
+			Type:     api.JenkinsBuildType,
+			ImageTag: "repository/jenkinsBuild",
+			JenkinsInfo: &api.JenkinsBuildInput{
+				JenkinsURL: "https://jenkins.example.com",
+				JobName:    "my-job",
+			},
+		},
+		Status: api.BuildNew,
+		PodID:  "-the-pod-id",
+	}
+}
@@ -5,12 +5,15 @@ import (
 
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	buildapi "github.com/openshift/origin/pkg/build/api"
+	secretapi "github.com/openshift/origin/pkg/secret/api"
+	"github.com/openshift/origin/pkg/util/ownerref"
 )
 
 // STIBuildStrategy creates STI(source to image) builds
 type STIBuildStrategy struct {
 	stiBuilderImage      string
 	tempDirectoryCreator TempDirectoryCreator
+	override             PodTemplateOverride
 }
 
 type TempDirectoryCreator interface {
@@ -25,19 +28,21 @@ func (tc *tempDirectoryCreator) CreateTempDirectory() (string, error) {
 
 var STITempDirectoryCreator = &tempDirectoryCreator{}
 
-// NewSTIBuildStrategy creates a new STIBuildStrategy with the given
-// builder image
-func NewSTIBuildStrategy(stiBuilderImage string, tc TempDirectoryCreator) *STIBuildStrategy {
-	return &STIBuildStrategy{stiBuilderImage, tc}
+// NewSTIBuildStrategy creates a new STIBuildStrategy with the given builder image.
+// override is merged into every pod this strategy generates.
+func NewSTIBuildStrategy(stiBuilderImage string, tc TempDirectoryCreator, override PodTemplateOverride) *STIBuildStrategy {
+	return &STIBuildStrategy{stiBuilderImage, tc, override}
 }
 
-// CreateBuildPod creates a pod that will execute the STI build
-// TODO: Make the Pod definition configurable
-func (bs *STIBuildStrategy) CreateBuildPod(build *buildapi.Build) (*api.Pod, error) {
+// CreateBuildPod creates a pod that will execute the STI build. When sourceCacheURL is set,
+// the build clones from it instead of from SOURCE_URI directly, falling back to SOURCE_URI if
+// the mirror doesn't have the ref.
+func (bs *STIBuildStrategy) CreateBuildPod(build *buildapi.Build, pushSecret, pullSecret *secretapi.Secret, sourceCacheURL string) (*api.Pod, error) {
 	pod := &api.Pod{
 		JSONBase: api.JSONBase{
 			ID: build.PodID,
 		},
+		Labels: ownerref.Set(nil, "Build", build.ID, build.UID),
 		DesiredState: api.PodState{
 			Manifest: api.ContainerManifest{
 				Version: "v1beta1",
@@ -50,6 +55,7 @@ func (bs *STIBuildStrategy) CreateBuildPod(build *buildapi.Build) (*api.Pod, err
 							{Name: "DOCKER_REGISTRY", Value: build.Input.Registry},
 							{Name: "SOURCE_URI", Value: build.Input.SourceURI},
 							{Name: "SOURCE_REF", Value: build.Input.SourceRef},
+							{Name: "SOURCE_CACHE_URL", Value: sourceCacheURL},
 							{Name: "BUILDER_IMAGE", Value: build.Input.BuilderImage},
 						},
 					},
@@ -67,6 +73,13 @@ func (bs *STIBuildStrategy) CreateBuildPod(build *buildapi.Build) (*api.Pod, err
 
 	setupDockerSocket(pod)
 	setupDockerConfig(pod)
+	if err := setupSecretVolume(pod, "push-secret", "PUSH_DOCKERCFG_PATH", "/var/run/secrets/openshift.io/push", pushSecret); err != nil {
+		return nil, err
+	}
+	if err := setupSecretVolume(pod, "pull-secret", "PULL_DOCKERCFG_PATH", "/var/run/secrets/openshift.io/pull", pullSecret); err != nil {
+		return nil, err
+	}
+	bs.override.apply(pod)
 	return pod, nil
 }
 
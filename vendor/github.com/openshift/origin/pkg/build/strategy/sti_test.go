@@ -14,9 +14,9 @@ func (t *FakeTempDirCreator) CreateTempDirectory() (string, error) {
 }
 
 func TestSTICreateBuildPod(t *testing.T) {
-	strategy := NewSTIBuildStrategy("sti-test-image", &FakeTempDirCreator{})
+	strategy := NewSTIBuildStrategy("sti-test-image", &FakeTempDirCreator{}, PodTemplateOverride{})
 	expected := mockSTIBuild()
-	actual, _ := strategy.CreateBuildPod(expected)
+	actual, _ := strategy.CreateBuildPod(expected, nil, nil, "")
 
 	if actual.JSONBase.ID != expected.PodID {
 		t.Errorf("Expected %s, but got %s!", expected.PodID, actual.JSONBase.ID)
@@ -47,7 +47,10 @@ func TestSTICreateBuildPod(t *testing.T) {
 	if e := container.Env[3]; e.Name != "SOURCE_REF" || e.Value != expected.Input.SourceRef {
 		t.Errorf("Expected %s got %s:%s!", expected.Input.SourceRef, e.Name, e.Value)
 	}
-	if e := container.Env[4]; e.Name != "BUILDER_IMAGE" || e.Value != expected.Input.BuilderImage {
+	if e := container.Env[4]; e.Name != "SOURCE_CACHE_URL" || e.Value != "" {
+		t.Errorf("Expected an empty SOURCE_CACHE_URL, got %s:%s!", e.Name, e.Value)
+	}
+	if e := container.Env[5]; e.Name != "BUILDER_IMAGE" || e.Value != expected.Input.BuilderImage {
 		t.Errorf("Expected %s, got %s:%s!", expected.Input.BuilderImage, e.Name, e.Value)
 	}
 }
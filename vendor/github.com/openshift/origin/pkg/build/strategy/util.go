@@ -1,12 +1,45 @@
 package strategy
 
 import (
+	"io/ioutil"
 	"os"
 	"path"
 
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	secretapi "github.com/openshift/origin/pkg/secret/api"
 )
 
+// PodTemplateOverride holds administrator-managed additions merged into every pod a
+// BuildJobStrategy generates, so cluster policy (a cache proxy sidecar, a mandatory
+// volume) can be applied uniformly without patching strategy code. This vintage of the
+// API has no annotations field and no notion of tolerations, so only what the Pod type
+// actually supports today is covered. The zero value applies no override.
+type PodTemplateOverride struct {
+	// Labels are merged into the pod's labels, alongside the ownerref labels the
+	// strategy sets automatically. An override label never replaces one the strategy
+	// already set.
+	Labels map[string]string
+	// Volumes are appended to the pod's volume list.
+	Volumes []api.Volume
+	// Containers are appended to the pod's container list as sidecars alongside the
+	// strategy's own build container.
+	Containers []api.Container
+}
+
+// apply merges override into podSpec.
+func (override PodTemplateOverride) apply(podSpec *api.Pod) {
+	for name, value := range override.Labels {
+		if podSpec.Labels == nil {
+			podSpec.Labels = map[string]string{}
+		}
+		if _, exists := podSpec.Labels[name]; !exists {
+			podSpec.Labels[name] = value
+		}
+	}
+	podSpec.DesiredState.Manifest.Volumes = append(podSpec.DesiredState.Manifest.Volumes, override.Volumes...)
+	podSpec.DesiredState.Manifest.Containers = append(podSpec.DesiredState.Manifest.Containers, override.Containers...)
+}
+
 // setupDockerSocket configures the pod to support the host's Docker socket
 func setupDockerSocket(podSpec *api.Pod) {
 	dockerSocketVolume := api.Volume{
@@ -57,3 +90,48 @@ func setupDockerConfig(podSpec *api.Pod) {
 		append(podSpec.DesiredState.Manifest.Containers[0].VolumeMounts,
 			dockerConfigVolumeMount)
 }
+
+// setupSecretVolume writes secret's Data entries out as files in a fresh temp
+// directory and mounts that directory, read-only, into the pod's build container
+// at mountPath. envName is set to mountPath so the builder image knows where to
+// find the files. Does nothing if secret is nil.
+func setupSecretVolume(podSpec *api.Pod, volumeName, envName, mountPath string, secret *secretapi.Secret) error {
+	if secret == nil {
+		return nil
+	}
+
+	secretDir, err := ioutil.TempDir("", "secret")
+	if err != nil {
+		return err
+	}
+	for name, value := range secret.Data {
+		if err := ioutil.WriteFile(path.Join(secretDir, name), []byte(value), 0600); err != nil {
+			return err
+		}
+	}
+
+	secretVolume := api.Volume{
+		Name: volumeName,
+		Source: &api.VolumeSource{
+			HostDir: &api.HostDir{
+				Path: secretDir,
+			},
+		},
+	}
+	secretVolumeMount := api.VolumeMount{
+		Name:      volumeName,
+		ReadOnly:  true,
+		MountPath: mountPath,
+	}
+
+	podSpec.DesiredState.Manifest.Volumes = append(podSpec.DesiredState.Manifest.Volumes,
+		secretVolume)
+	podSpec.DesiredState.Manifest.Containers[0].VolumeMounts =
+		append(podSpec.DesiredState.Manifest.Containers[0].VolumeMounts,
+			secretVolumeMount)
+	podSpec.DesiredState.Manifest.Containers[0].Env =
+		append(podSpec.DesiredState.Manifest.Containers[0].Env,
+			api.EnvVar{Name: envName, Value: mountPath})
+
+	return nil
+}
@@ -53,3 +53,36 @@ func TestSetupDockerSocketHostSocket(t *testing.T) {
 		t.Error("Expected privileged to be false")
 	}
 }
+
+func TestPodTemplateOverrideApply(t *testing.T) {
+	pod := api.Pod{
+		Labels: map[string]string{"owner.kind": "Build"},
+		DesiredState: api.PodState{
+			Manifest: api.ContainerManifest{
+				Containers: []api.Container{
+					{Name: "build"},
+				},
+			},
+		},
+	}
+
+	override := PodTemplateOverride{
+		Labels:     map[string]string{"owner.kind": "should-not-win", "policy": "cache-proxy"},
+		Volumes:    []api.Volume{{Name: "cache-config"}},
+		Containers: []api.Container{{Name: "cache-proxy"}},
+	}
+	override.apply(&pod)
+
+	if e, a := "Build", pod.Labels["owner.kind"]; e != a {
+		t.Errorf("Expected the strategy's own label to win, got %s", a)
+	}
+	if e, a := "cache-proxy", pod.Labels["policy"]; e != a {
+		t.Errorf("Expected the override label to be added, got %s", a)
+	}
+	if len(pod.DesiredState.Manifest.Volumes) != 1 || pod.DesiredState.Manifest.Volumes[0].Name != "cache-config" {
+		t.Fatalf("Expected the override volume to be appended, got %#v", pod.DesiredState.Manifest.Volumes)
+	}
+	if len(pod.DesiredState.Manifest.Containers) != 2 || pod.DesiredState.Manifest.Containers[1].Name != "cache-proxy" {
+		t.Fatalf("Expected the override container to be appended as a sidecar, got %#v", pod.DesiredState.Manifest.Containers)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
 	"github.com/openshift/origin/pkg/build/api"
 	"github.com/openshift/origin/pkg/client"
 )
@@ -55,10 +56,15 @@ func (c *controller) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		badRequest(w, err.Error())
 		return
 	}
-	if uv.secret != buildCfg.Secret {
+	ctx = kapi.WithNamespace(ctx, buildCfg.Namespace)
+	if !secretAccepted(uv.secret, buildCfg) {
 		badRequest(w, "")
 		return
 	}
+	if buildCfg.Paused {
+		badRequest(w, "BuildConfig ", uv.buildId, " is paused")
+		return
+	}
 
 	plugin, ok := c.plugins[uv.plugin]
 	if !ok {
@@ -78,6 +84,10 @@ func (c *controller) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 			Input: buildCfg.DesiredInput,
 		}
 	}
+	if len(build.Namespace) == 0 {
+		build.Namespace = buildCfg.Namespace
+	}
+	build.BuildConfigID = buildCfg.ID
 
 	if _, err := c.osClient.CreateBuild(ctx, build); err != nil {
 		badRequest(w, err.Error())
@@ -97,6 +107,19 @@ func parseUrl(url string) (uv urlVars, err error) {
 	return
 }
 
+// secretAccepted reports whether secret matches buildCfg's current Secret, or its
+// PreviousSecret while that hasn't yet expired, so a hook secret can be rotated without a
+// window where a caller still configured with the old secret is rejected.
+func secretAccepted(secret string, buildCfg *api.BuildConfig) bool {
+	if secret == buildCfg.Secret {
+		return true
+	}
+	if len(buildCfg.PreviousSecret) == 0 || secret != buildCfg.PreviousSecret {
+		return false
+	}
+	return buildCfg.PreviousSecretExpiry == nil || util.Now().Before(buildCfg.PreviousSecretExpiry.Time)
+}
+
 func splitPath(path string) []string {
 	path = strings.Trim(path, "/")
 	if path == "" {
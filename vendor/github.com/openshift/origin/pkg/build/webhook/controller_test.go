@@ -7,8 +7,10 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
 	"github.com/openshift/origin/pkg/build/api"
 	"github.com/openshift/origin/pkg/client"
 )
@@ -46,6 +48,20 @@ func (p *pathPlugin) Extract(buildCfg *api.BuildConfig, path string, req *http.R
 	return nil, true, nil
 }
 
+type namespacedClient struct {
+	osClient
+	createdBuild *api.Build
+}
+
+func (c *namespacedClient) GetBuildConfig(ctx kapi.Context, id string) (result *api.BuildConfig, err error) {
+	return &api.BuildConfig{JSONBase: kapi.JSONBase{Namespace: "myproject"}, Secret: "secret101"}, nil
+}
+
+func (c *namespacedClient) CreateBuild(ctx kapi.Context, build *api.Build) (result *api.Build, err error) {
+	c.createdBuild = build
+	return build, nil
+}
+
 type errPlugin struct{}
 
 func (_ *errPlugin) Extract(buildCfg *api.BuildConfig, path string, req *http.Request) (*api.Build, bool, error) {
@@ -212,3 +228,58 @@ func TestInvokeWebhookOk(t *testing.T) {
 			string(body))
 	}
 }
+
+func TestSecretAccepted(t *testing.T) {
+	expired := util.Time{Time: time.Now().Add(-time.Hour)}
+	notExpired := util.Time{Time: time.Now().Add(time.Hour)}
+
+	buildCfg := &api.BuildConfig{
+		Secret:         "secret101",
+		PreviousSecret: "secret100",
+	}
+	if !secretAccepted("secret101", buildCfg) {
+		t.Errorf("Expected the current secret to be accepted")
+	}
+	if !secretAccepted("secret100", buildCfg) {
+		t.Errorf("Expected the previous secret to be accepted with no PreviousSecretExpiry set")
+	}
+
+	buildCfg.PreviousSecretExpiry = &notExpired
+	if !secretAccepted("secret100", buildCfg) {
+		t.Errorf("Expected the previous secret to be accepted before its expiry")
+	}
+
+	buildCfg.PreviousSecretExpiry = &expired
+	if secretAccepted("secret100", buildCfg) {
+		t.Errorf("Expected the previous secret to be rejected after its expiry")
+	}
+
+	if secretAccepted("wrongsecret", buildCfg) {
+		t.Errorf("Expected an unrelated secret to be rejected")
+	}
+}
+
+func TestInvokeWebhookDefaultsBuildNamespace(t *testing.T) {
+	fakeClient := &namespacedClient{}
+	server := httptest.NewServer(NewController(fakeClient, map[string]Plugin{
+		"okPlugin": &pathPlugin{},
+	}))
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/build100/secret101/okPlugin",
+		"application/json", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Wrong response code, expecting 200, got %s: %s!", resp.Status,
+			string(body))
+	}
+	if fakeClient.createdBuild == nil {
+		t.Fatalf("Expected a build to have been created")
+	}
+	if e, a := "myproject", fakeClient.createdBuild.Namespace; e != a {
+		t.Errorf("Expected build namespace %s, got %s", e, a)
+	}
+}
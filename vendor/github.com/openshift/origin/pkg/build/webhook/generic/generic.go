@@ -0,0 +1,28 @@
+package generic
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/openshift/origin/pkg/build/api"
+)
+
+// WebHook used for processing generic webhook requests. Unlike the provider-specific
+// plugins, it does not attempt to make sense of the request body; POSTing to it with the
+// right secret is all that's required to trigger a build of the config's DesiredInput, the
+// same as the webhook.controller does for any plugin that returns a nil build.
+type WebHook struct{}
+
+// New returns a generic webhook plugin.
+func New() *WebHook {
+	return &WebHook{}
+}
+
+// Extract validates that req is a POST and always requests that a Build be triggered,
+// leaving the Build itself to be defaulted by the caller from the BuildConfig.
+func (p *WebHook) Extract(buildCfg *api.BuildConfig, path string, req *http.Request) (build *api.Build, proceed bool, err error) {
+	if method := req.Method; method != "POST" {
+		return nil, false, fmt.Errorf("Unsupported HTTP method %s", method)
+	}
+	return nil, true, nil
+}
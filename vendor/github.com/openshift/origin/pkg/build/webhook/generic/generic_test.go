@@ -0,0 +1,58 @@
+package generic
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/openshift/origin/pkg/build/api"
+	"github.com/openshift/origin/pkg/build/webhook"
+	"github.com/openshift/origin/pkg/client"
+)
+
+type osClient struct {
+	client.Fake
+}
+
+func (_ *osClient) GetBuildConfig(ctx kapi.Context, id string) (result *api.BuildConfig, err error) {
+	return &api.BuildConfig{Secret: "secret101"}, nil
+}
+
+func TestWrongMethod(t *testing.T) {
+	server := httptest.NewServer(webhook.NewController(&osClient{}, map[string]webhook.Plugin{"generic": New()}))
+	defer server.Close()
+
+	resp, _ := http.Get(server.URL + "/build100/secret101/generic")
+	body, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusBadRequest ||
+		!strings.Contains(string(body), "method") {
+		t.Errorf("Expected BadRequest, got %s: %s!", resp.Status, string(body))
+	}
+}
+
+func TestWrongSecret(t *testing.T) {
+	server := httptest.NewServer(webhook.NewController(&osClient{}, map[string]webhook.Plugin{"generic": New()}))
+	defer server.Close()
+
+	resp, _ := http.Post(server.URL+"/build100/wrongsecret/generic", "application/json", nil)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected BadRequest, got %s!", resp.Status)
+	}
+}
+
+func TestPostTriggersBuild(t *testing.T) {
+	server := httptest.NewServer(webhook.NewController(&osClient{}, map[string]webhook.Plugin{"generic": New()}))
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/build100/secret101/generic", "application/json", nil)
+	if err != nil {
+		t.Fatalf("Failed posting webhook: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		t.Errorf("Expected OK, got %s: %s!", resp.Status, string(body))
+	}
+}
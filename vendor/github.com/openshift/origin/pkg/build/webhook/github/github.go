@@ -19,6 +19,20 @@ func New() *GitHubWebHook {
 	return &GitHubWebHook{}
 }
 
+// pushEvent is the subset of a GitHub push event payload used to populate a Build's
+// SourceRef and commit Revision. See https://developer.github.com/v3/activity/events/types/#pushevent.
+type pushEvent struct {
+	Ref        string `json:"ref"`
+	HeadCommit struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+		Author  struct {
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		} `json:"author"`
+	} `json:"head_commit"`
+}
+
 // Extract responsible for servicing webhooks from github.com.
 func (p *GitHubWebHook) Extract(buildCfg *api.BuildConfig, path string, req *http.Request) (build *api.Build, proceed bool, err error) {
 	if err = verifyRequest(req); err != nil {
@@ -34,11 +48,25 @@ func (p *GitHubWebHook) Extract(buildCfg *api.BuildConfig, path string, req *htt
 	if err != nil {
 		return
 	}
-	var data map[string]interface{}
-	if err = json.Unmarshal(body, &data); err != nil {
+	var event pushEvent
+	if err = json.Unmarshal(body, &event); err != nil {
+		return
+	}
+	if !proceed {
 		return
 	}
 
+	build = &api.Build{Input: buildCfg.DesiredInput}
+	if ref := strings.TrimPrefix(event.Ref, "refs/heads/"); len(ref) > 0 {
+		build.Input.SourceRef = ref
+	}
+	if commit := event.HeadCommit.ID; len(commit) > 0 {
+		build.Input.Revision = &api.SourceRevision{
+			Commit:  commit,
+			Author:  fmt.Sprintf("%s <%s>", event.HeadCommit.Author.Name, event.HeadCommit.Author.Email),
+			Message: event.HeadCommit.Message,
+		}
+	}
 	return
 }
 
@@ -131,6 +131,41 @@ func TestJsonPushEvent(t *testing.T) {
 		http.StatusOK, t)
 }
 
+func TestExtractPushEventPopulatesRevision(t *testing.T) {
+	data, err := ioutil.ReadFile("fixtures/pushevent.json")
+	if err != nil {
+		t.Fatalf("Failed to open fixture: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", "http://example.com/build100/secret101/github", bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Error creating request: %v", err)
+	}
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("User-Agent", "GitHub-Hookshot/github")
+	req.Header.Add("X-Github-Event", "push")
+
+	build, proceed, err := New().Extract(&api.BuildConfig{Secret: "secret101"}, "", req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !proceed {
+		t.Fatalf("Expected proceed to be true for a push event")
+	}
+	if build.Input.SourceRef != "master" {
+		t.Errorf("Expected SourceRef %q, got %q", "master", build.Input.SourceRef)
+	}
+	if build.Input.Revision == nil {
+		t.Fatalf("Expected a non-nil Revision")
+	}
+	if build.Input.Revision.Commit != "9bdc3a26ff933b32f3e558636b58aea86a69f051" {
+		t.Errorf("Unexpected commit: %s", build.Input.Revision.Commit)
+	}
+	if build.Input.Revision.Message != "Added license" {
+		t.Errorf("Unexpected message: %s", build.Input.Revision.Message)
+	}
+}
+
 func postFile(event, filename, url string, expStatusCode int, t *testing.T) {
 	data, err := ioutil.ReadFile("fixtures/" + filename)
 	if err != nil {
@@ -0,0 +1,55 @@
+package capabilities
+
+import (
+	"sync"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+)
+
+// Capabilities defines policy toggles that gate potentially unsafe resource fields at
+// validation time. For now these are global; eventually they may be scoped per namespace
+// or user.
+type Capabilities struct {
+	// AllowHostDir determines whether HostDir volume sources are permitted. A HostDir volume
+	// lets a Pod mount an arbitrary path from the node's filesystem, which is a container
+	// escape vector in a multi-tenant cluster, so it defaults to disallowed.
+	AllowHostDir bool
+
+	// ManifestVersionEndOfSupport, if set, is the date after which deprecated
+	// ContainerManifest versions (currently "v1beta1") are rejected outright instead of
+	// merely warned about. Leaving it nil means deprecated versions are never rejected for
+	// age, only flagged, so operators can stage migrations before enforcing a cutoff.
+	ManifestVersionEndOfSupport *util.Time
+
+	// AllowCrossNamespaceItems determines whether a template item may specify a namespace
+	// other than the one the template is being instantiated into. Templates carry no
+	// caller-identity information, so allowing items to target arbitrary namespaces would let
+	// a self-service template create or overwrite objects the caller has no access to, and it
+	// defaults to disallowed.
+	AllowCrossNamespaceItems bool
+}
+
+var once sync.Once
+var capabilities *Capabilities
+
+// Initialize the capability set. This can only be done once per binary, subsequent calls are ignored.
+func Initialize(c Capabilities) {
+	once.Do(func() {
+		capabilities = &c
+	})
+}
+
+// SetForTests. Convenience method for testing. This should only be called from tests.
+func SetForTests(c Capabilities) {
+	capabilities = &c
+}
+
+// Get returns a read-only copy of the system capabilities.
+func Get() Capabilities {
+	if capabilities == nil {
+		Initialize(Capabilities{
+			AllowHostDir: false,
+		})
+	}
+	return *capabilities
+}
@@ -8,10 +8,14 @@ import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
 
 	"github.com/openshift/origin/pkg/api/latest"
+	autoscaleapi "github.com/openshift/origin/pkg/autoscale/api"
 	buildapi "github.com/openshift/origin/pkg/build/api"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	imageapi "github.com/openshift/origin/pkg/image/api"
+	pipelineapi "github.com/openshift/origin/pkg/pipeline/api"
+	projectapi "github.com/openshift/origin/pkg/project/api"
 	routeapi "github.com/openshift/origin/pkg/route/api"
+	secretapi "github.com/openshift/origin/pkg/secret/api"
 )
 
 // Interface exposes methods on OpenShift resources.
@@ -23,7 +27,11 @@ type Interface interface {
 	ImageRepositoryMappingInterface
 	DeploymentInterface
 	DeploymentConfigInterface
+	DeploymentConfigAutoscalerInterface
+	PipelineInterface
+	ProjectDefaultsInterface
 	RouteInterface
+	SecretInterface
 	UserInterface
 	UserIdentityMappingInterface
 }
@@ -31,8 +39,13 @@ type Interface interface {
 // BuildInterface exposes methods on Build resources.
 type BuildInterface interface {
 	ListBuilds(ctx api.Context, labels labels.Selector) (*buildapi.BuildList, error)
+	GetBuild(ctx api.Context, id string) (*buildapi.Build, error)
+	WatchBuilds(ctx api.Context, label, field labels.Selector, resourceVersion uint64) (watch.Interface, error)
 	CreateBuild(ctx api.Context, build *buildapi.Build) (*buildapi.Build, error)
 	UpdateBuild(ctx api.Context, build *buildapi.Build) (*buildapi.Build, error)
+	UpdateBuildStatus(ctx api.Context, build *buildapi.Build) (*buildapi.Build, error)
+	UpdateBuildArtifacts(ctx api.Context, build *buildapi.Build) (*buildapi.Build, error)
+	FinalizeBuild(ctx api.Context, build *buildapi.Build) (*buildapi.Build, error)
 	DeleteBuild(ctx api.Context, id string) error
 }
 
@@ -75,6 +88,16 @@ type DeploymentConfigInterface interface {
 	DeleteDeploymentConfig(ctx api.Context, id string) error
 }
 
+// DeploymentConfigAutoscalerInterface contains methods for working with
+// DeploymentConfigAutoscalers
+type DeploymentConfigAutoscalerInterface interface {
+	ListDeploymentConfigAutoscalers(ctx api.Context, selector labels.Selector) (*autoscaleapi.DeploymentConfigAutoscalerList, error)
+	GetDeploymentConfigAutoscaler(ctx api.Context, id string) (*autoscaleapi.DeploymentConfigAutoscaler, error)
+	CreateDeploymentConfigAutoscaler(ctx api.Context, autoscaler *autoscaleapi.DeploymentConfigAutoscaler) (*autoscaleapi.DeploymentConfigAutoscaler, error)
+	UpdateDeploymentConfigAutoscaler(ctx api.Context, autoscaler *autoscaleapi.DeploymentConfigAutoscaler) (*autoscaleapi.DeploymentConfigAutoscaler, error)
+	DeleteDeploymentConfigAutoscaler(ctx api.Context, id string) error
+}
+
 // DeploymentInterface contains methods for working with Deployments
 type DeploymentInterface interface {
 	ListDeployments(ctx api.Context, selector labels.Selector) (*deployapi.DeploymentList, error)
@@ -84,6 +107,20 @@ type DeploymentInterface interface {
 	DeleteDeployment(ctx api.Context, id string) error
 }
 
+// PipelineInterface exposes methods on Pipeline resources
+type PipelineInterface interface {
+	ListPipelines(ctx api.Context, selector labels.Selector) (*pipelineapi.PipelineList, error)
+	GetPipeline(ctx api.Context, id string) (*pipelineapi.Pipeline, error)
+	CreatePipeline(ctx api.Context, pipeline *pipelineapi.Pipeline) (*pipelineapi.Pipeline, error)
+	UpdatePipeline(ctx api.Context, pipeline *pipelineapi.Pipeline) (*pipelineapi.Pipeline, error)
+	DeletePipeline(ctx api.Context, id string) error
+}
+
+// ProjectDefaultsInterface exposes methods on ProjectDefaults resources
+type ProjectDefaultsInterface interface {
+	GetProjectDefaults(ctx api.Context, id string) (*projectapi.ProjectDefaults, error)
+}
+
 // RouteInterface exposes methods on Route resources
 type RouteInterface interface {
 	ListRoutes(ctx api.Context, selector labels.Selector) (*routeapi.RouteList, error)
@@ -94,6 +131,15 @@ type RouteInterface interface {
 	WatchRoutes(ctx api.Context, label, field labels.Selector, resourceVersion uint64) (watch.Interface, error)
 }
 
+// SecretInterface exposes methods on Secret resources
+type SecretInterface interface {
+	ListSecrets(ctx api.Context, selector labels.Selector) (*secretapi.SecretList, error)
+	GetSecret(ctx api.Context, id string) (*secretapi.Secret, error)
+	CreateSecret(ctx api.Context, secret *secretapi.Secret) (*secretapi.Secret, error)
+	UpdateSecret(ctx api.Context, secret *secretapi.Secret) (*secretapi.Secret, error)
+	DeleteSecret(ctx api.Context, id string) error
+}
+
 // Client is an OpenShift client object
 type Client struct {
 	*kubeclient.RESTClient
@@ -142,6 +188,24 @@ func (c *Client) ListBuilds(ctx api.Context, selector labels.Selector) (result *
 	return
 }
 
+// GetBuild returns information about a particular build and error if one occurs.
+func (c *Client) GetBuild(ctx api.Context, id string) (result *buildapi.Build, err error) {
+	result = &buildapi.Build{}
+	err = c.Get().Path("builds").Path(id).Do().Into(result)
+	return
+}
+
+// WatchBuilds returns a watch.Interface that watches the requested builds.
+func (c *Client) WatchBuilds(ctx api.Context, label, field labels.Selector, resourceVersion uint64) (watch.Interface, error) {
+	return c.Get().
+		Path("watch").
+		Path("builds").
+		UintParam("resourceVersion", resourceVersion).
+		SelectorParam("labels", label).
+		SelectorParam("fields", field).
+		Watch()
+}
+
 // UpdateBuild updates the build on server. Returns the server's representation of the build and error if one occurs.
 func (c *Client) UpdateBuild(ctx api.Context, build *buildapi.Build) (result *buildapi.Build, err error) {
 	result = &buildapi.Build{}
@@ -149,6 +213,31 @@ func (c *Client) UpdateBuild(ctx api.Context, build *buildapi.Build) (result *bu
 	return
 }
 
+// UpdateBuildStatus updates only the status of the build on server. Returns the server's
+// representation of the build and error if one occurs.
+func (c *Client) UpdateBuildStatus(ctx api.Context, build *buildapi.Build) (result *buildapi.Build, err error) {
+	result = &buildapi.Build{}
+	err = c.Put().Path("buildStatus").Path(build.ID).Body(build).Do().Into(result)
+	return
+}
+
+// UpdateBuildArtifacts updates only the artifacts collected for the build on the
+// server. Returns the server's representation of the build and error if one occurs.
+func (c *Client) UpdateBuildArtifacts(ctx api.Context, build *buildapi.Build) (result *buildapi.Build, err error) {
+	result = &buildapi.Build{}
+	err = c.Put().Path("buildArtifacts").Path(build.ID).Body(build).Do().Into(result)
+	return
+}
+
+// FinalizeBuild clears a finalizer that build's caller has finished, completing the
+// build's pending deletion once none remain. Returns the server's representation of
+// the build and error if one occurs.
+func (c *Client) FinalizeBuild(ctx api.Context, build *buildapi.Build) (result *buildapi.Build, err error) {
+	result = &buildapi.Build{}
+	err = c.Put().Path("buildFinalize").Path(build.ID).Body(build).Do().Into(result)
+	return
+}
+
 // DeleteBuild deletes a build, returns error if one occurs.
 func (c *Client) DeleteBuild(ctx api.Context, id string) (err error) {
 	err = c.Delete().Path("builds").Path(id).Do().Error()
@@ -286,6 +375,40 @@ func (c *Client) DeleteDeploymentConfig(ctx api.Context, id string) error {
 	return c.Delete().Path("deploymentConfigs").Path(id).Do().Error()
 }
 
+// ListDeploymentConfigAutoscalers takes a selector, and returns the list of
+// deploymentConfigAutoscalers that match that selector
+func (c *Client) ListDeploymentConfigAutoscalers(ctx api.Context, selector labels.Selector) (result *autoscaleapi.DeploymentConfigAutoscalerList, err error) {
+	result = &autoscaleapi.DeploymentConfigAutoscalerList{}
+	err = c.Get().Path("deploymentConfigAutoscalers").SelectorParam("labels", selector).Do().Into(result)
+	return
+}
+
+// GetDeploymentConfigAutoscaler returns information about a particular deploymentConfigAutoscaler
+func (c *Client) GetDeploymentConfigAutoscaler(ctx api.Context, id string) (result *autoscaleapi.DeploymentConfigAutoscaler, err error) {
+	result = &autoscaleapi.DeploymentConfigAutoscaler{}
+	err = c.Get().Path("deploymentConfigAutoscalers").Path(id).Do().Into(result)
+	return
+}
+
+// CreateDeploymentConfigAutoscaler creates a new deploymentConfigAutoscaler
+func (c *Client) CreateDeploymentConfigAutoscaler(ctx api.Context, autoscaler *autoscaleapi.DeploymentConfigAutoscaler) (result *autoscaleapi.DeploymentConfigAutoscaler, err error) {
+	result = &autoscaleapi.DeploymentConfigAutoscaler{}
+	err = c.Post().Path("deploymentConfigAutoscalers").Body(autoscaler).Do().Into(result)
+	return
+}
+
+// UpdateDeploymentConfigAutoscaler updates an existing deploymentConfigAutoscaler
+func (c *Client) UpdateDeploymentConfigAutoscaler(ctx api.Context, autoscaler *autoscaleapi.DeploymentConfigAutoscaler) (result *autoscaleapi.DeploymentConfigAutoscaler, err error) {
+	result = &autoscaleapi.DeploymentConfigAutoscaler{}
+	err = c.Put().Path("deploymentConfigAutoscalers").Path(autoscaler.ID).Body(autoscaler).Do().Into(result)
+	return
+}
+
+// DeleteDeploymentConfigAutoscaler deletes an existing deploymentConfigAutoscaler.
+func (c *Client) DeleteDeploymentConfigAutoscaler(ctx api.Context, id string) error {
+	return c.Delete().Path("deploymentConfigAutoscalers").Path(id).Do().Error()
+}
+
 // ListDeployments takes a selector, and returns the list of deployments that match that selector
 func (c *Client) ListDeployments(ctx api.Context, selector labels.Selector) (result *deployapi.DeploymentList, err error) {
 	result = &deployapi.DeploymentList{}
@@ -319,6 +442,39 @@ func (c *Client) DeleteDeployment(ctx api.Context, id string) error {
 	return c.Delete().Path("deployments").Path(id).Do().Error()
 }
 
+// ListPipelines takes a selector, and returns the list of pipelines that match that selector
+func (c *Client) ListPipelines(ctx api.Context, selector labels.Selector) (result *pipelineapi.PipelineList, err error) {
+	result = &pipelineapi.PipelineList{}
+	err = c.Get().Path("pipelines").SelectorParam("labels", selector).Do().Into(result)
+	return
+}
+
+// GetPipeline returns information about a particular pipeline
+func (c *Client) GetPipeline(ctx api.Context, id string) (result *pipelineapi.Pipeline, err error) {
+	result = &pipelineapi.Pipeline{}
+	err = c.Get().Path("pipelines").Path(id).Do().Into(result)
+	return
+}
+
+// CreatePipeline creates a new pipeline
+func (c *Client) CreatePipeline(ctx api.Context, pipeline *pipelineapi.Pipeline) (result *pipelineapi.Pipeline, err error) {
+	result = &pipelineapi.Pipeline{}
+	err = c.Post().Path("pipelines").Body(pipeline).Do().Into(result)
+	return
+}
+
+// UpdatePipeline updates an existing pipeline
+func (c *Client) UpdatePipeline(ctx api.Context, pipeline *pipelineapi.Pipeline) (result *pipelineapi.Pipeline, err error) {
+	result = &pipelineapi.Pipeline{}
+	err = c.Put().Path("pipelines").Path(pipeline.ID).Body(pipeline).Do().Into(result)
+	return
+}
+
+// DeletePipeline deletes an existing pipeline.
+func (c *Client) DeletePipeline(ctx api.Context, id string) error {
+	return c.Delete().Path("pipelines").Path(id).Do().Error()
+}
+
 // ListRoutes takes a selector, and returns the list of routes that match that selector
 func (c *Client) ListRoutes(ctx api.Context, selector labels.Selector) (result *routeapi.RouteList, err error) {
 	result = &routeapi.RouteList{}
@@ -362,3 +518,43 @@ func (c *Client) WatchRoutes(ctx api.Context, label, field labels.Selector, reso
 		SelectorParam("fields", field).
 		Watch()
 }
+
+// GetProjectDefaults returns the ProjectDefaults for the project with the given id.
+func (c *Client) GetProjectDefaults(ctx api.Context, id string) (result *projectapi.ProjectDefaults, err error) {
+	result = &projectapi.ProjectDefaults{}
+	err = c.Get().Path("projectDefaults").Path(id).Do().Into(result)
+	return
+}
+
+// ListSecrets takes a selector, and returns the list of secrets that match that selector
+func (c *Client) ListSecrets(ctx api.Context, selector labels.Selector) (result *secretapi.SecretList, err error) {
+	result = &secretapi.SecretList{}
+	err = c.Get().Path("secrets").SelectorParam("labels", selector).Do().Into(result)
+	return
+}
+
+// GetSecret returns information about a particular secret
+func (c *Client) GetSecret(ctx api.Context, id string) (result *secretapi.Secret, err error) {
+	result = &secretapi.Secret{}
+	err = c.Get().Path("secrets").Path(id).Do().Into(result)
+	return
+}
+
+// CreateSecret creates a new secret
+func (c *Client) CreateSecret(ctx api.Context, secret *secretapi.Secret) (result *secretapi.Secret, err error) {
+	result = &secretapi.Secret{}
+	err = c.Post().Path("secrets").Body(secret).Do().Into(result)
+	return
+}
+
+// UpdateSecret updates an existing secret
+func (c *Client) UpdateSecret(ctx api.Context, secret *secretapi.Secret) (result *secretapi.Secret, err error) {
+	result = &secretapi.Secret{}
+	err = c.Put().Path("secrets").Path(secret.ID).Body(secret).Do().Into(result)
+	return
+}
+
+// DeleteSecret deletes an existing secret.
+func (c *Client) DeleteSecret(ctx api.Context, id string) error {
+	return c.Delete().Path("secrets").Path(id).Do().Error()
+}
@@ -5,10 +5,14 @@ import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
 
+	autoscaleapi "github.com/openshift/origin/pkg/autoscale/api"
 	buildapi "github.com/openshift/origin/pkg/build/api"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	imageapi "github.com/openshift/origin/pkg/image/api"
+	pipelineapi "github.com/openshift/origin/pkg/pipeline/api"
+	projectapi "github.com/openshift/origin/pkg/project/api"
 	routeapi "github.com/openshift/origin/pkg/route/api"
+	secretapi "github.com/openshift/origin/pkg/secret/api"
 	userapi "github.com/openshift/origin/pkg/user/api"
 )
 
@@ -34,11 +38,36 @@ func (c *Fake) ListBuilds(ctx api.Context, selector labels.Selector) (*buildapi.
 	return &buildapi.BuildList{}, nil
 }
 
+func (c *Fake) GetBuild(ctx api.Context, id string) (*buildapi.Build, error) {
+	c.Actions = append(c.Actions, FakeAction{Action: "get-build"})
+	return &buildapi.Build{}, nil
+}
+
+func (c *Fake) WatchBuilds(ctx api.Context, label, field labels.Selector, resourceVersion uint64) (watch.Interface, error) {
+	c.Actions = append(c.Actions, FakeAction{Action: "watch-builds"})
+	return nil, nil
+}
+
 func (c *Fake) UpdateBuild(ctx api.Context, build *buildapi.Build) (*buildapi.Build, error) {
 	c.Actions = append(c.Actions, FakeAction{Action: "update-build"})
 	return &buildapi.Build{}, nil
 }
 
+func (c *Fake) UpdateBuildStatus(ctx api.Context, build *buildapi.Build) (*buildapi.Build, error) {
+	c.Actions = append(c.Actions, FakeAction{Action: "update-build-status"})
+	return &buildapi.Build{}, nil
+}
+
+func (c *Fake) UpdateBuildArtifacts(ctx api.Context, build *buildapi.Build) (*buildapi.Build, error) {
+	c.Actions = append(c.Actions, FakeAction{Action: "update-build-artifacts"})
+	return &buildapi.Build{}, nil
+}
+
+func (c *Fake) FinalizeBuild(ctx api.Context, build *buildapi.Build) (*buildapi.Build, error) {
+	c.Actions = append(c.Actions, FakeAction{Action: "finalize-build"})
+	return &buildapi.Build{}, nil
+}
+
 func (c *Fake) DeleteBuild(ctx api.Context, id string) error {
 	c.Actions = append(c.Actions, FakeAction{Action: "delete-build", Value: id})
 	return nil
@@ -139,6 +168,31 @@ func (c *Fake) DeleteDeploymentConfig(ctx api.Context, id string) error {
 	return nil
 }
 
+func (c *Fake) ListDeploymentConfigAutoscalers(ctx api.Context, selector labels.Selector) (*autoscaleapi.DeploymentConfigAutoscalerList, error) {
+	c.Actions = append(c.Actions, FakeAction{Action: "list-deploymentconfigautoscaler"})
+	return &autoscaleapi.DeploymentConfigAutoscalerList{}, nil
+}
+
+func (c *Fake) GetDeploymentConfigAutoscaler(ctx api.Context, id string) (*autoscaleapi.DeploymentConfigAutoscaler, error) {
+	c.Actions = append(c.Actions, FakeAction{Action: "get-deploymentconfigautoscaler"})
+	return &autoscaleapi.DeploymentConfigAutoscaler{}, nil
+}
+
+func (c *Fake) CreateDeploymentConfigAutoscaler(ctx api.Context, autoscaler *autoscaleapi.DeploymentConfigAutoscaler) (*autoscaleapi.DeploymentConfigAutoscaler, error) {
+	c.Actions = append(c.Actions, FakeAction{Action: "create-deploymentconfigautoscaler"})
+	return &autoscaleapi.DeploymentConfigAutoscaler{}, nil
+}
+
+func (c *Fake) UpdateDeploymentConfigAutoscaler(ctx api.Context, autoscaler *autoscaleapi.DeploymentConfigAutoscaler) (*autoscaleapi.DeploymentConfigAutoscaler, error) {
+	c.Actions = append(c.Actions, FakeAction{Action: "update-deploymentconfigautoscaler"})
+	return &autoscaleapi.DeploymentConfigAutoscaler{}, nil
+}
+
+func (c *Fake) DeleteDeploymentConfigAutoscaler(ctx api.Context, id string) error {
+	c.Actions = append(c.Actions, FakeAction{Action: "delete-deploymentconfigautoscaler"})
+	return nil
+}
+
 func (c *Fake) ListDeployments(ctx api.Context, selector labels.Selector) (*deployapi.DeploymentList, error) {
 	c.Actions = append(c.Actions, FakeAction{Action: "list-deployment"})
 	return &deployapi.DeploymentList{}, nil
@@ -174,6 +228,31 @@ func (c *Fake) GetRoute(ctx api.Context, id string) (*routeapi.Route, error) {
 	return &routeapi.Route{}, nil
 }
 
+func (c *Fake) ListPipelines(ctx api.Context, selector labels.Selector) (*pipelineapi.PipelineList, error) {
+	c.Actions = append(c.Actions, FakeAction{Action: "list-pipelines"})
+	return &pipelineapi.PipelineList{}, nil
+}
+
+func (c *Fake) GetPipeline(ctx api.Context, id string) (*pipelineapi.Pipeline, error) {
+	c.Actions = append(c.Actions, FakeAction{Action: "get-pipeline"})
+	return &pipelineapi.Pipeline{}, nil
+}
+
+func (c *Fake) CreatePipeline(ctx api.Context, pipeline *pipelineapi.Pipeline) (*pipelineapi.Pipeline, error) {
+	c.Actions = append(c.Actions, FakeAction{Action: "create-pipeline"})
+	return &pipelineapi.Pipeline{}, nil
+}
+
+func (c *Fake) UpdatePipeline(ctx api.Context, pipeline *pipelineapi.Pipeline) (*pipelineapi.Pipeline, error) {
+	c.Actions = append(c.Actions, FakeAction{Action: "update-pipeline"})
+	return &pipelineapi.Pipeline{}, nil
+}
+
+func (c *Fake) DeletePipeline(ctx api.Context, id string) error {
+	c.Actions = append(c.Actions, FakeAction{Action: "delete-pipeline", Value: id})
+	return nil
+}
+
 func (c *Fake) CreateRoute(ctx api.Context, route *routeapi.Route) (*routeapi.Route, error) {
 	c.Actions = append(c.Actions, FakeAction{Action: "create-route"})
 	return &routeapi.Route{}, nil
@@ -194,6 +273,36 @@ func (c *Fake) WatchRoutes(ctx api.Context, field, label labels.Selector, resour
 	return nil, nil
 }
 
+func (c *Fake) GetProjectDefaults(ctx api.Context, id string) (*projectapi.ProjectDefaults, error) {
+	c.Actions = append(c.Actions, FakeAction{Action: "get-project-defaults", Value: id})
+	return &projectapi.ProjectDefaults{}, nil
+}
+
+func (c *Fake) ListSecrets(ctx api.Context, selector labels.Selector) (*secretapi.SecretList, error) {
+	c.Actions = append(c.Actions, FakeAction{Action: "list-secrets"})
+	return &secretapi.SecretList{}, nil
+}
+
+func (c *Fake) GetSecret(ctx api.Context, id string) (*secretapi.Secret, error) {
+	c.Actions = append(c.Actions, FakeAction{Action: "get-secret"})
+	return &secretapi.Secret{}, nil
+}
+
+func (c *Fake) CreateSecret(ctx api.Context, secret *secretapi.Secret) (*secretapi.Secret, error) {
+	c.Actions = append(c.Actions, FakeAction{Action: "create-secret"})
+	return &secretapi.Secret{}, nil
+}
+
+func (c *Fake) UpdateSecret(ctx api.Context, secret *secretapi.Secret) (*secretapi.Secret, error) {
+	c.Actions = append(c.Actions, FakeAction{Action: "update-secret"})
+	return &secretapi.Secret{}, nil
+}
+
+func (c *Fake) DeleteSecret(ctx api.Context, id string) error {
+	c.Actions = append(c.Actions, FakeAction{Action: "delete-secret", Value: id})
+	return nil
+}
+
 func (c *Fake) GetUser(id string) (*userapi.User, error) {
 	c.Actions = append(c.Actions, FakeAction{Action: "get-user", Value: id})
 	return &userapi.User{}, nil
@@ -287,7 +287,7 @@ func storagePathFromArg(arg string) (storage, path string, hasSuffix bool) {
 	return storage, path, hasSuffix
 }
 
-//checkStorage returns true if the provided storage is valid
+// checkStorage returns true if the provided storage is valid
 func checkStorage(storage string) bool {
 	for _, allowed := range parser.SupportedWireStorage() {
 		if allowed == storage {
@@ -491,7 +491,8 @@ func (c *KubeConfig) executeBuildLogRequest(method string, client *osclient.Clie
 // valid Config JSON.
 //
 // TODO: Print the output for each resource on success, as "create" method
-//       does in the executeAPIRequest().
+//
+//	does in the executeAPIRequest().
 func (c *KubeConfig) executeTemplateRequest(method string, client *osclient.Client) bool {
 	if method != "process" {
 		return false
@@ -0,0 +1,70 @@
+package migrate
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	etcdclient "github.com/coreos/go-etcd/etcd"
+	"github.com/spf13/cobra"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	"github.com/openshift/origin/pkg/cmd/flagtypes"
+	"github.com/openshift/origin/pkg/cmd/server/origin"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	"github.com/openshift/origin/pkg/migrate"
+)
+
+// config is a struct that the command stores flag values into.
+type config struct {
+	EtcdAddr flagtypes.Addr
+
+	StorageVersion  string
+	CompressStorage bool
+}
+
+// resources lists the etcd key prefixes this command knows how to migrate.
+var resources = []migrate.Resource{
+	{Name: "build", Prefix: "/registry/builds", NewObject: func() runtime.Object { return &buildapi.Build{} }},
+	{Name: "deployment", Prefix: "/deployments", NewObject: func() runtime.Object { return &deployapi.Deployment{} }},
+}
+
+// NewCommandMigrate returns a command that reads every stored Build and Deployment, decodes
+// it into its current internal version, and rewrites it. Run this against a live etcd before
+// shipping a change that breaks decoding of an older stored version, so nothing is left
+// behind that the new code can no longer read.
+func NewCommandMigrate(name string) *cobra.Command {
+	cfg := &config{
+		EtcdAddr: flagtypes.Addr{Value: "0.0.0.0:4001", DefaultScheme: "http", DefaultPort: 4001}.Default(),
+	}
+
+	cmd := &cobra.Command{
+		Use:   name,
+		Short: "Migrate stored builds and deployments to the latest storage version",
+		Run: func(c *cobra.Command, args []string) {
+			etcdClient := etcdclient.NewClient([]string{cfg.EtcdAddr.URL.String()})
+			helper, err := origin.NewEtcdHelper(cfg.StorageVersion, etcdClient, cfg.CompressStorage)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error setting up storage: %v\n", err)
+				os.Exit(1)
+			}
+
+			failures := migrate.Migrate(helper, resources)
+			for _, failure := range failures {
+				fmt.Fprintln(os.Stderr, failure.Error())
+			}
+			if len(failures) > 0 {
+				fmt.Fprintf(os.Stderr, "Migration finished with %d failure(s)\n", len(failures))
+				os.Exit(1)
+			}
+			fmt.Println("Migration complete")
+		},
+	}
+
+	flag := cmd.Flags()
+	flag.Var(&cfg.EtcdAddr, "etcd", "The address of the etcd server (host, host:port, or URL).")
+	flag.StringVar(&cfg.StorageVersion, "storage-version", "", "The API version to store resources with, if not the internal default.")
+	flag.BoolVar(&cfg.CompressStorage, "compress-storage", false, "Gzip-compress objects before writing them to etcd, and transparently decompress them on read.")
+
+	return cmd
+}
@@ -4,29 +4,66 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
 
 	"github.com/openshift/origin/pkg/auth/api"
 	"github.com/openshift/origin/pkg/auth/authenticator"
+	"github.com/openshift/origin/pkg/auth/authenticator/bearertoken"
+	"github.com/openshift/origin/pkg/auth/authenticator/impersonation"
+	jwtauthenticator "github.com/openshift/origin/pkg/auth/authenticator/jwt"
 	"github.com/openshift/origin/pkg/auth/oauth/handlers"
 	"github.com/openshift/origin/pkg/auth/oauth/registry"
+	"github.com/openshift/origin/pkg/auth/server/csrf"
+	"github.com/openshift/origin/pkg/auth/server/grant"
 	"github.com/openshift/origin/pkg/auth/server/login"
+	"github.com/openshift/origin/pkg/auth/server/selectprovider"
 	"github.com/openshift/origin/pkg/auth/server/session"
 	cmdutil "github.com/openshift/origin/pkg/cmd/util"
+	"github.com/openshift/origin/pkg/oauth/jwt"
+	"github.com/openshift/origin/pkg/oauth/registry/accesstoken"
+	"github.com/openshift/origin/pkg/oauth/registry/cache"
+	"github.com/openshift/origin/pkg/oauth/registry/client"
 	oauthetcd "github.com/openshift/origin/pkg/oauth/registry/etcd"
+	"github.com/openshift/origin/pkg/oauth/server/devicecode"
 	"github.com/openshift/origin/pkg/oauth/server/osinserver"
 	"github.com/openshift/origin/pkg/oauth/server/osinserver/registrystorage"
+	"github.com/openshift/origin/pkg/oauth/server/selfaccesstoken"
 )
 
 const (
-	OpenShiftOAuthAPIPrefix = "/oauth"
-	OpenShiftLoginPrefix    = "/login"
+	OpenShiftOAuthAPIPrefix       = "/oauth"
+	OpenShiftLoginPrefix          = "/login"
+	OpenShiftTokenAPIPrefix       = "/oauth/token/self"
+	OpenShiftDeviceAuthPrefix     = "/oauth/device"
+	OpenShiftDeviceVerifyPrefix   = "/oauth/device/authorize"
+	OpenShiftSelectProviderPrefix = "/select-provider"
 )
 
 type AuthConfig struct {
 	SessionSecrets []string
 	EtcdHelper     tools.EtcdHelper
+
+	// UseJWTAccessTokens switches issued access tokens from opaque values, verified by a
+	// registry round trip, to signed JWTs that a resource server can verify itself. Leave
+	// false for the default opaque format.
+	UseJWTAccessTokens bool
+
+	// IdentityProviders lists the configured identity providers a user can authenticate
+	// against. With zero or one entries, users go straight to the login page as before. With
+	// more than one, they see a selection page first, which they can skip by naming a
+	// provider directly with the selectprovider.HintParam query parameter.
+	IdentityProviders []selectprovider.Provider
+
+	// TokenCacheTTL enables a read-through cache in front of the client and access token
+	// registries for the given duration, so that authenticating a request doesn't hit etcd
+	// on every call. Leave zero to hit the registries directly on every request.
+	TokenCacheTTL time.Duration
+
+	// ImpersonationAdmins lists the usernames allowed to act as another user via the
+	// Impersonate-User header. Leave empty to disable impersonation entirely.
+	ImpersonationAdmins []string
 }
 
 // InstallAPI starts an OAuth2 server and registers the supported REST APIs
@@ -35,37 +72,86 @@ type AuthConfig struct {
 // a single string value).
 func (c *AuthConfig) InstallAPI(mux cmdutil.Mux) []string {
 	oauthEtcd := oauthetcd.New(c.EtcdHelper)
-	storage := registrystorage.New(oauthEtcd, oauthEtcd, oauthEtcd, registry.NewUserConversion())
+
+	var clientRegistry client.Registry = oauthEtcd
+	var accessTokenRegistry accesstoken.Registry = oauthEtcd
+	if c.TokenCacheTTL > 0 {
+		clientRegistry = cache.NewClientRegistry(clientRegistry, c.TokenCacheTTL)
+		accessTokenRegistry = cache.NewAccessTokenRegistry(accessTokenRegistry, c.TokenCacheTTL)
+	}
+
+	storage := registrystorage.New(accessTokenRegistry, oauthEtcd, clientRegistry, registry.NewUserConversion())
 	config := osinserver.NewDefaultServerConfig()
 	sessionStore := session.NewStore(c.SessionSecrets...)
 	sessionAuth := session.NewSessionAuthenticator(sessionStore, "ssn")
+	csrfStore := csrf.NewCSRF(sessionStore, "csrf")
+
+	loginURL := OpenShiftLoginPrefix
+	if len(c.IdentityProviders) > 1 {
+		loginURL = OpenShiftSelectProviderPrefix
+		selectprovider.New(c.IdentityProviders, selectprovider.DefaultSelectProviderFormRenderer).Install(mux, OpenShiftSelectProviderPrefix)
+	}
+	loginHandler := &redirectAuthHandler{RedirectURL: loginURL, ThenParam: "then", ForwardParams: []string{selectprovider.HintParam}}
 
 	server := osinserver.New(
 		config,
 		storage,
 		osinserver.AuthorizeHandlers{
 			handlers.NewAuthorizeAuthenticator(
-				&redirectAuthHandler{RedirectURL: OpenShiftLoginPrefix, ThenParam: "then"},
+				loginHandler,
 				sessionAuth,
 			),
 			handlers.NewGrantCheck(
 				registry.NewClientAuthorizationGrantChecker(oauthEtcd),
-				emptyGrant{},
+				grant.NewGrant(csrfStore, grant.DefaultGrantFormRenderer),
 			),
 		},
 		osinserver.AccessHandlers{
 			handlers.NewDenyAccessAuthenticator(),
 		},
 	)
+	tokenAuth := authenticator.Token(registry.NewTokenAuthenticator(accessTokenRegistry))
+	if c.UseJWTAccessTokens {
+		keys, err := jwt.NewKeyStore("primary")
+		if err != nil {
+			// Signing key generation only fails if the platform's random source is broken, in
+			// which case nothing else the server does would be trustworthy either.
+			panic(err)
+		}
+		server.UseJWTAccessTokens(keys)
+		tokenAuth = jwtauthenticator.New(keys)
+	}
 	server.Install(mux, OpenShiftOAuthAPIPrefix)
 
-	login := login.NewLogin(emptyCsrf{}, &sessionPasswordAuthenticator{emptyPasswordAuth{}, sessionAuth}, login.DefaultLoginFormRenderer)
+	login := login.NewLogin(csrfStore, &sessionPasswordAuthenticator{emptyPasswordAuth{}, sessionAuth}, login.DefaultLoginFormRenderer)
 	login.Install(mux, OpenShiftLoginPrefix)
 
-	return []string{
+	var tokenAuthenticator authenticator.Request = bearertoken.New(tokenAuth)
+	if len(c.ImpersonationAdmins) > 0 {
+		tokenAuthenticator = impersonation.New(tokenAuthenticator, impersonation.NewStaticAuthorizer(c.ImpersonationAdmins), impersonation.DefaultAuditor)
+	}
+	selfaccesstoken.New(tokenAuthenticator, oauthEtcd).Install(mux, OpenShiftTokenAPIPrefix)
+
+	deviceCodes := devicecode.NewStore()
+	devicecode.New(oauthEtcd, oauthEtcd, deviceCodes, OpenShiftDeviceVerifyPrefix).Install(mux, OpenShiftDeviceAuthPrefix)
+	devicecode.NewVerify(
+		sessionAuth,
+		loginHandler,
+		csrfStore,
+		deviceCodes,
+		devicecode.DefaultVerifyFormRenderer,
+	).Install(mux, OpenShiftDeviceVerifyPrefix)
+
+	messages := []string{
 		fmt.Sprintf("Started OAuth2 API at %%s%s", OpenShiftOAuthAPIPrefix),
 		fmt.Sprintf("Started login server at %%s%s", OpenShiftLoginPrefix),
+		fmt.Sprintf("Started self-service token API at %%s%s", OpenShiftTokenAPIPrefix),
+		fmt.Sprintf("Started device authorization API at %%s%s", OpenShiftDeviceAuthPrefix),
 	}
+	if len(c.IdentityProviders) > 1 {
+		messages = append(messages, fmt.Sprintf("Started provider selection page at %%s%s", OpenShiftSelectProviderPrefix))
+	}
+	return messages
 }
 
 type emptyAuth struct{}
@@ -77,10 +163,13 @@ func (emptyAuth) AuthenticationError(err error, w http.ResponseWriter, req *http
 	fmt.Fprintf(w, "<body>AuthenticationError - %s</body>", err)
 }
 
-// Captures the original request url as a "then" param in a redirect to a login flow
+// Captures the original request url as a "then" param in a redirect to a login flow, along
+// with any of ForwardParams already present on the original request (the provider hint a
+// selection page needs to skip itself, for example).
 type redirectAuthHandler struct {
-	RedirectURL string
-	ThenParam   string
+	RedirectURL   string
+	ThenParam     string
+	ForwardParams []string
 }
 
 func (auth *redirectAuthHandler) AuthenticationNeeded(w http.ResponseWriter, req *http.Request) {
@@ -89,11 +178,16 @@ func (auth *redirectAuthHandler) AuthenticationNeeded(w http.ResponseWriter, req
 		auth.AuthenticationError(err, w, req)
 		return
 	}
+	query := url.Values{}
+	for _, param := range auth.ForwardParams {
+		if value := req.URL.Query().Get(param); len(value) != 0 {
+			query.Set(param, value)
+		}
+	}
 	if len(auth.ThenParam) != 0 {
-		redirectURL.RawQuery = url.Values{
-			auth.ThenParam: {req.URL.String()},
-		}.Encode()
+		query.Set(auth.ThenParam, req.URL.String())
 	}
+	redirectURL.RawQuery = query.Encode()
 	http.Redirect(w, req, redirectURL.String(), http.StatusFound)
 }
 
@@ -103,29 +197,7 @@ func (auth *redirectAuthHandler) AuthenticationError(err error, w http.ResponseW
 	fmt.Fprintf(w, "<body>AuthenticationError - %s</body>", err)
 }
 
-type emptyGrant struct{}
-
-func (emptyGrant) GrantNeeded(grant *api.Grant, w http.ResponseWriter, req *http.Request) {
-	fmt.Fprintf(w, "<body>GrantNeeded - not implemented<pre>%#v</pre></body>", grant)
-}
-
-func (emptyGrant) GrantError(err error, w http.ResponseWriter, req *http.Request) {
-	fmt.Fprintf(w, "<body>GrantError - %s</body>", err)
-}
-
-type emptyCsrf struct{}
-
-func (emptyCsrf) Generate() (string, error) {
-	return "", nil
-}
-
-func (emptyCsrf) Check(string) (bool, error) {
-	return true, nil
-}
-
-//
 // Approves any login attempt with non-blank username and password
-//
 type emptyPasswordAuth struct{}
 
 func (emptyPasswordAuth) AuthenticatePassword(user, password string) (api.UserInfo, bool, error) {
@@ -137,9 +209,7 @@ func (emptyPasswordAuth) AuthenticatePassword(user, password string) (api.UserIn
 	}, true, nil
 }
 
-//
 // Saves the username of any successful password authentication in the session
-//
 type sessionPasswordAuthenticator struct {
 	passwordAuthenticator authenticator.Password
 	sessionAuthenticator  *session.SessionAuthenticator
@@ -0,0 +1,74 @@
+package origin
+
+import (
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/golang/glog"
+
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/generator"
+	oauthetcd "github.com/openshift/origin/pkg/oauth/registry/etcd"
+	projectapi "github.com/openshift/origin/pkg/project/api"
+	projectetcd "github.com/openshift/origin/pkg/project/registry/etcd"
+)
+
+// BootstrapWebConsoleClientName and BootstrapCLIClientName are the well-known OAuth client
+// names EnsureBootstrapPolicy seeds, so the web console and the CLI never need a manually
+// created client to authenticate against a fresh master.
+const (
+	BootstrapWebConsoleClientName = "openshift-web-console"
+	BootstrapCLIClientName        = "openshift-cli-client"
+
+	// BootstrapDefaultProjectName is the project EnsureBootstrapPolicy creates so a fresh
+	// master has somewhere to put resources before any project has been explicitly requested.
+	BootstrapDefaultProjectName = "default"
+)
+
+// EnsureBootstrapPolicy idempotently seeds a fresh master with the objects it needs to be
+// usable without a manual setup script: OAuth clients for the web console and CLI, and the
+// default project. assetPublicAddr is used as the web console client's redirect URI. Objects
+// that already exist are left untouched; this is meant to run on every master startup.
+func (c *MasterConfig) EnsureBootstrapPolicy(assetPublicAddr string) {
+	oauthEtcd := oauthetcd.New(c.EtcdHelper)
+	c.ensureOAuthClient(oauthEtcd, &oauthapi.Client{
+		Name:         BootstrapWebConsoleClientName,
+		RedirectURIs: []string{assetPublicAddr},
+		Trusted:      true,
+	})
+
+	secret, err := generator.New(generator.DefaultPrefix, generator.DefaultLength).Generate()
+	if err != nil {
+		glog.Errorf("Unable to generate a secret for the %q OAuth client: %v", BootstrapCLIClientName, err)
+	} else {
+		c.ensureOAuthClient(oauthEtcd, &oauthapi.Client{
+			Name:         BootstrapCLIClientName,
+			Secret:       secret,
+			RedirectURIs: []string{"urn:ietf:wg:oauth:2.0:oob"},
+			Trusted:      true,
+		})
+	}
+
+	c.ensureDefaultProject(projectetcd.New(c.EtcdHelper))
+}
+
+// ensureOAuthClient creates client, ignoring an already-exists error so repeated calls (every
+// master startup) are safe.
+func (c *MasterConfig) ensureOAuthClient(registry *oauthetcd.Etcd, client *oauthapi.Client) {
+	if err := registry.CreateClient(client); err != nil && !errors.IsAlreadyExists(err) {
+		glog.Errorf("Unable to create bootstrap OAuth client %q: %v", client.Name, err)
+	}
+}
+
+// ensureDefaultProject creates the default project, ignoring an already-exists error so
+// repeated calls (every master startup) are safe.
+func (c *MasterConfig) ensureDefaultProject(registry *projectetcd.Etcd) {
+	project := &projectapi.Project{
+		JSONBase:    kapi.JSONBase{ID: BootstrapDefaultProjectName},
+		DisplayName: "Default Project",
+		Description: "Default project created at cluster startup",
+	}
+	ctx := kapi.NewDefaultContext()
+	if err := registry.CreateProject(ctx, project); err != nil && !errors.IsAlreadyExists(err) {
+		glog.Errorf("Unable to create bootstrap default project: %v", err)
+	}
+}
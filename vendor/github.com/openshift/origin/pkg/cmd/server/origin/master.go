@@ -1,7 +1,9 @@
 package origin
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"regexp"
@@ -12,8 +14,10 @@ import (
 	klatest "github.com/GoogleCloudPlatform/kubernetes/pkg/api/latest"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/apiserver"
 	kubeclient "github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
 	etcdclient "github.com/coreos/go-etcd/etcd"
 	"github.com/elazarl/go-bindata-assetfs"
 	"github.com/golang/glog"
@@ -21,14 +25,21 @@ import (
 	"github.com/openshift/origin/pkg/api/latest"
 	"github.com/openshift/origin/pkg/api/v1beta1"
 	"github.com/openshift/origin/pkg/assets"
+	"github.com/openshift/origin/pkg/autoscale"
+	autoscaleregistry "github.com/openshift/origin/pkg/autoscale/registry/autoscaler"
+	autoscaleetcd "github.com/openshift/origin/pkg/autoscale/registry/etcd"
 	"github.com/openshift/origin/pkg/build"
 	buildapi "github.com/openshift/origin/pkg/build/api"
 	buildregistry "github.com/openshift/origin/pkg/build/registry/build"
+	buildartifactsregistry "github.com/openshift/origin/pkg/build/registry/buildartifacts"
 	buildconfigregistry "github.com/openshift/origin/pkg/build/registry/buildconfig"
+	buildfinalizeregistry "github.com/openshift/origin/pkg/build/registry/buildfinalize"
 	buildlogregistry "github.com/openshift/origin/pkg/build/registry/buildlog"
+	buildstatusregistry "github.com/openshift/origin/pkg/build/registry/buildstatus"
 	buildetcd "github.com/openshift/origin/pkg/build/registry/etcd"
 	"github.com/openshift/origin/pkg/build/strategy"
 	"github.com/openshift/origin/pkg/build/webhook"
+	"github.com/openshift/origin/pkg/build/webhook/generic"
 	"github.com/openshift/origin/pkg/build/webhook/github"
 	osclient "github.com/openshift/origin/pkg/client"
 	cmdutil "github.com/openshift/origin/pkg/cmd/util"
@@ -36,6 +47,7 @@ import (
 	deployregistry "github.com/openshift/origin/pkg/deploy/registry/deploy"
 	deployconfigregistry "github.com/openshift/origin/pkg/deploy/registry/deployconfig"
 	deployetcd "github.com/openshift/origin/pkg/deploy/registry/etcd"
+	"github.com/openshift/origin/pkg/gc"
 	imageetcd "github.com/openshift/origin/pkg/image/registry/etcd"
 	"github.com/openshift/origin/pkg/image/registry/image"
 	"github.com/openshift/origin/pkg/image/registry/imagerepository"
@@ -45,22 +57,41 @@ import (
 	clientregistry "github.com/openshift/origin/pkg/oauth/registry/client"
 	clientauthorizationregistry "github.com/openshift/origin/pkg/oauth/registry/clientauthorization"
 	oauthetcd "github.com/openshift/origin/pkg/oauth/registry/etcd"
+	"github.com/openshift/origin/pkg/oauth/tokenprune"
+	"github.com/openshift/origin/pkg/pipeline"
+	pipelineetcd "github.com/openshift/origin/pkg/pipeline/registry/etcd"
+	pipelineregistry "github.com/openshift/origin/pkg/pipeline/registry/pipeline"
+	"github.com/openshift/origin/pkg/project/lifecycle"
 	projectetcd "github.com/openshift/origin/pkg/project/registry/etcd"
+	projectoverviewregistry "github.com/openshift/origin/pkg/project/registry/overview"
 	projectregistry "github.com/openshift/origin/pkg/project/registry/project"
+	projectdefaultsregistry "github.com/openshift/origin/pkg/project/registry/projectdefaults"
+	projectresourceusageregistry "github.com/openshift/origin/pkg/project/registry/resourceusage"
 	routeetcd "github.com/openshift/origin/pkg/route/registry/etcd"
 	routeregistry "github.com/openshift/origin/pkg/route/registry/route"
+	secretetcd "github.com/openshift/origin/pkg/secret/registry/etcd"
+	secretregistry "github.com/openshift/origin/pkg/secret/registry/secret"
 	"github.com/openshift/origin/pkg/template"
+	templateinstanceetcd "github.com/openshift/origin/pkg/template/registry/etcd"
+	templateinstanceregistry "github.com/openshift/origin/pkg/template/registry/templateinstance"
 	"github.com/openshift/origin/pkg/user"
 	useretcd "github.com/openshift/origin/pkg/user/registry/etcd"
 	userregistry "github.com/openshift/origin/pkg/user/registry/user"
 	"github.com/openshift/origin/pkg/user/registry/useridentitymapping"
+	"github.com/openshift/origin/pkg/util/clog"
+	"github.com/openshift/origin/pkg/util/gzipcodec"
 	"github.com/openshift/origin/pkg/version"
+	"github.com/openshift/origin/pkg/watch/multiplex"
+	"github.com/openshift/origin/pkg/webhook/notify"
 
 	// Register versioned api types
+	_ "github.com/openshift/origin/pkg/autoscale/api/v1beta1"
 	_ "github.com/openshift/origin/pkg/config/api/v1beta1"
 	_ "github.com/openshift/origin/pkg/image/api/v1beta1"
+	_ "github.com/openshift/origin/pkg/pipeline/api/v1beta1"
 	_ "github.com/openshift/origin/pkg/project/api/v1beta1"
 	_ "github.com/openshift/origin/pkg/route/api/v1beta1"
+	_ "github.com/openshift/origin/pkg/secret/api/v1beta1"
 	_ "github.com/openshift/origin/pkg/template/api/v1beta1"
 )
 
@@ -80,6 +111,31 @@ type MasterConfig struct {
 
 	KubeClient *kubeclient.Client
 	OSClient   *osclient.Client
+
+	// UserMappingMethod controls how identities from all configured identity providers
+	// are mapped to Users. Defaults to user.MappingMethodClaim if empty.
+	UserMappingMethod user.MappingMethod
+
+	// MaxProjectsPerRequester bounds how many projects a single requester may own, as
+	// recorded by the ProjectRequesterAnnotation on projects created through the API.
+	// Zero means unlimited.
+	MaxProjectsPerRequester int
+
+	// Notifier delivers build, deployment, and project events to externally registered
+	// webhook URLs. Nil disables outbound notifications entirely. Set by
+	// EnsureWebhookNotifier.
+	Notifier *notify.Notifier
+
+	// ProjectLifecycleHook, when set, is called synchronously before a project is created or
+	// deleted, so an external provisioning system can run in lockstep with the project and,
+	// depending on its FailurePolicy, veto the operation. Nil disables the hook entirely.
+	ProjectLifecycleHook *lifecycle.Hook
+
+	// BuildPodTemplateOverride, when set, is merged into every pod each build strategy
+	// generates, letting an administrator apply cluster policy (a cache proxy sidecar, a
+	// mandatory volume) uniformly without patching strategy code. The zero value applies
+	// no override.
+	BuildPodTemplateOverride strategy.PodTemplateOverride
 }
 
 // APIInstaller installs additional API components into this server
@@ -97,6 +153,29 @@ func (c *MasterConfig) EnsureKubernetesClient() {
 	c.KubeClient = kubeClient
 }
 
+// EnsureWebhookNotifier builds a Notifier that delivers events to every URL in urls,
+// signed with secret, and stores it on c.Notifier. An empty urls leaves c.Notifier nil.
+func (c *MasterConfig) EnsureWebhookNotifier(urls []string, secret string) {
+	if len(urls) == 0 {
+		return
+	}
+	subscriptions := make([]notify.Subscription, len(urls))
+	for i, url := range urls {
+		subscriptions[i] = notify.Subscription{URL: url, Secret: secret}
+	}
+	c.Notifier = notify.New(subscriptions)
+}
+
+// EnsureProjectLifecycleHook builds a lifecycle.Hook that calls url, signed with secret, for
+// every project create and delete, and stores it on c.ProjectLifecycleHook. An empty url
+// leaves c.ProjectLifecycleHook nil.
+func (c *MasterConfig) EnsureProjectLifecycleHook(url, secret string, maxAttempts int, policy lifecycle.FailurePolicy) {
+	if len(url) == 0 {
+		return
+	}
+	c.ProjectLifecycleHook = lifecycle.New(url, secret, maxAttempts, policy)
+}
+
 // EnsureOpenShiftClient creates an OpenShift client or exits if the client cannot be created.
 func (c *MasterConfig) EnsureOpenShiftClient() {
 	osClient, err := osclient.New(&kubeclient.Config{Host: c.MasterAddr, Version: latest.Version})
@@ -118,42 +197,92 @@ func (c *MasterConfig) EnsureCORSAllowedOrigins(origins []string) {
 	}
 }
 
+// EnsureBuildPodTemplateOverride reads a JSON-encoded strategy.PodTemplateOverride from path
+// and stores it on c.BuildPodTemplateOverride, or exits if the file cannot be read or parsed.
+// An empty path leaves c.BuildPodTemplateOverride at its zero value.
+func (c *MasterConfig) EnsureBuildPodTemplateOverride(path string) {
+	if len(path) == 0 {
+		return
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		glog.Fatalf("Unable to read build pod template override file %s: %v", path, err)
+	}
+	override := strategy.PodTemplateOverride{}
+	if err := json.Unmarshal(data, &override); err != nil {
+		glog.Fatalf("Unable to parse build pod template override file %s: %v", path, err)
+	}
+	c.BuildPodTemplateOverride = override
+}
+
 // RunAPI launches the OpenShift master. It takes an optional API installer that
 // may install additional endpoints into the server.
 func (c *MasterConfig) RunAPI(installers ...APIInstaller) {
 	buildEtcd := buildetcd.New(c.EtcdHelper)
 	imageEtcd := imageetcd.New(c.EtcdHelper)
 	deployEtcd := deployetcd.New(c.EtcdHelper)
+	autoscalerEtcd := autoscaleetcd.New(c.EtcdHelper)
+	pipelineEtcd := pipelineetcd.New(c.EtcdHelper)
 	routeEtcd := routeetcd.New(c.EtcdHelper)
+	secretEtcd := secretetcd.New(c.EtcdHelper)
 	projectEtcd := projectetcd.New(c.EtcdHelper)
-	userEtcd := useretcd.New(c.EtcdHelper, user.NewDefaultUserInitStrategy())
+	templateInstanceEtcd := templateinstanceetcd.New(c.EtcdHelper)
+
+	userMappingMethod := c.UserMappingMethod
+	if len(userMappingMethod) == 0 {
+		userMappingMethod = user.MappingMethodClaim
+	}
+	// the mapper needs a UserRegistry to resolve preferred user names, which the Etcd
+	// store itself provides, so it is built in two steps
+	userLookup := useretcd.New(c.EtcdHelper, nil)
+	userMapper, err := user.NewMapper(userMappingMethod, userLookup, user.NewDefaultUserInitStrategy())
+	if err != nil {
+		glog.Fatalf("Invalid user mapping method %q: %v", userMappingMethod, err)
+	}
+	userEtcd := useretcd.New(c.EtcdHelper, userMapper)
 	oauthEtcd := oauthetcd.New(c.EtcdHelper)
 
 	// initialize OpenShift API
 	storage := map[string]apiserver.RESTStorage{
-		"builds":       buildregistry.NewREST(buildEtcd),
-		"buildConfigs": buildconfigregistry.NewREST(buildEtcd),
-		"buildLogs":    buildlogregistry.NewREST(buildEtcd, c.KubeClient, "/proxy/minion"),
+		"builds":         buildregistry.NewREST(buildEtcd),
+		"buildConfigs":   buildconfigregistry.NewREST(buildEtcd),
+		"buildLogs":      buildlogregistry.NewREST(buildEtcd, c.KubeClient, "/proxy/minion"),
+		"buildStatus":    buildstatusregistry.NewREST(buildEtcd),
+		"buildArtifacts": buildartifactsregistry.NewREST(buildEtcd),
+		"buildFinalize":  buildfinalizeregistry.NewREST(buildEtcd),
 
 		"images":                  image.NewREST(imageEtcd),
 		"imageRepositories":       imagerepository.NewREST(imageEtcd),
 		"imageRepositoryMappings": imagerepositorymapping.NewREST(imageEtcd, imageEtcd),
 
-		"deployments":       deployregistry.NewREST(deployEtcd),
-		"deploymentConfigs": deployconfigregistry.NewREST(deployEtcd),
+		"deployments":               deployregistry.NewREST(deployEtcd),
+		"deploymentConfigs":         deployconfigregistry.NewREST(deployEtcd),
+		"deploymentConfigHistories": deployconfigregistry.NewHistoryREST(deployEtcd),
+		"deploymentConfigDiffs":     deployconfigregistry.NewDiffREST(deployEtcd),
+
+		"deploymentConfigAutoscalers": autoscaleregistry.NewREST(autoscalerEtcd),
+
+		"pipelines": pipelineregistry.NewREST(pipelineEtcd),
 
-		"templateConfigs": template.NewStorage(),
+		"templateConfigs":   template.NewStorageWithInstanceRegistry(templateInstanceEtcd),
+		"templateInstances": templateinstanceregistry.NewREST(templateInstanceEtcd),
+		"templateDiffs":     template.NewDiffStorage(templateInstanceEtcd),
 
 		"routes": routeregistry.NewREST(routeEtcd),
 
-		"projects": projectregistry.NewREST(projectEtcd),
+		"secrets": secretregistry.NewREST(secretEtcd),
+
+		"projects":             projectregistry.NewRESTWithRequesterQuotaNotifierAndLifecycleHook(projectEtcd, c.MaxProjectsPerRequester, c.Notifier, c.ProjectLifecycleHook),
+		"projectDefaults":      projectdefaultsregistry.NewREST(projectEtcd),
+		"projectResourceUsage": projectresourceusageregistry.NewREST(c.KubeClient, c.OSClient, c.OSClient, 30*time.Second),
+		"projectOverview":      projectoverviewregistry.NewREST(c.KubeClient, c.OSClient, c.OSClient, c.OSClient, c.OSClient),
 
 		"userIdentityMappings": useridentitymapping.NewREST(userEtcd),
 		"users":                userregistry.NewREST(userEtcd),
 
 		"authorizeTokens":      authorizetokenregistry.NewREST(oauthEtcd),
 		"accessTokens":         accesstokenregistry.NewREST(oauthEtcd),
-		"clients":              clientregistry.NewREST(oauthEtcd),
+		"clients":              clientregistry.NewREST(oauthEtcd, oauthEtcd),
 		"clientAuthorizations": clientauthorizationregistry.NewREST(oauthEtcd),
 	}
 
@@ -162,14 +291,33 @@ func (c *MasterConfig) RunAPI(installers ...APIInstaller) {
 	whPrefix := OpenShiftAPIPrefixV1Beta1 + "/buildConfigHooks/"
 	osMux.Handle(whPrefix, http.StripPrefix(whPrefix,
 		webhook.NewController(c.OSClient, map[string]webhook.Plugin{
-			"github": github.New(),
+			"github":  github.New(),
+			"generic": generic.New(),
 		})))
 
+	watchMuxPrefix := OpenShiftAPIPrefixV1Beta1 + "/watch/multiplex/"
+	osMux.Handle(watchMuxPrefix, http.StripPrefix(watchMuxPrefix,
+		multiplex.NewHandler(map[string]multiplex.Source{
+			"builds": func(resourceVersion uint64) (watch.Interface, error) {
+				return c.OSClient.WatchBuilds(api.NewContext(), labels.Everything(), labels.Everything(), resourceVersion)
+			},
+			"imageRepositories": func(resourceVersion uint64) (watch.Interface, error) {
+				return c.OSClient.WatchImageRepositories(api.NewContext(), labels.Everything(), labels.Everything(), resourceVersion)
+			},
+			"routes": func(resourceVersion uint64) (watch.Interface, error) {
+				return c.OSClient.WatchRoutes(api.NewContext(), labels.Everything(), labels.Everything(), resourceVersion)
+			},
+		}, v1beta1.Codec)))
+
+	osMux.Handle(OpenShiftAPIPrefixV1Beta1+"/logLevels", clog.NewHandler())
+
 	var extra []string
 	for _, i := range installers {
 		extra = append(extra, i.InstallAPI(osMux)...)
 	}
-	apiserver.NewAPIGroup(storage, v1beta1.Codec, OpenShiftAPIPrefixV1Beta1, latest.SelfLinker).InstallREST(osMux, OpenShiftAPIPrefixV1Beta1)
+	osAPIGroup := apiserver.NewAPIGroup(storage, v1beta1.Codec, OpenShiftAPIPrefixV1Beta1, latest.SelfLinker)
+	osAPIGroup.SetStrict(true)
+	osAPIGroup.InstallREST(osMux, OpenShiftAPIPrefixV1Beta1)
 	apiserver.InstallSupport(osMux)
 
 	handler := http.Handler(osMux)
@@ -234,13 +382,18 @@ func (c *MasterConfig) RunBuildController() {
 	// initialize build controller
 	dockerBuilderImage := env("OPENSHIFT_DOCKER_BUILDER_IMAGE", "openshift/docker-builder")
 	stiBuilderImage := env("OPENSHIFT_STI_BUILDER_IMAGE", "openshift/sti-builder")
+	jenkinsTriggerImage := env("OPENSHIFT_JENKINS_TRIGGER_IMAGE", "openshift/jenkins-trigger")
 
 	buildStrategies := map[buildapi.BuildType]build.BuildJobStrategy{
-		buildapi.DockerBuildType: strategy.NewDockerBuildStrategy(dockerBuilderImage),
-		buildapi.STIBuildType:    strategy.NewSTIBuildStrategy(stiBuilderImage, strategy.STITempDirectoryCreator),
+		buildapi.DockerBuildType:  strategy.NewDockerBuildStrategy(dockerBuilderImage, c.BuildPodTemplateOverride),
+		buildapi.STIBuildType:     strategy.NewSTIBuildStrategy(stiBuilderImage, strategy.STITempDirectoryCreator, c.BuildPodTemplateOverride),
+		buildapi.JenkinsBuildType: strategy.NewJenkinsBuildStrategy(jenkinsTriggerImage, c.MasterAddr, c.BuildPodTemplateOverride),
 	}
 
-	buildController := build.NewBuildController(c.KubeClient, c.OSClient, buildStrategies, 1200)
+	// maxInFlightBuilds caps how many builds run at once, so a burst of triggered builds
+	// doesn't create pods for all of them simultaneously and starve the cluster.
+	maxInFlightBuilds := 10
+	buildController := build.NewBuildController(c.KubeClient, c.OSClient, buildStrategies, 1200, c.Notifier, maxInFlightBuilds)
 	buildController.Run(10 * time.Second)
 }
 
@@ -250,13 +403,46 @@ func (c *MasterConfig) RunDeploymentController() {
 		api.EnvVar{Name: "KUBERNETES_MASTER", Value: c.MasterAddr},
 	}
 
-	deployController := deploy.NewDeploymentController(c.KubeClient, c.OSClient, env)
+	deployController := deploy.NewDeploymentController(c.KubeClient, c.OSClient, env, c.Notifier)
 	deployController.Run(10 * time.Second)
 }
 
+// RunAutoscalerController starts the sync loop that reconciles DeploymentConfigAutoscalers
+// against the DeploymentConfigs they target.
+func (c *MasterConfig) RunAutoscalerController() {
+	autoscalerController := autoscale.NewController(c.OSClient)
+	autoscalerController.Run(30 * time.Second)
+}
+
+// RunPipelineController starts the sync loop that advances Pipeline stages.
+func (c *MasterConfig) RunPipelineController() {
+	pipelineController := pipeline.NewPipelineController(c.KubeClient, c.OSClient)
+	pipelineController.Run(10 * time.Second)
+}
+
+// RunGarbageCollector starts the sweep that reaps build and deployer pods whose owning
+// Build or Deployment has been deleted, and build pods left over from builds that finished
+// more than 24 hours ago. Failed and Error build pods are kept regardless of age, so their
+// logs stay available for debugging.
+func (c *MasterConfig) RunGarbageCollector() {
+	gcController := gc.NewController(c.KubeClient, c.OSClient, 24*time.Hour, true)
+	gcController.Run(10 * time.Second)
+}
+
+// RunTokenPruner starts a periodic sweep that deletes expired OAuth authorize and access
+// tokens, as a fallback for entries etcd's native TTL on the token key hasn't reaped yet.
+func (c *MasterConfig) RunTokenPruner() {
+	oauthEtcd := oauthetcd.New(c.EtcdHelper)
+	tokenPruneController := tokenprune.NewController(oauthEtcd, 100)
+	tokenPruneController.Run(1 * time.Hour)
+}
+
 // NewEtcdHelper returns an EtcdHelper for the provided arguments or an error if the version
-// is incorrect.
-func NewEtcdHelper(version string, client *etcdclient.Client) (helper tools.EtcdHelper, err error) {
+// is incorrect. When compress is true, objects are gzip-compressed before being written to
+// etcd and transparently decompressed on the way back out; this shrinks large objects like
+// templates and builds at the cost of some CPU, and is safe to turn on or off across restarts
+// since decoding falls back to reading uncompressed data untouched.
+func NewEtcdHelper(version string, client *etcdclient.Client, compress bool) (helper tools.EtcdHelper, err error) {
 	if len(version) == 0 {
 		version = latest.Version
 	}
@@ -264,7 +450,11 @@ func NewEtcdHelper(version string, client *etcdclient.Client) (helper tools.Etcd
 	if err != nil {
 		return helper, err
 	}
-	return tools.EtcdHelper{client, interfaces.Codec, interfaces.ResourceVersioner}, nil
+	codec := interfaces.Codec
+	if compress {
+		codec = gzipcodec.NewCodec(codec)
+	}
+	return tools.EtcdHelper{client, codec, interfaces.ResourceVersioner}, nil
 }
 
 // env returns an environment variable, or the defaultValue if it is not set.
@@ -0,0 +1,154 @@
+// Package simulatebuild implements a command that shows the pod a Build would produce
+// without creating anything, so an operator can debug why a build pod looks the way it
+// does without a live cluster.
+package simulatebuild
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/origin/pkg/api/latest"
+	"github.com/openshift/origin/pkg/build"
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	"github.com/openshift/origin/pkg/build/strategy"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+	projectapi "github.com/openshift/origin/pkg/project/api"
+)
+
+// config is a struct that the command stores flag values into.
+type config struct {
+	BuildFile           string
+	ProjectDefaultsFile string
+
+	DockerBuilderImage  string
+	STIBuilderImage     string
+	JenkinsTriggerImage string
+	MasterAddr          string
+}
+
+// NewCommandSimulateBuild returns a command that decodes a Build from a file, runs it
+// through the same BuildJobStrategy and project defaults the build controller would apply,
+// and prints the resulting pod, without creating anything or talking to a cluster. Push and
+// pull secrets are not resolved, since doing so requires a live apiserver; the printed pod
+// reflects everything the strategy and project defaults contribute on their own.
+func NewCommandSimulateBuild(name string) *cobra.Command {
+	cfg := &config{
+		DockerBuilderImage:  "openshift/docker-builder",
+		STIBuilderImage:     "openshift/sti-builder",
+		JenkinsTriggerImage: "openshift/jenkins-trigger",
+	}
+
+	cmd := &cobra.Command{
+		Use:   name,
+		Short: "Print the pod a Build would produce, without creating it",
+		Run: func(c *cobra.Command, args []string) {
+			if len(cfg.BuildFile) == 0 {
+				fmt.Fprintln(os.Stderr, "Need a build file (--build)")
+				os.Exit(1)
+			}
+
+			buildObj, err := readBuild(cfg.BuildFile)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading build: %v\n", err)
+				os.Exit(1)
+			}
+
+			var defaults *projectapi.ProjectDefaults
+			if len(cfg.ProjectDefaultsFile) > 0 {
+				defaults, err = readProjectDefaults(cfg.ProjectDefaultsFile)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error reading project defaults: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			if defaults != nil && !imageapi.RegistryAllowed(buildObj.Input.ImageTag, defaults.AllowedRegistries) {
+				fmt.Fprintf(os.Stderr, "Image tag %s is not from a registry allowed by the given project defaults\n", buildObj.Input.ImageTag)
+				os.Exit(1)
+			}
+
+			buildStrategies := map[buildapi.BuildType]build.BuildJobStrategy{
+				buildapi.DockerBuildType:  strategy.NewDockerBuildStrategy(cfg.DockerBuilderImage, strategy.PodTemplateOverride{}),
+				buildapi.STIBuildType:     strategy.NewSTIBuildStrategy(cfg.STIBuilderImage, strategy.STITempDirectoryCreator, strategy.PodTemplateOverride{}),
+				buildapi.JenkinsBuildType: strategy.NewJenkinsBuildStrategy(cfg.JenkinsTriggerImage, cfg.MasterAddr, strategy.PodTemplateOverride{}),
+			}
+			buildStrategy, ok := buildStrategies[buildObj.Input.Type]
+			if !ok {
+				fmt.Fprintf(os.Stderr, "No build strategy for type %s\n", buildObj.Input.Type)
+				os.Exit(1)
+			}
+
+			var sourceCacheURL string
+			if defaults != nil {
+				sourceCacheURL = defaults.SourceCacheURL
+			}
+
+			pod, err := buildStrategy.CreateBuildPod(buildObj, nil, nil, sourceCacheURL)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error simulating build pod: %v\n", err)
+				os.Exit(1)
+			}
+
+			if defaults != nil {
+				if err := defaults.Resources.ApplyLimits(pod); err != nil {
+					fmt.Fprintf(os.Stderr, "Error applying project defaults: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			data, err := latest.Codec.Encode(pod)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding pod: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println(string(data))
+		},
+	}
+
+	flag := cmd.Flags()
+	flag.StringVar(&cfg.BuildFile, "build", "", "Path to a file containing the Build to simulate.")
+	flag.StringVar(&cfg.ProjectDefaultsFile, "project-defaults", "", "Path to a file containing the ProjectDefaults to apply, if any.")
+	flag.StringVar(&cfg.DockerBuilderImage, "docker-builder-image", cfg.DockerBuilderImage, "The image used for Docker builds.")
+	flag.StringVar(&cfg.STIBuilderImage, "sti-builder-image", cfg.STIBuilderImage, "The image used for STI builds.")
+	flag.StringVar(&cfg.JenkinsTriggerImage, "jenkins-trigger-image", cfg.JenkinsTriggerImage, "The image used to trigger Jenkins builds.")
+	flag.StringVar(&cfg.MasterAddr, "master", "", "The address of the OpenShift master, as passed to Jenkins builds.")
+
+	return cmd
+}
+
+// readBuild decodes a Build from the file at path.
+func readBuild(path string) (*buildapi.Build, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := latest.Codec.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	buildObj, ok := obj.(*buildapi.Build)
+	if !ok {
+		return nil, fmt.Errorf("%s does not contain a Build", path)
+	}
+	return buildObj, nil
+}
+
+// readProjectDefaults decodes a ProjectDefaults from the file at path.
+func readProjectDefaults(path string) (*projectapi.ProjectDefaults, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := latest.Codec.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	defaults, ok := obj.(*projectapi.ProjectDefaults)
+	if !ok {
+		return nil, fmt.Errorf("%s does not contain a ProjectDefaults", path)
+	}
+	return defaults, nil
+}
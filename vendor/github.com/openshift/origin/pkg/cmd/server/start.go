@@ -24,6 +24,7 @@ import (
 	"github.com/openshift/origin/pkg/cmd/server/origin"
 	"github.com/openshift/origin/pkg/cmd/util"
 	"github.com/openshift/origin/pkg/cmd/util/docker"
+	"github.com/openshift/origin/pkg/project/lifecycle"
 )
 
 const longCommandDesc = `
@@ -72,11 +73,27 @@ type config struct {
 
 	EtcdDir string
 
-	StorageVersion string
+	StorageVersion  string
+	CompressStorage bool
 
 	NodeList flagtypes.StringList
 
 	CORSAllowedOrigins flagtypes.StringList
+
+	WebhookURLs   flagtypes.StringList
+	WebhookSecret string
+
+	ProjectLifecycleHookURL           string
+	ProjectLifecycleHookSecret        string
+	ProjectLifecycleHookFailurePolicy string
+
+	ImpersonationAdmins flagtypes.StringList
+
+	BuildPodTemplateOverrideFile string
+
+	MaxProjectsPerRequester int
+
+	UseJWTAccessTokens bool
 }
 
 func NewCommandStartServer(name string) *cobra.Command {
@@ -89,6 +106,8 @@ func NewCommandStartServer(name string) *cobra.Command {
 		KubernetesAddr: flagtypes.Addr{DefaultScheme: "http", DefaultPort: 8080}.Default(),
 
 		NodeList: flagtypes.StringList{"127.0.0.1"},
+
+		ProjectLifecycleHookFailurePolicy: string(lifecycle.FailurePolicyIgnore),
 	}
 
 	cmd := &cobra.Command{
@@ -153,7 +172,7 @@ func NewCommandStartServer(name string) *cobra.Command {
 
 				// Connect and setup etcd interfaces
 				etcdClient := getEtcdClient(cfg)
-				etcdHelper, err := origin.NewEtcdHelper(cfg.StorageVersion, etcdClient)
+				etcdHelper, err := origin.NewEtcdHelper(cfg.StorageVersion, etcdClient, cfg.CompressStorage)
 				if err != nil {
 					glog.Errorf("Error setting up server storage: %v", err)
 				}
@@ -165,10 +184,11 @@ func NewCommandStartServer(name string) *cobra.Command {
 				assetAddr := net.JoinHostPort(cfg.MasterAddr.Host, strconv.Itoa(cfg.BindAddr.Port+1))
 
 				osmaster := &origin.MasterConfig{
-					BindAddr:   cfg.BindAddr.URL.Host,
-					MasterAddr: cfg.MasterAddr.URL.String(),
-					AssetAddr:  assetAddr,
-					EtcdHelper: etcdHelper,
+					BindAddr:                cfg.BindAddr.URL.Host,
+					MasterAddr:              cfg.MasterAddr.URL.String(),
+					AssetAddr:               assetAddr,
+					EtcdHelper:              etcdHelper,
+					MaxProjectsPerRequester: cfg.MaxProjectsPerRequester,
 				}
 
 				// pick an appropriate Kube client
@@ -184,10 +204,17 @@ func NewCommandStartServer(name string) *cobra.Command {
 
 				osmaster.EnsureOpenShiftClient()
 				osmaster.EnsureCORSAllowedOrigins(cfg.CORSAllowedOrigins)
+				osmaster.EnsureWebhookNotifier(cfg.WebhookURLs, cfg.WebhookSecret)
+				osmaster.EnsureProjectLifecycleHook(cfg.ProjectLifecycleHookURL, cfg.ProjectLifecycleHookSecret, 3, lifecycle.FailurePolicy(cfg.ProjectLifecycleHookFailurePolicy))
+				osmaster.EnsureBootstrapPolicy(fmt.Sprintf("https://%s", assetAddr))
+				osmaster.EnsureBuildPodTemplateOverride(cfg.BuildPodTemplateOverrideFile)
 
 				auth := &origin.AuthConfig{
-					SessionSecrets: []string{"secret"},
-					EtcdHelper:     etcdHelper,
+					SessionSecrets:      []string{"secret"},
+					EtcdHelper:          etcdHelper,
+					TokenCacheTTL:       5 * time.Second,
+					ImpersonationAdmins: cfg.ImpersonationAdmins,
+					UseJWTAccessTokens:  cfg.UseJWTAccessTokens,
 				}
 
 				if startKube {
@@ -209,6 +236,10 @@ func NewCommandStartServer(name string) *cobra.Command {
 				osmaster.RunAssetServer()
 				osmaster.RunBuildController()
 				osmaster.RunDeploymentController()
+				osmaster.RunAutoscalerController()
+				osmaster.RunPipelineController()
+				osmaster.RunGarbageCollector()
+				osmaster.RunTokenPruner()
 			}
 
 			if startNode {
@@ -251,10 +282,26 @@ func NewCommandStartServer(name string) *cobra.Command {
 
 	flag.StringVar(&cfg.VolumeDir, "volume-dir", "openshift.local.volumes", "The volume storage directory.")
 	flag.StringVar(&cfg.EtcdDir, "etcd-dir", "openshift.local.etcd", "The etcd data directory.")
+	flag.BoolVar(&cfg.CompressStorage, "compress-storage", false, "Gzip-compress objects before writing them to etcd, and transparently decompress them on read.")
 
 	flag.Var(&cfg.NodeList, "nodes", "The hostnames of each node. This currently must be specified up front. Comma delimited list")
 	flag.Var(&cfg.CORSAllowedOrigins, "cors-allowed-origins", "List of allowed origins for CORS, comma separated.  An allowed origin can be a regular expression to support subdomain matching.  If this list is empty CORS will not be enabled.")
 
+	flag.Var(&cfg.WebhookURLs, "webhook-urls", "List of URLs to notify on build completion, deployment completion/failure, and project creation, comma separated. If this list is empty no notifications will be sent.")
+	flag.StringVar(&cfg.WebhookSecret, "webhook-secret", "", "The shared secret used to HMAC-sign outbound webhook deliveries. Empty disables signing.")
+
+	flag.StringVar(&cfg.ProjectLifecycleHookURL, "project-lifecycle-hook-url", "", "A URL to call synchronously on project create and delete, so an external system (DNS, billing, LDAP groups) can provision or deprovision in lockstep. Empty disables the hook.")
+	flag.StringVar(&cfg.ProjectLifecycleHookSecret, "project-lifecycle-hook-secret", "", "The shared secret used to HMAC-sign project lifecycle hook calls. Empty disables signing.")
+	flag.StringVar(&cfg.ProjectLifecycleHookFailurePolicy, "project-lifecycle-hook-failure-policy", cfg.ProjectLifecycleHookFailurePolicy, "What to do when the project lifecycle hook fails after retrying: \"Deny\" to fail the project operation, or \"Ignore\" to let it proceed.")
+
+	flag.Var(&cfg.ImpersonationAdmins, "impersonation-admins", "List of usernames allowed to act as another user via the Impersonate-User header, comma separated. If this list is empty impersonation is disabled.")
+
+	flag.StringVar(&cfg.BuildPodTemplateOverrideFile, "build-pod-template-override-file", "", "Path to a JSON file containing a strategy.PodTemplateOverride, merged into every pod each build strategy generates. Empty applies no override.")
+
+	flag.IntVar(&cfg.MaxProjectsPerRequester, "max-projects-per-requester", 0, "The maximum number of projects a single requester may own, as recorded by the ProjectRequesterAnnotation. Zero means unlimited.")
+
+	flag.BoolVar(&cfg.UseJWTAccessTokens, "use-jwt-access-tokens", false, "Issue signed JWT access tokens a resource server can verify itself, instead of opaque tokens that require a registry round trip.")
+
 	cfg.Docker.InstallFlags(flag)
 
 	return cmd
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"unicode"
+)
+
+// NewDeploymentConfigDiff computes the field-level differences between old, the currently
+// stored DeploymentConfig, and proposed, a caller-submitted DeploymentConfig with the same ID.
+// Only the Labels, TriggerPolicy, and Template fields are compared; CurrentState reflects
+// controller-managed defaulting rather than caller intent, so it is not diffed.
+func NewDeploymentConfigDiff(old, proposed *DeploymentConfig) *DeploymentConfigDiff {
+	changes := []DeploymentConfigFieldDiff{}
+	changes = append(changes, diffValue("labels", reflect.ValueOf(old.Labels), reflect.ValueOf(proposed.Labels))...)
+	changes = append(changes, diffValue("triggerPolicy", reflect.ValueOf(old.TriggerPolicy), reflect.ValueOf(proposed.TriggerPolicy))...)
+	changes = append(changes, diffValue("template", reflect.ValueOf(old.Template), reflect.ValueOf(proposed.Template))...)
+
+	sort.Sort(byField(changes))
+
+	return &DeploymentConfigDiff{
+		DeploymentConfig: old.ID,
+		Changes:          changes,
+	}
+}
+
+// diffValue recursively compares old and proposed, both assumed to be of the same type, and
+// returns a FieldDiff for every leaf value that differs. Structs are descended into field by
+// field; every other kind is compared as a whole and, if different, rendered with %v.
+func diffValue(field string, old, proposed reflect.Value) []DeploymentConfigFieldDiff {
+	if old.Kind() == reflect.Struct {
+		diffs := []DeploymentConfigFieldDiff{}
+		for i := 0; i < old.NumField(); i++ {
+			name := lowercaseFirst(old.Type().Field(i).Name)
+			diffs = append(diffs, diffValue(field+"."+name, old.Field(i), proposed.Field(i))...)
+		}
+		return diffs
+	}
+
+	if old.Kind() == reflect.Ptr && !old.IsNil() && !proposed.IsNil() {
+		return diffValue(field, old.Elem(), proposed.Elem())
+	}
+
+	if reflect.DeepEqual(old.Interface(), proposed.Interface()) {
+		return nil
+	}
+	return []DeploymentConfigFieldDiff{
+		{Field: field, Old: fmt.Sprintf("%v", old.Interface()), New: fmt.Sprintf("%v", proposed.Interface())},
+	}
+}
+
+// lowercaseFirst renders a Go field name in the lowerCamelCase used for field paths elsewhere
+// in this package's validation errors.
+func lowercaseFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+type byField []DeploymentConfigFieldDiff
+
+func (b byField) Len() int           { return len(b) }
+func (b byField) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byField) Less(i, j int) bool { return b[i].Field < b[j].Field }
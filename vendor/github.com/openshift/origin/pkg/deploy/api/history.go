@@ -0,0 +1,48 @@
+package api
+
+import "sort"
+
+// NewDeploymentHistory assembles the ordered deployment history for a config from the
+// Deployments the controller created for it, newest first.
+func NewDeploymentHistory(configID string, deployments []Deployment) *DeploymentHistory {
+	entries := make([]DeploymentHistoryEntry, 0, len(deployments))
+	for _, d := range deployments {
+		if d.ConfigID != configID {
+			continue
+		}
+		entries = append(entries, DeploymentHistoryEntry{
+			Version:         d.Version,
+			Cause:           d.Cause,
+			Status:          d.State,
+			DurationSeconds: deploymentDurationSeconds(&d),
+			Image:           d.Strategy.CustomPod.imageOrEmpty(),
+		})
+	}
+
+	sort.Sort(sort.Reverse(byVersion(entries)))
+
+	return &DeploymentHistory{
+		ConfigID: configID,
+		Items:    entries,
+	}
+}
+
+func deploymentDurationSeconds(d *Deployment) int64 {
+	if d.CompletionTimestamp == nil || d.CreationTimestamp.IsZero() {
+		return 0
+	}
+	return int64(d.CompletionTimestamp.Sub(d.CreationTimestamp.Time).Seconds())
+}
+
+func (s *CustomPodDeploymentStrategy) imageOrEmpty() string {
+	if s == nil {
+		return ""
+	}
+	return s.Image
+}
+
+type byVersion []DeploymentHistoryEntry
+
+func (b byVersion) Len() int           { return len(b) }
+func (b byVersion) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byVersion) Less(i, j int) bool { return b[i].Version < b[j].Version }
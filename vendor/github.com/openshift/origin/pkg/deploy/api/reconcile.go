@@ -0,0 +1,12 @@
+package api
+
+// ReconcileReplicas carries the observed replica count on old forward onto updated so that
+// pushing a new pod template does not reset a replica count that was scaled manually on the
+// live ReplicationController. Callers should invoke this before persisting an update.
+func ReconcileReplicas(old, updated *DeploymentConfig) {
+	if old == nil || updated == nil {
+		return
+	}
+	updated.CurrentState.Replicas = old.CurrentState.Replicas
+	updated.Template.ControllerTemplate.Replicas = old.Template.ControllerTemplate.Replicas
+}
@@ -0,0 +1,8 @@
+package api
+
+// TriggerEnabled reports whether config's trigger is currently allowed to start a new
+// Deployment. A paused trigger definition is kept in place but produces no deployments until
+// unpaused.
+func TriggerEnabled(config *DeploymentConfig) bool {
+	return !config.TriggerPolicy.Paused
+}
@@ -2,12 +2,18 @@ package api
 
 import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
 )
 
 // CustomPodDeploymentStrategy describes a deployment carried out by a custom pod.
 type CustomPodDeploymentStrategy struct {
 	Image       string       `json:"image,omitempty" yaml:"image,omitempty"`
 	Environment []api.EnvVar `json:"environment,omitempty" yaml:"environment,omitempty"`
+
+	// SecretName is the ID of a Secret whose contents are mounted into the deployment
+	// pod, for custom pods that need credentials the deployment config shouldn't carry
+	// in Environment.
+	SecretName string `json:"secretName,omitempty" yaml:"secretName,omitempty"`
 }
 
 // DeploymentStrategy describes how to perform a deployment.
@@ -42,11 +48,40 @@ type Deployment struct {
 	ControllerTemplate api.ReplicationControllerState `json:"controllerTemplate,omitempty" yaml:"controllerTemplate,omitempty"`
 	State              DeploymentState                `json:"state,omitempty" yaml:"state,omitempty"`
 	ConfigID           string                         `json:"configId,omitempty" yaml:"configId,omitempty"`
+	// Version is the ordinal of the DeploymentConfig that produced this Deployment.
+	Version int `json:"version,omitempty" yaml:"version,omitempty"`
+	// Cause is a human-readable description of what triggered this Deployment.
+	Cause string `json:"cause,omitempty" yaml:"cause,omitempty"`
+	// CompletionTimestamp records when the Deployment reached a terminal state.
+	CompletionTimestamp *util.Time `json:"completionTimestamp,omitempty" yaml:"completionTimestamp,omitempty"`
+	// GenerateName, if specified on Create and ID is empty, instructs the server to fill in
+	// ID with this value followed by a random suffix, retrying on a collision.
+	GenerateName string `json:"generateName,omitempty" yaml:"generateName,omitempty"`
+	// UID is set by the server on creation and never changes for the lifetime of this
+	// Deployment, even if its ID is later reused by a different Deployment. Owner references
+	// that need to survive ID reuse, such as the pod-to-Deployment labels the garbage
+	// collector checks, should compare UID rather than ID.
+	UID string `json:"uid,omitempty" yaml:"uid,omitempty"`
 }
 
 // DeploymentTriggerPolicy describes the possible triggers that result in a new Deployment.
 type DeploymentTriggerPolicy struct {
-	Type DeploymentTriggerType `json:"type,omitempty" yaml:"type,omitempty"`
+	Type              DeploymentTriggerType `json:"type,omitempty" yaml:"type,omitempty"`
+	ImageChangeParams *ImageChangeParams    `json:"imageChangeParams,omitempty" yaml:"imageChangeParams,omitempty"`
+	// Paused stops this trigger from starting new deployments without removing the trigger
+	// definition, so a noisy image stream or config source can be silenced temporarily.
+	Paused bool `json:"paused,omitempty" yaml:"paused,omitempty"`
+}
+
+// ImageChangeParams holds the parameters for an ImageChange trigger. RepositoryNamespace may
+// name a namespace other than the DeploymentConfig's own, allowing a config to redeploy when a
+// shared base-image repository owned by another project changes tags.
+type ImageChangeParams struct {
+	Automatic           bool     `json:"automatic,omitempty" yaml:"automatic,omitempty"`
+	ContainerNames      []string `json:"containerNames,omitempty" yaml:"containerNames,omitempty"`
+	RepositoryName      string   `json:"repositoryName,omitempty" yaml:"repositoryName,omitempty"`
+	RepositoryNamespace string   `json:"repositoryNamespace,omitempty" yaml:"repositoryNamespace,omitempty"`
+	Tag                 string   `json:"tag,omitempty" yaml:"tag,omitempty"`
 }
 
 type DeploymentTriggerType string
@@ -66,6 +101,11 @@ type DeploymentConfig struct {
 	TriggerPolicy DeploymentTriggerPolicy        `json:"triggerPolicy,omitempty" yaml:"triggerPolicy,omitempty"`
 	Template      DeploymentTemplate             `json:"template,omitempty" yaml:"template,omitempty"`
 	CurrentState  api.ReplicationControllerState `json:"currentState" yaml:"currentState,omitempty"`
+
+	// ConsecutiveFailures counts the Deployments produced by this config that have
+	// finished as DeploymentFailed since the last DeploymentComplete. It is reset to
+	// zero on the next successful Deployment.
+	ConsecutiveFailures int `json:"consecutiveFailures,omitempty" yaml:"consecutiveFailures,omitempty"`
 }
 
 // A DeploymentConfigList is a collection of deployment configs
@@ -79,3 +119,41 @@ type DeploymentList struct {
 	api.JSONBase `json:",inline" yaml:",inline"`
 	Items        []Deployment `json:"items,omitempty" yaml:"items,omitempty"`
 }
+
+// DeploymentHistoryEntry describes a single Deployment produced by a DeploymentConfig, in the
+// shape a CLI wants to render without reconstructing it from ReplicationController annotations.
+type DeploymentHistoryEntry struct {
+	Version         int             `json:"version,omitempty" yaml:"version,omitempty"`
+	Cause           string          `json:"cause,omitempty" yaml:"cause,omitempty"`
+	Status          DeploymentState `json:"status,omitempty" yaml:"status,omitempty"`
+	DurationSeconds int64           `json:"durationSeconds,omitempty" yaml:"durationSeconds,omitempty"`
+	Image           string          `json:"image,omitempty" yaml:"image,omitempty"`
+}
+
+// DeploymentHistory is the ordered history of Deployments created for a DeploymentConfig, newest
+// first.
+type DeploymentHistory struct {
+	api.JSONBase `json:",inline" yaml:",inline"`
+	ConfigID     string                   `json:"configId,omitempty" yaml:"configId,omitempty"`
+	Items        []DeploymentHistoryEntry `json:"items,omitempty" yaml:"items,omitempty"`
+}
+
+// DeploymentConfigFieldDiff describes a single field that differs between the stored and
+// proposed versions of a DeploymentConfig.
+type DeploymentConfigFieldDiff struct {
+	// Field is the dotted path to the differing field.
+	Field string `json:"field" yaml:"field"`
+	// Old is the field's value in the currently stored DeploymentConfig.
+	Old string `json:"old,omitempty" yaml:"old,omitempty"`
+	// New is the field's value in the proposed DeploymentConfig.
+	New string `json:"new,omitempty" yaml:"new,omitempty"`
+}
+
+// DeploymentConfigDiff describes the field-level differences between a proposed
+// DeploymentConfig and the version currently stored under the same ID, so tooling can show a
+// user exactly what an update would change before submitting it.
+type DeploymentConfigDiff struct {
+	api.JSONBase     `json:",inline" yaml:",inline"`
+	DeploymentConfig string                      `json:"deploymentConfig,omitempty" yaml:"deploymentConfig,omitempty"`
+	Changes          []DeploymentConfigFieldDiff `json:"changes,omitempty" yaml:"changes,omitempty"`
+}
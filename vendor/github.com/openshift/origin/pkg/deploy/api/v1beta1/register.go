@@ -10,6 +10,8 @@ func init() {
 		&DeploymentList{},
 		&DeploymentConfig{},
 		&DeploymentConfigList{},
+		&DeploymentHistory{},
+		&DeploymentConfigDiff{},
 	)
 }
 
@@ -17,3 +19,5 @@ func (*Deployment) IsAnAPIObject()           {}
 func (*DeploymentList) IsAnAPIObject()       {}
 func (*DeploymentConfig) IsAnAPIObject()     {}
 func (*DeploymentConfigList) IsAnAPIObject() {}
+func (*DeploymentHistory) IsAnAPIObject()    {}
+func (*DeploymentConfigDiff) IsAnAPIObject() {}
@@ -35,22 +35,120 @@ func validateDeploymentStrategy(strategy *deployapi.DeploymentStrategy) errors.E
 	return result
 }
 
-func validateTriggerPolicy(policy *deployapi.DeploymentTriggerPolicy) errors.ErrorList {
+func validateTriggerPolicy(namespace string, policy *deployapi.DeploymentTriggerPolicy) errors.ErrorList {
 	result := errors.ErrorList{}
 
 	if len(policy.Type) == 0 {
 		result = append(result, errors.NewFieldRequired("Type", ""))
 	}
 
+	if policy.Type == deployapi.DeploymentTriggerOnImageChange {
+		result = append(result, validateImageChangeParams(namespace, policy.ImageChangeParams).Prefix("ImageChangeParams")...)
+	}
+
+	return result
+}
+
+// ImageStreamAccessChecker decides whether a DeploymentConfig in sourceNamespace is allowed to
+// trigger off an ImageRepository named repositoryName in repositoryNamespace. It is the extension
+// point projects use to plug in their authorization backend; RegisterImageStreamAccessChecker
+// replaces the default, which only allows triggers within the config's own namespace.
+type ImageStreamAccessChecker func(sourceNamespace, repositoryNamespace, repositoryName string) bool
+
+var imageStreamAccessChecker ImageStreamAccessChecker = sameNamespaceImageStreamAccessChecker
+
+func sameNamespaceImageStreamAccessChecker(sourceNamespace, repositoryNamespace, repositoryName string) bool {
+	return len(repositoryNamespace) == 0 || repositoryNamespace == sourceNamespace
+}
+
+// RegisterImageStreamAccessChecker overrides the function used to authorize cross-namespace image
+// change triggers.
+func RegisterImageStreamAccessChecker(checker ImageStreamAccessChecker) {
+	imageStreamAccessChecker = checker
+}
+
+func validateImageChangeParams(namespace string, params *deployapi.ImageChangeParams) errors.ErrorList {
+	result := errors.ErrorList{}
+
+	if params == nil {
+		result = append(result, errors.NewFieldRequired("ImageChangeParams", nil))
+		return result
+	}
+
+	if len(params.RepositoryName) == 0 {
+		result = append(result, errors.NewFieldRequired("RepositoryName", ""))
+	}
+
+	if !imageStreamAccessChecker(namespace, params.RepositoryNamespace, params.RepositoryName) {
+		result = append(result, errors.NewFieldInvalid("RepositoryNamespace", params.RepositoryNamespace))
+	}
+
 	return result
 }
 
 func ValidateDeploymentConfig(config *deployapi.DeploymentConfig) errors.ErrorList {
 	result := errors.ErrorList{}
-	result = append(result, validateTriggerPolicy(&config.TriggerPolicy).Prefix("TriggerPolicy")...)
+	result = append(result, validateTriggerPolicy(config.Namespace, &config.TriggerPolicy).Prefix("TriggerPolicy")...)
 	result = append(result, validateDeploymentStrategy(&config.Template.Strategy).Prefix("Template.Strategy")...)
+	result = append(result, validateConfigIDLength(config.ID, maxDeploymentSuffixLength)...)
 
 	// TODO: validate ReplicationControllerState
 
 	return result
 }
+
+// maxDeploymentSuffixLength reserves room for the two suffixes chained onto a
+// DeploymentConfig's ID before it reaches a running pod: a Deployment gets "-<n>"
+// appended, and the pod started from it gets a further generated suffix of its own.
+// This snapshot doesn't yet generate those derived names, but rejecting an ID that
+// wouldn't leave room for them now avoids the failure once it does.
+const maxDeploymentSuffixLength = 16
+
+// dnsLabelMaxLength mirrors the DNS label length limit (RFC 1035/1123) enforced
+// elsewhere in validation; it isn't exported by the upstream util package.
+const dnsLabelMaxLength = 63
+
+// validateConfigIDLength rejects an ID that is already too long to have suffixLength
+// characters appended to it and still fit within a DNS label.
+func validateConfigIDLength(id string, suffixLength int) errors.ErrorList {
+	result := errors.ErrorList{}
+	if len(id) > dnsLabelMaxLength-suffixLength {
+		result = append(result, errors.NewFieldTooLong("id", id))
+	}
+	return result
+}
+
+// ValidateDeploymentConfigSelectorConflict checks config's replica selector against every
+// other DeploymentConfig in existing that shares its namespace, returning an error for each
+// one whose selector could match the same pods. Left unchecked, two DeploymentConfigs with
+// overlapping selectors produce ReplicationControllers that silently fight over the same pods.
+func ValidateDeploymentConfigSelectorConflict(config *deployapi.DeploymentConfig, existing []deployapi.DeploymentConfig) errors.ErrorList {
+	result := errors.ErrorList{}
+	selector := config.Template.ControllerTemplate.ReplicaSelector
+	for i := range existing {
+		other := &existing[i]
+		if other.ID == config.ID || other.Namespace != config.Namespace {
+			continue
+		}
+		if selectorsOverlap(selector, other.Template.ControllerTemplate.ReplicaSelector) {
+			result = append(result, errors.NewFieldInvalid("Template.ControllerTemplate.ReplicaSelector", selector))
+			break
+		}
+	}
+	return result
+}
+
+// selectorsOverlap reports whether two equality-based label selectors could both match the
+// same pod. They conflict unless they disagree on the value of some key they share; either
+// selector being empty means it matches every pod, so it conflicts with anything.
+func selectorsOverlap(a, b map[string]string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; ok && bv != v {
+			return false
+		}
+	}
+	return true
+}
@@ -3,8 +3,10 @@ package validation
 import (
 	"testing"
 
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
 	"github.com/openshift/origin/pkg/deploy/api"
+	validationutil "github.com/openshift/origin/pkg/util/validation"
 )
 
 // Convenience methods
@@ -83,17 +85,7 @@ func TestValidateDeploymentMissingFields(t *testing.T) {
 
 	for k, v := range errorCases {
 		errs := ValidateDeployment(&v.D)
-		if len(errs) == 0 {
-			t.Errorf("Expected failure for scenario %s", k)
-		}
-		for i := range errs {
-			if errs[i].(errors.ValidationError).Type != v.T {
-				t.Errorf("%s: expected errors to have type %s: %v", k, v.T, errs[i])
-			}
-			if errs[i].(errors.ValidationError).Field != v.F {
-				t.Errorf("%s: expected errors to have field %s: %v", k, v.F, errs[i])
-			}
-		}
+		validationutil.ExpectInvalid(t, k, errs, v.T, v.F)
 	}
 }
 
@@ -160,16 +152,92 @@ func TestValidateDeploymentConfigMissingFields(t *testing.T) {
 
 	for k, v := range errorCases {
 		errs := ValidateDeploymentConfig(&v.D)
-		if len(errs) == 0 {
-			t.Errorf("Expected failure for scenario %s", k)
-		}
-		for i := range errs {
-			if errs[i].(errors.ValidationError).Type != v.T {
-				t.Errorf("%s: expected errors to have type %s: %v", k, v.T, errs[i])
-			}
-			if errs[i].(errors.ValidationError).Field != v.F {
-				t.Errorf("%s: expected errors to have field %s: %v", k, v.F, errs[i])
-			}
+		validationutil.ExpectInvalid(t, k, errs, v.T, v.F)
+	}
+}
+
+func TestValidateDeploymentConfigIDTooLong(t *testing.T) {
+	config := &api.DeploymentConfig{
+		JSONBase:      kubeapi.JSONBase{ID: "this-config-id-is-far-too-long-to-leave-room-for-generated-suffixes"},
+		TriggerPolicy: manualTrigger(),
+		Template:      okTemplate(),
+	}
+	errs := ValidateDeploymentConfig(config)
+	validationutil.ExpectInvalid(t, "id too long", errs, errors.ValidationErrorTypeTooLong, "id")
+}
+
+func imageChangeTrigger(namespace, repositoryNamespace, repositoryName string) api.DeploymentTriggerPolicy {
+	return api.DeploymentTriggerPolicy{
+		Type: api.DeploymentTriggerOnImageChange,
+		ImageChangeParams: &api.ImageChangeParams{
+			RepositoryName:      repositoryName,
+			RepositoryNamespace: repositoryNamespace,
+		},
+	}
+}
+
+func TestValidateDeploymentConfigCrossNamespaceImageChangeTrigger(t *testing.T) {
+	defer RegisterImageStreamAccessChecker(sameNamespaceImageStreamAccessChecker)
+
+	config := api.DeploymentConfig{
+		JSONBase:      kubeapi.JSONBase{Namespace: "ns"},
+		TriggerPolicy: imageChangeTrigger("ns", "other-ns", "shared-base-image"),
+		Template:      okTemplate(),
+	}
+
+	errs := ValidateDeploymentConfig(&config)
+	validationutil.ExpectInvalid(t, "cross-namespace reference rejected by default", errs, errors.ValidationErrorTypeInvalid, "TriggerPolicy.ImageChangeParams.RepositoryNamespace")
+
+	RegisterImageStreamAccessChecker(func(sourceNamespace, repositoryNamespace, repositoryName string) bool {
+		return repositoryNamespace == "other-ns"
+	})
+
+	errs = ValidateDeploymentConfig(&config)
+	if len(errs) > 0 {
+		t.Errorf("unexpected non-empty error list once the checker allows the reference: %#v", errs)
+	}
+}
+
+func withSelector(namespace, id string, selector map[string]string) api.DeploymentConfig {
+	config := api.DeploymentConfig{
+		JSONBase: kubeapi.JSONBase{ID: id, Namespace: namespace},
+	}
+	config.Template.ControllerTemplate.ReplicaSelector = selector
+	return config
+}
+
+func TestValidateDeploymentConfigSelectorConflict(t *testing.T) {
+	config := withSelector("ns", "new", map[string]string{"name": "frontend"})
+	existing := []api.DeploymentConfig{
+		withSelector("ns", "other", map[string]string{"name": "frontend"}),
+	}
+	errs := ValidateDeploymentConfigSelectorConflict(&config, existing)
+	validationutil.ExpectInvalid(t, "overlapping selector", errs, errors.ValidationErrorTypeInvalid, "Template.ControllerTemplate.ReplicaSelector")
+}
+
+func TestValidateDeploymentConfigSelectorConflictOK(t *testing.T) {
+	cases := map[string]struct {
+		config   api.DeploymentConfig
+		existing []api.DeploymentConfig
+	}{
+		"disjoint selector": {
+			withSelector("ns", "new", map[string]string{"name": "frontend"}),
+			[]api.DeploymentConfig{withSelector("ns", "other", map[string]string{"name": "backend"})},
+		},
+		"different namespace": {
+			withSelector("ns", "new", map[string]string{"name": "frontend"}),
+			[]api.DeploymentConfig{withSelector("other-ns", "other", map[string]string{"name": "frontend"})},
+		},
+		"same config": {
+			withSelector("ns", "same", map[string]string{"name": "frontend"}),
+			[]api.DeploymentConfig{withSelector("ns", "same", map[string]string{"name": "frontend"})},
+		},
+	}
+
+	for k, v := range cases {
+		errs := ValidateDeploymentConfigSelectorConflict(&v.config, v.existing)
+		if len(errs) > 0 {
+			t.Errorf("%s: unexpected non-empty error list: %#v", k, errs)
 		}
 	}
 }
@@ -1,15 +1,25 @@
 package deploy
 
 import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"strconv"
 	"time"
 
 	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
 	kubeclient "github.com/GoogleCloudPlatform/kubernetes/pkg/client"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
 	"github.com/golang/glog"
 	osclient "github.com/openshift/origin/pkg/client"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+	projectapi "github.com/openshift/origin/pkg/project/api"
+	secretapi "github.com/openshift/origin/pkg/secret/api"
+	"github.com/openshift/origin/pkg/util/ownerref"
+	"github.com/openshift/origin/pkg/webhook/notify"
 )
 
 // A DeploymentController is responsible for executing Deployment objects stored in etcd
@@ -32,10 +42,14 @@ type DefaultDeploymentHandler struct {
 	osClient    osclient.Interface
 	kubeClient  kubeclient.Interface
 	environment []kapi.EnvVar
+	// notifier delivers a "deployment.complete" or "deployment.failed" event whenever a
+	// deployment reaches a terminal state. May be nil, in which case no notifications
+	// are sent.
+	notifier *notify.Notifier
 }
 
 // NewDeploymentController creates a new DeploymentController.
-func NewDeploymentController(kubeClient kubeclient.Interface, osClient osclient.Interface, initialEnvironment []kapi.EnvVar) *DeploymentController {
+func NewDeploymentController(kubeClient kubeclient.Interface, osClient osclient.Interface, initialEnvironment []kapi.EnvVar, notifier *notify.Notifier) *DeploymentController {
 	dc := &DeploymentController{
 		kubeClient: kubeClient,
 		osClient:   osClient,
@@ -43,6 +57,7 @@ func NewDeploymentController(kubeClient kubeclient.Interface, osClient osclient.
 			osClient:    osClient,
 			kubeClient:  kubeClient,
 			environment: initialEnvironment,
+			notifier:    notifier,
 		},
 	}
 	return dc
@@ -102,19 +117,26 @@ func (dh *DefaultDeploymentHandler) saveDeployment(ctx kapi.Context, deployment
 	return err
 }
 
-func (dh *DefaultDeploymentHandler) makeDeploymentPod(deployment *deployapi.Deployment) *kapi.Pod {
+func (dh *DefaultDeploymentHandler) makeDeploymentPod(ctx kapi.Context, deployment *deployapi.Deployment) (*kapi.Pod, error) {
 	podID := deploymentPodID(deployment)
 
+	defaults := dh.projectDefaults(ctx, deployment.Namespace)
+	if defaults != nil && !imageapi.RegistryAllowed(deployment.Strategy.CustomPod.Image, defaults.AllowedRegistries) {
+		return nil, fmt.Errorf("Image %s for deployment ID %v is not from a registry allowed by project %s", deployment.Strategy.CustomPod.Image, deployment.ID, deployment.Namespace)
+	}
+
 	envVars := deployment.Strategy.CustomPod.Environment
 	envVars = append(envVars, kapi.EnvVar{Name: "KUBERNETES_DEPLOYMENT_ID", Value: deployment.ID})
+	envVars = append(envVars, dh.rolloutEnvironment(ctx, deployment)...)
 	for _, env := range dh.environment {
 		envVars = append(envVars, env)
 	}
 
-	return &kapi.Pod{
+	pod := &kapi.Pod{
 		JSONBase: kapi.JSONBase{
 			ID: podID,
 		},
+		Labels: ownerref.Set(nil, "Deployment", deployment.ID, deployment.UID),
 		DesiredState: kapi.PodState{
 			Manifest: kapi.ContainerManifest{
 				Version: "v1beta1",
@@ -131,6 +153,140 @@ func (dh *DefaultDeploymentHandler) makeDeploymentPod(deployment *deployapi.Depl
 			},
 		},
 	}
+
+	secretName := deployment.Strategy.CustomPod.SecretName
+	if len(secretName) == 0 && defaults != nil {
+		secretName = defaults.PullSecretName
+	}
+	if len(secretName) > 0 {
+		secret, err := dh.osClient.GetSecret(ctx, secretName)
+		if err != nil {
+			return nil, err
+		}
+		if err := setupSecretVolume(pod, secret); err != nil {
+			return nil, err
+		}
+	}
+
+	if defaults != nil {
+		if err := defaults.Resources.ApplyLimits(pod); err != nil {
+			return nil, err
+		}
+	}
+
+	return pod, nil
+}
+
+// rolloutEnvironment builds the OPENSHIFT_DEPLOYMENT_* env vars describing this rollout, so a
+// deployment pod's migration or other custom logic can branch on what's being rolled out
+// without querying the API itself. The OLD_* vars are omitted for a config's first deployment,
+// since there is nothing to compare against.
+func (dh *DefaultDeploymentHandler) rolloutEnvironment(ctx kapi.Context, deployment *deployapi.Deployment) []kapi.EnvVar {
+	env := []kapi.EnvVar{
+		{Name: "OPENSHIFT_DEPLOYMENT_CONFIG_NAME", Value: deployment.ConfigID},
+		{Name: "OPENSHIFT_DEPLOYMENT_NAMESPACE", Value: deployment.Namespace},
+		{Name: "OPENSHIFT_DEPLOYMENT_NEW_VERSION", Value: strconv.Itoa(deployment.Version)},
+	}
+	if image := firstContainerImage(deployment); len(image) > 0 {
+		env = append(env, kapi.EnvVar{Name: "OPENSHIFT_DEPLOYMENT_NEW_IMAGE", Value: image})
+	}
+
+	previous := dh.previousDeployment(ctx, deployment)
+	if previous == nil {
+		return env
+	}
+	env = append(env, kapi.EnvVar{Name: "OPENSHIFT_DEPLOYMENT_OLD_VERSION", Value: strconv.Itoa(previous.Version)})
+	if image := firstContainerImage(previous); len(image) > 0 {
+		env = append(env, kapi.EnvVar{Name: "OPENSHIFT_DEPLOYMENT_OLD_IMAGE", Value: image})
+	}
+	return env
+}
+
+// previousDeployment returns the Deployment belonging to the same DeploymentConfig as
+// deployment with the highest Version less than deployment's own, or nil if deployment is that
+// config's first.
+func (dh *DefaultDeploymentHandler) previousDeployment(ctx kapi.Context, deployment *deployapi.Deployment) *deployapi.Deployment {
+	deployments, err := dh.osClient.ListDeployments(ctx, labels.Everything())
+	if err != nil {
+		glog.Errorf("Error listing deployments while resolving the previous version for deployment ID %v: %#v", deployment.ID, err)
+		return nil
+	}
+
+	var previous *deployapi.Deployment
+	for i := range deployments.Items {
+		candidate := &deployments.Items[i]
+		if candidate.ConfigID != deployment.ConfigID || candidate.Version >= deployment.Version {
+			continue
+		}
+		if previous == nil || candidate.Version > previous.Version {
+			previous = candidate
+		}
+	}
+	return previous
+}
+
+// firstContainerImage returns the image of deployment's first container, or "" if its
+// controller template has none.
+func firstContainerImage(deployment *deployapi.Deployment) string {
+	containers := deployment.ControllerTemplate.PodTemplate.DesiredState.Manifest.Containers
+	if len(containers) == 0 {
+		return ""
+	}
+	return containers[0].Image
+}
+
+// projectDefaults returns the ProjectDefaults for namespace, or nil if namespace is empty
+// or has none configured.
+func (dh *DefaultDeploymentHandler) projectDefaults(ctx kapi.Context, namespace string) *projectapi.ProjectDefaults {
+	if len(namespace) == 0 {
+		return nil
+	}
+	defaults, err := dh.osClient.GetProjectDefaults(ctx, namespace)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			glog.Errorf("Error retrieving project defaults for namespace %s: %#v", namespace, err)
+		}
+		return nil
+	}
+	return defaults
+}
+
+// setupSecretVolume writes secret's Data entries out as files in a fresh temp
+// directory and mounts that directory, read-only, into the deployment pod's
+// container, matching the way build strategy pods pick up credential secrets.
+func setupSecretVolume(pod *kapi.Pod, secret *secretapi.Secret) error {
+	secretDir, err := ioutil.TempDir("", "secret")
+	if err != nil {
+		return err
+	}
+	for name, value := range secret.Data {
+		if err := ioutil.WriteFile(path.Join(secretDir, name), []byte(value), 0600); err != nil {
+			return err
+		}
+	}
+
+	secretVolume := kapi.Volume{
+		Name: "secret",
+		Source: &kapi.VolumeSource{
+			HostDir: &kapi.HostDir{
+				Path: secretDir,
+			},
+		},
+	}
+	secretVolumeMount := kapi.VolumeMount{
+		Name:      "secret",
+		ReadOnly:  true,
+		MountPath: "/var/run/secrets/openshift.io/deploy",
+	}
+
+	pod.DesiredState.Manifest.Volumes = append(pod.DesiredState.Manifest.Volumes, secretVolume)
+	pod.DesiredState.Manifest.Containers[0].VolumeMounts =
+		append(pod.DesiredState.Manifest.Containers[0].VolumeMounts, secretVolumeMount)
+	pod.DesiredState.Manifest.Containers[0].Env =
+		append(pod.DesiredState.Manifest.Containers[0].Env,
+			kapi.EnvVar{Name: "DEPLOYMENT_SECRET_PATH", Value: "/var/run/secrets/openshift.io/deploy"})
+
+	return nil
 }
 
 func deploymentPodID(deployment *deployapi.Deployment) string {
@@ -139,11 +295,19 @@ func deploymentPodID(deployment *deployapi.Deployment) string {
 
 // Handler for a deployment in the 'new' state.
 func (dh *DefaultDeploymentHandler) HandleNew(ctx kapi.Context, deployment *deployapi.Deployment) error {
-	deploymentPod := dh.makeDeploymentPod(deployment)
+	deploymentPod, err := dh.makeDeploymentPod(ctx, deployment)
+	if err != nil {
+		glog.Warningf("Received error building deployment pod: %v", err)
+		deployment.State = deployapi.DeploymentFailed
+		dh.notifyOnTerminalState(ctx, deployment)
+		return dh.saveDeployment(ctx, deployment)
+	}
+
 	glog.Infof("Attempting to create deployment pod: %+v", deploymentPod)
 	if pod, err := dh.kubeClient.CreatePod(kapi.NewContext(), deploymentPod); err != nil {
 		glog.Warningf("Received error creating pod: %v", err)
 		deployment.State = deployapi.DeploymentFailed
+		dh.notifyOnTerminalState(ctx, deployment)
 	} else {
 		glog.Infof("Successfully created pod %+v", pod)
 		deployment.State = deployapi.DeploymentPending
@@ -160,6 +324,7 @@ func (dh *DefaultDeploymentHandler) HandlePending(ctx kapi.Context, deployment *
 	if err != nil {
 		glog.Errorf("Error retrieving pod for deployment ID %v: %#v", deployment.ID, err)
 		deployment.State = deployapi.DeploymentFailed
+		dh.notifyOnTerminalState(ctx, deployment)
 	} else {
 		glog.Infof("Deployment pod is %+v", pod)
 
@@ -167,7 +332,7 @@ func (dh *DefaultDeploymentHandler) HandlePending(ctx kapi.Context, deployment *
 		case kapi.PodRunning:
 			deployment.State = deployapi.DeploymentRunning
 		case kapi.PodTerminated:
-			dh.checkForTerminatedDeploymentPod(deployment, pod)
+			dh.checkForTerminatedDeploymentPod(ctx, deployment, pod)
 		}
 	}
 
@@ -182,15 +347,16 @@ func (dh *DefaultDeploymentHandler) HandleRunning(ctx kapi.Context, deployment *
 	if err != nil {
 		glog.Errorf("Error retrieving pod for deployment ID %v: %#v", deployment.ID, err)
 		deployment.State = deployapi.DeploymentFailed
+		dh.notifyOnTerminalState(ctx, deployment)
 	} else {
 		glog.Infof("Deployment pod is %+v", pod)
-		dh.checkForTerminatedDeploymentPod(deployment, pod)
+		dh.checkForTerminatedDeploymentPod(ctx, deployment, pod)
 	}
 
 	return dh.saveDeployment(ctx, deployment)
 }
 
-func (dh *DefaultDeploymentHandler) checkForTerminatedDeploymentPod(deployment *deployapi.Deployment, pod *kapi.Pod) {
+func (dh *DefaultDeploymentHandler) checkForTerminatedDeploymentPod(ctx kapi.Context, deployment *deployapi.Deployment, pod *kapi.Pod) {
 	if pod.CurrentState.Status != kapi.PodTerminated {
 		glog.Infof("The deployment has not yet finished. Pod status is %s. Continuing", pod.CurrentState.Status)
 		return
@@ -210,5 +376,72 @@ func (dh *DefaultDeploymentHandler) checkForTerminatedDeploymentPod(deployment *
 	}
 
 	glog.Infof("The deployment pod has finished. Setting deployment state to %s", deployment.State)
+	dh.notifyOnTerminalState(ctx, deployment)
 	return
 }
+
+// notifyOnTerminalState sends a deployment.complete or deployment.failed event once a
+// deployment reaches a state a caller might be waiting to hear about, and updates that
+// state's DeploymentConfig status summary.
+func (dh *DefaultDeploymentHandler) notifyOnTerminalState(ctx kapi.Context, deployment *deployapi.Deployment) {
+	dh.updateDeploymentConfigStatus(ctx, deployment)
+
+	if dh.notifier == nil {
+		return
+	}
+	var eventType string
+	switch deployment.State {
+	case deployapi.DeploymentComplete:
+		eventType = "deployment.complete"
+	case deployapi.DeploymentFailed:
+		eventType = "deployment.failed"
+	default:
+		return
+	}
+	dh.notifier.Notify(notify.Event{Type: eventType, ID: deployment.ID, Timestamp: util.Now()})
+}
+
+// MaxConsecutiveDeploymentFailures is the number of consecutive Deployment failures a
+// DeploymentConfig tolerates before its trigger is automatically paused, to keep a
+// broken image or pod spec from being retriggered into an endless string of failing
+// deployments.
+const MaxConsecutiveDeploymentFailures = 5
+
+// updateDeploymentConfigStatus tracks deployment's outcome on its DeploymentConfig. Once
+// ConsecutiveFailures reaches MaxConsecutiveDeploymentFailures it also pauses the
+// config's trigger and emits a deploymentConfig.paused event. It is a no-op for
+// deployments not in a terminal state or without a ConfigID.
+func (dh *DefaultDeploymentHandler) updateDeploymentConfigStatus(ctx kapi.Context, deployment *deployapi.Deployment) {
+	if len(deployment.ConfigID) == 0 {
+		return
+	}
+	switch deployment.State {
+	case deployapi.DeploymentComplete, deployapi.DeploymentFailed:
+	default:
+		return
+	}
+
+	config, err := dh.osClient.GetDeploymentConfig(ctx, deployment.ConfigID)
+	if err != nil {
+		glog.Errorf("Error retrieving DeploymentConfig %s to update its status for deployment ID %v: %#v", deployment.ConfigID, deployment.ID, err)
+		return
+	}
+
+	switch deployment.State {
+	case deployapi.DeploymentComplete:
+		config.ConsecutiveFailures = 0
+	case deployapi.DeploymentFailed:
+		config.ConsecutiveFailures++
+		if config.ConsecutiveFailures >= MaxConsecutiveDeploymentFailures && !config.TriggerPolicy.Paused {
+			config.TriggerPolicy.Paused = true
+			glog.Errorf("Pausing DeploymentConfig %s after %d consecutive failures", config.ID, config.ConsecutiveFailures)
+			if dh.notifier != nil {
+				dh.notifier.Notify(notify.Event{Type: "deploymentConfig.paused", ID: config.ID, Timestamp: util.Now()})
+			}
+		}
+	}
+
+	if _, err := dh.osClient.UpdateDeploymentConfig(ctx, config); err != nil {
+		glog.Errorf("Error updating status on DeploymentConfig %s for deployment ID %v: %#v", deployment.ConfigID, deployment.ID, err)
+	}
+}
@@ -2,6 +2,7 @@ package deploy
 
 import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
 	api "github.com/openshift/origin/pkg/deploy/api"
 )
 
@@ -9,6 +10,9 @@ import (
 type Registry interface {
 	ListDeployments(selector labels.Selector) (*api.DeploymentList, error)
 	GetDeployment(id string) (*api.Deployment, error)
+	// WatchDeployments watches for new/changed/deleted deployments, so callers can follow a
+	// rollout's progress (New -> Pending -> Running -> Complete/Failed) as it happens.
+	WatchDeployments(resourceVersion uint64, filter func(deployment *api.Deployment) bool) (watch.Interface, error)
 	CreateDeployment(deployment *api.Deployment) error
 	UpdateDeployment(deployment *api.Deployment) error
 	DeleteDeployment(id string) error
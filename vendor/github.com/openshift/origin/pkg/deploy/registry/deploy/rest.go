@@ -9,10 +9,14 @@ import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/apiserver"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
 	"github.com/golang/glog"
 
+	originapi "github.com/openshift/origin/pkg/api"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	"github.com/openshift/origin/pkg/deploy/api/validation"
+	"github.com/openshift/origin/pkg/util/apierrors"
+	"github.com/openshift/origin/pkg/util/generatename"
 )
 
 // REST is an implementation of RESTStorage for the api server.
@@ -50,8 +54,33 @@ func (s *REST) Get(ctx kubeapi.Context, id string) (runtime.Object, error) {
 	return deployment, err
 }
 
-// Delete asynchronously deletes the Deployment specified by its id.
+// Watch returns Deployment events via a watch.Interface, so callers can follow a rollout's
+// progress as its Deployment moves through New, Pending, Running, and Complete or Failed.
+// It implements apiserver.ResourceWatcher.
+func (s *REST) Watch(ctx kubeapi.Context, label, field labels.Selector, resourceVersion uint64) (watch.Interface, error) {
+	return s.registry.WatchDeployments(resourceVersion, func(deployment *deployapi.Deployment) bool {
+		fields := labels.Set{
+			"ID":       deployment.ID,
+			"configID": deployment.ConfigID,
+			"state":    string(deployment.State),
+		}
+		return label.Matches(labels.Set(deployment.Labels)) && field.Matches(fields)
+	})
+}
+
+// Delete asynchronously deletes the Deployment specified by its id. It implements
+// apiserver.RESTStorage and is equivalent to calling DeleteWithOptions with nil options.
 func (s *REST) Delete(ctx kubeapi.Context, id string) (<-chan runtime.Object, error) {
+	return s.DeleteWithOptions(ctx, id, nil)
+}
+
+// DeleteWithOptions deletes the Deployment specified by its id. Deployment has no
+// finalizer-based dependent-cleanup mechanism the way Build does, so options.Cascade
+// currently has no observable effect here; the option is accepted for interface parity
+// with the other REST storages, and a future deployment pod cleanup path would hang its
+// orphan behavior off it. GracePeriodSeconds is accepted for forward compatibility but has
+// no effect yet; see api.DeleteOptions.
+func (s *REST) DeleteWithOptions(ctx kubeapi.Context, id string, options *originapi.DeleteOptions) (<-chan runtime.Object, error) {
 	return apiserver.MakeAsync(func() (runtime.Object, error) {
 		return &kubeapi.Status{Status: kubeapi.StatusSuccess}, s.registry.DeleteDeployment(id)
 	}), nil
@@ -61,14 +90,23 @@ func (s *REST) Delete(ctx kubeapi.Context, id string) (<-chan runtime.Object, er
 func (s *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
 	deployment, ok := obj.(*deployapi.Deployment)
 	if !ok {
-		return nil, fmt.Errorf("not a deployment: %#v", obj)
+		return nil, apierrors.NewBadRequest("deployment", fmt.Sprintf("not a deployment: %#v", obj))
 	}
 
 	glog.Infof("Creating deployment with ID: %v", deployment.ID)
 
+	if !kubeapi.ValidNamespace(ctx, &deployment.JSONBase) {
+		return nil, kubeerrors.NewConflict("deployment", deployment.Namespace, fmt.Errorf("Deployment.Namespace does not match the provided context"))
+	}
+
 	if len(deployment.ID) == 0 {
-		deployment.ID = uuid.NewUUID().String()
+		if len(deployment.GenerateName) > 0 {
+			deployment.ID = deployment.GenerateName
+		} else {
+			deployment.ID = uuid.NewUUID().String()
+		}
 	}
+	deployment.UID = uuid.NewUUID().String()
 	deployment.State = deployapi.DeploymentNew
 
 	if errs := validation.ValidateDeployment(deployment); len(errs) > 0 {
@@ -76,8 +114,16 @@ func (s *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.O
 	}
 
 	return apiserver.MakeAsync(func() (runtime.Object, error) {
-		err := s.registry.CreateDeployment(deployment)
-		if err != nil {
+		if len(deployment.GenerateName) > 0 {
+			if err := generatename.Retry(deployment.GenerateName, func(name string) error {
+				deployment.ID = name
+				return s.registry.CreateDeployment(deployment)
+			}); err != nil {
+				return nil, err
+			}
+			return deployment, nil
+		}
+		if err := s.registry.CreateDeployment(deployment); err != nil {
 			return nil, err
 		}
 		return deployment, nil
@@ -88,10 +134,13 @@ func (s *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.O
 func (s *REST) Update(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
 	deployment, ok := obj.(*deployapi.Deployment)
 	if !ok {
-		return nil, fmt.Errorf("not a deployment: %#v", obj)
+		return nil, apierrors.NewBadRequest("deployment", fmt.Sprintf("not a deployment: %#v", obj))
 	}
 	if len(deployment.ID) == 0 {
-		return nil, fmt.Errorf("id is unspecified: %#v", deployment)
+		return nil, apierrors.NewBadRequest("deployment", fmt.Sprintf("id is unspecified: %#v", deployment))
+	}
+	if !kubeapi.ValidNamespace(ctx, &deployment.JSONBase) {
+		return nil, kubeerrors.NewConflict("deployment", deployment.Namespace, fmt.Errorf("Deployment.Namespace does not match the provided context"))
 	}
 	return apiserver.MakeAsync(func() (runtime.Object, error) {
 		err := s.registry.UpdateDeployment(deployment)
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
 	"github.com/openshift/origin/pkg/deploy/api"
 	"github.com/openshift/origin/pkg/deploy/registry/test"
@@ -86,7 +87,7 @@ func TestListDeploymentsPopulatedList(t *testing.T) {
 func TestCreateDeploymentBadObject(t *testing.T) {
 	storage := REST{}
 
-	channel, err := storage.Create(nil, &api.DeploymentList{})
+	channel, err := storage.Create(kubeapi.NewDefaultContext(), &api.DeploymentList{})
 	if channel != nil {
 		t.Errorf("Expected nil, got %v", channel)
 	}
@@ -113,7 +114,7 @@ func TestCreateRegistrySaveError(t *testing.T) {
 	mockRegistry.Err = fmt.Errorf("test error")
 	storage := REST{registry: mockRegistry}
 
-	channel, err := storage.Create(nil, &api.Deployment{
+	channel, err := storage.Create(kubeapi.NewDefaultContext(), &api.Deployment{
 		JSONBase: kubeapi.JSONBase{ID: "foo"},
 		Strategy: okStrategy(),
 	})
@@ -143,7 +144,7 @@ func TestCreateDeploymentOK(t *testing.T) {
 	mockRegistry := test.NewDeploymentRegistry()
 	storage := REST{registry: mockRegistry}
 
-	channel, err := storage.Create(nil, &api.Deployment{
+	channel, err := storage.Create(kubeapi.NewDefaultContext(), &api.Deployment{
 		JSONBase: kubeapi.JSONBase{ID: "foo"},
 		Strategy: okStrategy(),
 	})
@@ -169,6 +170,22 @@ func TestCreateDeploymentOK(t *testing.T) {
 	}
 }
 
+func TestCreateDeploymentNamespaceConflict(t *testing.T) {
+	mockRegistry := test.NewDeploymentRegistry()
+	storage := REST{registry: mockRegistry}
+
+	_, err := storage.Create(kubeapi.WithNamespace(kubeapi.NewContext(), "bar"), &api.Deployment{
+		JSONBase: kubeapi.JSONBase{ID: "foo", Namespace: "foo"},
+		Strategy: okStrategy(),
+	})
+	if err == nil {
+		t.Fatal("expected an error, got none")
+	}
+	if !errors.IsConflict(err) {
+		t.Errorf("expected a conflict error, got %v", err)
+	}
+}
+
 func TestGetDeploymentError(t *testing.T) {
 	mockRegistry := test.NewDeploymentRegistry()
 	mockRegistry.Err = fmt.Errorf("bad")
@@ -205,7 +222,7 @@ func TestGetDeploymentOK(t *testing.T) {
 func TestUpdateDeploymentBadObject(t *testing.T) {
 	storage := REST{}
 
-	channel, err := storage.Update(nil, &api.DeploymentConfig{})
+	channel, err := storage.Update(kubeapi.NewDefaultContext(), &api.DeploymentConfig{})
 	if channel != nil {
 		t.Errorf("Expected nil, got %v", channel)
 	}
@@ -217,7 +234,7 @@ func TestUpdateDeploymentBadObject(t *testing.T) {
 func TestUpdateDeploymentMissingID(t *testing.T) {
 	storage := REST{}
 
-	channel, err := storage.Update(nil, &api.Deployment{})
+	channel, err := storage.Update(kubeapi.NewDefaultContext(), &api.Deployment{})
 	if channel != nil {
 		t.Errorf("Expected nil, got %v", channel)
 	}
@@ -231,7 +248,7 @@ func TestUpdateRegistryErrorSaving(t *testing.T) {
 	mockRepositoryRegistry.Err = fmt.Errorf("foo")
 	storage := REST{registry: mockRepositoryRegistry}
 
-	channel, err := storage.Update(nil, &api.Deployment{
+	channel, err := storage.Update(kubeapi.NewDefaultContext(), &api.Deployment{
 		JSONBase: kubeapi.JSONBase{ID: "bar"},
 	})
 	if err != nil {
@@ -251,7 +268,7 @@ func TestUpdateDeploymentOK(t *testing.T) {
 	mockRepositoryRegistry := test.NewDeploymentRegistry()
 	storage := REST{registry: mockRepositoryRegistry}
 
-	channel, err := storage.Update(nil, &api.Deployment{
+	channel, err := storage.Update(kubeapi.NewDefaultContext(), &api.Deployment{
 		JSONBase: kubeapi.JSONBase{ID: "bar"},
 	})
 	if err != nil {
@@ -0,0 +1,57 @@
+package deployconfig
+
+import (
+	"fmt"
+
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/apiserver"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+)
+
+// DeploymentLister is the subset of the deployment registry the history endpoint needs.
+type DeploymentLister interface {
+	ListDeployments(selector labels.Selector) (*deployapi.DeploymentList, error)
+}
+
+// HistoryREST implements a read-only RESTStorage that assembles the ordered deployment history
+// for a DeploymentConfig, identified by its ID, from the Deployments the controller created.
+type HistoryREST struct {
+	deployments DeploymentLister
+}
+
+// NewHistoryREST creates a REST storage backed by deployments.
+func NewHistoryREST(deployments DeploymentLister) apiserver.RESTStorage {
+	return &HistoryREST{deployments: deployments}
+}
+
+func (r *HistoryREST) New() runtime.Object {
+	return &deployapi.DeploymentHistory{}
+}
+
+// Get assembles the history for the DeploymentConfig identified by id.
+func (r *HistoryREST) Get(ctx kubeapi.Context, id string) (runtime.Object, error) {
+	deployments, err := r.deployments.ListDeployments(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	return deployapi.NewDeploymentHistory(id, deployments.Items), nil
+}
+
+func (r *HistoryREST) List(ctx kubeapi.Context, label, field labels.Selector) (runtime.Object, error) {
+	return nil, fmt.Errorf("deploymentConfigHistories does not support list")
+}
+
+func (r *HistoryREST) Delete(ctx kubeapi.Context, id string) (<-chan runtime.Object, error) {
+	return nil, fmt.Errorf("deploymentConfigHistories is read-only")
+}
+
+func (r *HistoryREST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
+	return nil, fmt.Errorf("deploymentConfigHistories is read-only")
+}
+
+func (r *HistoryREST) Update(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
+	return nil, fmt.Errorf("deploymentConfigHistories is read-only")
+}
@@ -9,6 +9,9 @@ import (
 type Registry interface {
 	ListDeploymentConfigs(selector labels.Selector) (*api.DeploymentConfigList, error)
 	GetDeploymentConfig(id string) (*api.DeploymentConfig, error)
+	// GetDeploymentConfigs retrieves the DeploymentConfigs named by ids in a single call, so
+	// callers resolving many references don't have to issue one GetDeploymentConfig per id.
+	GetDeploymentConfigs(ids []string) (*api.DeploymentConfigList, error)
 	CreateDeploymentConfig(deploymentConfig *api.DeploymentConfig) error
 	UpdateDeploymentConfig(deploymentConfig *api.DeploymentConfig) error
 	DeleteDeploymentConfig(id string) error
@@ -5,11 +5,14 @@ import (
 
 	"code.google.com/p/go-uuid/uuid"
 	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	kubeerrors "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/apiserver"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
 
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	"github.com/openshift/origin/pkg/deploy/api/validation"
+	"github.com/openshift/origin/pkg/util/apierrors"
 )
 
 // REST is an implementation of RESTStorage for the api server.
@@ -58,12 +61,19 @@ func (s *REST) Delete(ctx kubeapi.Context, id string) (<-chan runtime.Object, er
 func (s *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
 	deploymentConfig, ok := obj.(*deployapi.DeploymentConfig)
 	if !ok {
-		return nil, fmt.Errorf("not a deploymentConfig: %#v", obj)
+		return nil, apierrors.NewBadRequest("deploymentConfig", fmt.Sprintf("not a deploymentConfig: %#v", obj))
+	}
+	if !kubeapi.ValidNamespace(ctx, &deploymentConfig.JSONBase) {
+		return nil, kubeerrors.NewConflict("deploymentConfig", deploymentConfig.Namespace, fmt.Errorf("DeploymentConfig.Namespace does not match the provided context"))
 	}
 	if len(deploymentConfig.ID) == 0 {
 		deploymentConfig.ID = uuid.NewUUID().String()
 	}
 
+	if errs := s.validateSelectorConflict(deploymentConfig); len(errs) > 0 {
+		return nil, kubeerrors.NewInvalid("deploymentConfig", deploymentConfig.ID, errs)
+	}
+
 	//TODO: Add validation
 
 	return apiserver.MakeAsync(func() (runtime.Object, error) {
@@ -79,11 +89,22 @@ func (s *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.O
 func (s *REST) Update(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
 	deploymentConfig, ok := obj.(*deployapi.DeploymentConfig)
 	if !ok {
-		return nil, fmt.Errorf("not a deploymentConfig: %#v", obj)
+		return nil, apierrors.NewBadRequest("deploymentConfig", fmt.Sprintf("not a deploymentConfig: %#v", obj))
 	}
 	if len(deploymentConfig.ID) == 0 {
-		return nil, fmt.Errorf("id is unspecified: %#v", deploymentConfig)
+		return nil, apierrors.NewBadRequest("deploymentConfig", fmt.Sprintf("id is unspecified: %#v", deploymentConfig))
 	}
+	if !kubeapi.ValidNamespace(ctx, &deploymentConfig.JSONBase) {
+		return nil, kubeerrors.NewConflict("deploymentConfig", deploymentConfig.Namespace, fmt.Errorf("DeploymentConfig.Namespace does not match the provided context"))
+	}
+	if old, err := s.registry.GetDeploymentConfig(deploymentConfig.ID); err == nil {
+		deployapi.ReconcileReplicas(old, deploymentConfig)
+	}
+
+	if errs := s.validateSelectorConflict(deploymentConfig); len(errs) > 0 {
+		return nil, kubeerrors.NewInvalid("deploymentConfig", deploymentConfig.ID, errs)
+	}
+
 	return apiserver.MakeAsync(func() (runtime.Object, error) {
 		err := s.registry.UpdateDeploymentConfig(deploymentConfig)
 		if err != nil {
@@ -92,3 +113,13 @@ func (s *REST) Update(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.O
 		return deploymentConfig, nil
 	}), nil
 }
+
+// validateSelectorConflict checks deploymentConfig's replica selector against every other
+// DeploymentConfig currently in the registry.
+func (s *REST) validateSelectorConflict(deploymentConfig *deployapi.DeploymentConfig) kubeerrors.ErrorList {
+	existing, err := s.registry.ListDeploymentConfigs(labels.Everything())
+	if err != nil || existing == nil {
+		return kubeerrors.ErrorList{}
+	}
+	return validation.ValidateDeploymentConfigSelectorConflict(deploymentConfig, existing.Items)
+}
@@ -0,0 +1,63 @@
+package deployconfig
+
+import (
+	"fmt"
+
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/apiserver"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	"github.com/openshift/origin/pkg/util/apierrors"
+)
+
+// DiffREST implements a RESTStorage that computes the field-level difference between a
+// caller-submitted DeploymentConfig and the version currently stored under the same ID, so
+// apply-style tooling can show a user exactly what an update would change before submitting it.
+type DiffREST struct {
+	registry Registry
+}
+
+// NewDiffREST creates a REST storage backed by registry.
+func NewDiffREST(registry Registry) apiserver.RESTStorage {
+	return &DiffREST{registry: registry}
+}
+
+func (r *DiffREST) New() runtime.Object {
+	return &deployapi.DeploymentConfigDiff{}
+}
+
+// Create diffs the submitted DeploymentConfig against the version currently stored for its ID.
+func (r *DiffREST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
+	proposed, ok := obj.(*deployapi.DeploymentConfig)
+	if !ok {
+		return nil, apierrors.NewBadRequest("deploymentConfig", fmt.Sprintf("not a deploymentConfig: %#v", obj))
+	}
+	if len(proposed.ID) == 0 {
+		return nil, apierrors.NewBadRequest("deploymentConfig", fmt.Sprintf("id is unspecified: %#v", proposed))
+	}
+	stored, err := r.registry.GetDeploymentConfig(proposed.ID)
+	if err != nil {
+		return nil, err
+	}
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		return deployapi.NewDeploymentConfigDiff(stored, proposed), nil
+	}), nil
+}
+
+func (r *DiffREST) List(ctx kubeapi.Context, label, field labels.Selector) (runtime.Object, error) {
+	return nil, apierrors.NewBadRequest("deploymentConfigDiff", "deploymentConfigDiffs does not support list")
+}
+
+func (r *DiffREST) Get(ctx kubeapi.Context, id string) (runtime.Object, error) {
+	return nil, apierrors.NewBadRequest("deploymentConfigDiff", "deploymentConfigDiffs does not support get")
+}
+
+func (r *DiffREST) Delete(ctx kubeapi.Context, id string) (<-chan runtime.Object, error) {
+	return nil, apierrors.NewBadRequest("deploymentConfigDiff", "deploymentConfigDiffs is read-only")
+}
+
+func (r *DiffREST) Update(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
+	return nil, apierrors.NewBadRequest("deploymentConfigDiff", "deploymentConfigDiffs is read-only")
+}
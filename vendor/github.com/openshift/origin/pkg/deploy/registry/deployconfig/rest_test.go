@@ -7,6 +7,7 @@ import (
 	"time"
 
 	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
 	"github.com/openshift/origin/pkg/deploy/api"
 	"github.com/openshift/origin/pkg/deploy/registry/test"
@@ -86,7 +87,7 @@ func TestListDeploymentConfigsPopulatedList(t *testing.T) {
 func TestCreateDeploymentConfigBadObject(t *testing.T) {
 	storage := REST{}
 
-	channel, err := storage.Create(nil, &api.DeploymentList{})
+	channel, err := storage.Create(kubeapi.NewDefaultContext(), &api.DeploymentList{})
 	if channel != nil {
 		t.Errorf("Expected nil, got %v", channel)
 	}
@@ -100,7 +101,7 @@ func TestCreateRegistrySaveError(t *testing.T) {
 	mockRegistry.Err = fmt.Errorf("test error")
 	storage := REST{registry: mockRegistry}
 
-	channel, err := storage.Create(nil, &api.DeploymentConfig{
+	channel, err := storage.Create(kubeapi.NewDefaultContext(), &api.DeploymentConfig{
 		JSONBase: kubeapi.JSONBase{ID: "foo"},
 	})
 	if channel == nil {
@@ -129,7 +130,7 @@ func TestCreateDeploymentConfigOK(t *testing.T) {
 	mockRegistry := test.NewDeploymentConfigRegistry()
 	storage := REST{registry: mockRegistry}
 
-	channel, err := storage.Create(nil, &api.DeploymentConfig{
+	channel, err := storage.Create(kubeapi.NewDefaultContext(), &api.DeploymentConfig{
 		JSONBase: kubeapi.JSONBase{ID: "foo"},
 	})
 	if channel == nil {
@@ -154,6 +155,21 @@ func TestCreateDeploymentConfigOK(t *testing.T) {
 	}
 }
 
+func TestCreateDeploymentConfigNamespaceConflict(t *testing.T) {
+	mockRegistry := test.NewDeploymentConfigRegistry()
+	storage := REST{registry: mockRegistry}
+
+	_, err := storage.Create(kubeapi.WithNamespace(kubeapi.NewContext(), "bar"), &api.DeploymentConfig{
+		JSONBase: kubeapi.JSONBase{ID: "foo", Namespace: "foo"},
+	})
+	if err == nil {
+		t.Fatal("expected an error, got none")
+	}
+	if !errors.IsConflict(err) {
+		t.Errorf("expected a conflict error, got %v", err)
+	}
+}
+
 func TestGetDeploymentConfigError(t *testing.T) {
 	mockRegistry := test.NewDeploymentConfigRegistry()
 	mockRegistry.Err = fmt.Errorf("bad")
@@ -190,7 +206,7 @@ func TestGetDeploymentConfigOK(t *testing.T) {
 func TestUpdateDeploymentConfigBadObject(t *testing.T) {
 	storage := REST{}
 
-	channel, err := storage.Update(nil, &api.DeploymentList{})
+	channel, err := storage.Update(kubeapi.NewDefaultContext(), &api.DeploymentList{})
 	if channel != nil {
 		t.Errorf("Expected nil, got %v", channel)
 	}
@@ -202,7 +218,7 @@ func TestUpdateDeploymentConfigBadObject(t *testing.T) {
 func TestUpdateDeploymentConfigMissingID(t *testing.T) {
 	storage := REST{}
 
-	channel, err := storage.Update(nil, &api.DeploymentConfig{})
+	channel, err := storage.Update(kubeapi.NewDefaultContext(), &api.DeploymentConfig{})
 	if channel != nil {
 		t.Errorf("Expected nil, got %v", channel)
 	}
@@ -216,7 +232,7 @@ func TestUpdateRegistryErrorSaving(t *testing.T) {
 	mockRepositoryRegistry.Err = fmt.Errorf("foo")
 	storage := REST{registry: mockRepositoryRegistry}
 
-	channel, err := storage.Update(nil, &api.DeploymentConfig{
+	channel, err := storage.Update(kubeapi.NewDefaultContext(), &api.DeploymentConfig{
 		JSONBase: kubeapi.JSONBase{ID: "bar"},
 	})
 	if err != nil {
@@ -236,7 +252,7 @@ func TestUpdateDeploymentConfigOK(t *testing.T) {
 	mockRepositoryRegistry := test.NewDeploymentConfigRegistry()
 	storage := REST{registry: mockRepositoryRegistry}
 
-	channel, err := storage.Update(nil, &api.DeploymentConfig{
+	channel, err := storage.Update(kubeapi.NewDefaultContext(), &api.DeploymentConfig{
 		JSONBase: kubeapi.JSONBase{ID: "bar"},
 	})
 	if err != nil {
@@ -252,6 +268,48 @@ func TestUpdateDeploymentConfigOK(t *testing.T) {
 	}
 }
 
+func TestDiffDeploymentConfigOK(t *testing.T) {
+	mockRegistry := test.NewDeploymentConfigRegistry()
+	mockRegistry.DeploymentConfig = &api.DeploymentConfig{
+		JSONBase: kubeapi.JSONBase{ID: "foo"},
+		Labels:   map[string]string{"env": "dev"},
+	}
+	storage := DiffREST{registry: mockRegistry}
+
+	channel, err := storage.Create(kubeapi.NewDefaultContext(), &api.DeploymentConfig{
+		JSONBase: kubeapi.JSONBase{ID: "foo"},
+		Labels:   map[string]string{"env": "prod"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected non-nil error: %#v", err)
+	}
+
+	select {
+	case result := <-channel:
+		diff, ok := result.(*api.DeploymentConfigDiff)
+		if !ok {
+			t.Fatalf("Expected DeploymentConfigDiff, got: %#v", result)
+		}
+		if len(diff.Changes) != 1 {
+			t.Fatalf("Expected exactly one changed field, got: %#v", diff.Changes)
+		}
+		if e, a := "labels", diff.Changes[0].Field; e != a {
+			t.Errorf("Expected field %s, got %s", e, a)
+		}
+	case <-time.After(50 * time.Millisecond):
+		t.Errorf("Timed out waiting for result")
+	}
+}
+
+func TestDiffDeploymentConfigMissingID(t *testing.T) {
+	storage := DiffREST{}
+
+	_, err := storage.Create(kubeapi.NewDefaultContext(), &api.DeploymentConfig{})
+	if err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
+
 func TestDeleteDeploymentConfig(t *testing.T) {
 	mockRegistry := test.NewDeploymentConfigRegistry()
 	storage := REST{registry: mockRegistry}
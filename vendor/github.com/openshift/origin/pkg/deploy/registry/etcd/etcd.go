@@ -3,7 +3,10 @@ package etcd
 import (
 	etcderr "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors/etcd"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+	"github.com/golang/glog"
 
 	"github.com/openshift/origin/pkg/deploy/api"
 )
@@ -38,6 +41,18 @@ func (r *Etcd) ListDeployments(selector labels.Selector) (*api.DeploymentList, e
 	return &deployments, err
 }
 
+// WatchDeployments begins watching for new, changed, or deleted Deployments.
+func (r *Etcd) WatchDeployments(resourceVersion uint64, filter func(deployment *api.Deployment) bool) (watch.Interface, error) {
+	return r.WatchList("/deployments", resourceVersion, func(obj runtime.Object) bool {
+		deployment, ok := obj.(*api.Deployment)
+		if !ok {
+			glog.Errorf("Unexpected object during deployment watch: %#v", obj)
+			return false
+		}
+		return filter(deployment)
+	})
+}
+
 func makeDeploymentKey(id string) string {
 	return "/deployments/" + id
 }
@@ -105,6 +120,19 @@ func (r *Etcd) GetDeploymentConfig(id string) (*api.DeploymentConfig, error) {
 	return &deploymentConfig, nil
 }
 
+// GetDeploymentConfigs gets the DeploymentConfigs specified by their IDs.
+func (r *Etcd) GetDeploymentConfigs(ids []string) (*api.DeploymentConfigList, error) {
+	deploymentConfigs := api.DeploymentConfigList{Items: make([]api.DeploymentConfig, 0, len(ids))}
+	for _, id := range ids {
+		deploymentConfig, err := r.GetDeploymentConfig(id)
+		if err != nil {
+			return nil, err
+		}
+		deploymentConfigs.Items = append(deploymentConfigs.Items, *deploymentConfig)
+	}
+	return &deploymentConfigs, nil
+}
+
 // CreateDeploymentConfig creates a new DeploymentConfig.
 func (r *Etcd) CreateDeploymentConfig(deploymentConfig *api.DeploymentConfig) error {
 	err := r.CreateObj(makeDeploymentConfigKey(deploymentConfig.ID), deploymentConfig, 0)
@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
 	"github.com/openshift/origin/pkg/deploy/api"
 )
 
@@ -32,6 +33,10 @@ func (r *DeploymentRegistry) GetDeployment(id string) (*api.Deployment, error) {
 	return r.Deployment, r.Err
 }
 
+func (r *DeploymentRegistry) WatchDeployments(resourceVersion uint64, filter func(deployment *api.Deployment) bool) (watch.Interface, error) {
+	return nil, r.Err
+}
+
 func (r *DeploymentRegistry) CreateDeployment(deployment *api.Deployment) error {
 	r.Lock()
 	defer r.Unlock()
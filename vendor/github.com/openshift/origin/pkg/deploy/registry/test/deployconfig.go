@@ -32,6 +32,13 @@ func (r *DeploymentConfigRegistry) GetDeploymentConfig(id string) (*api.Deployme
 	return r.DeploymentConfig, r.Err
 }
 
+func (r *DeploymentConfigRegistry) GetDeploymentConfigs(ids []string) (*api.DeploymentConfigList, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.DeploymentConfigs, r.Err
+}
+
 func (r *DeploymentConfigRegistry) CreateDeploymentConfig(image *api.DeploymentConfig) error {
 	r.Lock()
 	defer r.Unlock()
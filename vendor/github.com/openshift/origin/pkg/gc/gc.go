@@ -0,0 +1,215 @@
+// Package gc periodically reaps pods whose owning Build or Deployment has been deleted,
+// and finalizes Builds that are pending deletion once their pod is confirmed gone.
+// Before this, only the deploy controller cleaned up after itself, and only for the pod it
+// created moving through its own state machine; if a Build or Deployment was deleted
+// directly (e.g. via the CLI) while its pod was still running, nothing ever removed it.
+// Owner labels are stamped on generated pods by the build strategies and the deploy
+// controller; see pkg/util/ownerref. The owner check compares UID as well as ID, so a pod
+// left behind by a deleted Build or Deployment isn't kept alive by an unrelated object that
+// was later created reusing the same ID.
+package gc
+
+import (
+	"time"
+
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	kubeclient "github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+
+	"github.com/golang/glog"
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	osclient "github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/util/ownerref"
+)
+
+// ownerExists reports whether the owning resource named id still exists and still has the
+// given uid, so a pod left behind by a deleted owner isn't mistaken for one belonging to an
+// unrelated object that was later created with the same id.
+type ownerExists func(ctx kapi.Context, osClient osclient.Interface, id, uid string) (bool, error)
+
+// ownerCheckers maps an owner.kind label value to the check for that kind, mirroring the
+// kinds stamped by pkg/build/strategy and pkg/deploy's controller.
+var ownerCheckers = map[string]ownerExists{
+	"Build":      buildExists,
+	"Deployment": deploymentExists,
+}
+
+func buildExists(ctx kapi.Context, osClient osclient.Interface, id, uid string) (bool, error) {
+	build, err := osClient.GetBuild(ctx, id)
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return build.UID == uid, nil
+}
+
+func deploymentExists(ctx kapi.Context, osClient osclient.Interface, id, uid string) (bool, error) {
+	deployment, err := osClient.GetDeployment(ctx, id)
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return deployment.UID == uid, nil
+}
+
+// Controller periodically deletes pods whose owner label names a Build or Deployment that
+// no longer exists, and pods belonging to Builds that finished more than podRetentionPeriod
+// ago. If keepFailedPods is true, a Failed or Error build's pod is left alone regardless of
+// age, so its logs stay available for debugging.
+type Controller struct {
+	kubeClient         kubeclient.Interface
+	osClient           osclient.Interface
+	podRetentionPeriod time.Duration
+	keepFailedPods     bool
+}
+
+// NewController creates a new garbage collection Controller. podRetentionPeriod is how long
+// a completed build's pod is kept around after the build finishes before being swept; if
+// keepFailedPods is true, pods belonging to Failed or Error builds are never swept.
+func NewController(kubeClient kubeclient.Interface, osClient osclient.Interface, podRetentionPeriod time.Duration, keepFailedPods bool) *Controller {
+	return &Controller{
+		kubeClient:         kubeClient,
+		osClient:           osClient,
+		podRetentionPeriod: podRetentionPeriod,
+		keepFailedPods:     keepFailedPods,
+	}
+}
+
+// Run begins periodically sweeping for orphaned pods, every period, until the process exits.
+func (c *Controller) Run(period time.Duration) {
+	ctx := kapi.NewContext()
+	go util.Forever(func() { c.sweep(ctx) }, period)
+}
+
+func (c *Controller) sweep(ctx kapi.Context) {
+	c.sweepOrphanedPods(ctx)
+	c.sweepTerminatingBuilds(ctx)
+	c.sweepCompletedBuildPods(ctx)
+}
+
+// sweepOrphanedPods deletes pods whose owner label names a Build or Deployment that no
+// longer exists at all.
+func (c *Controller) sweepOrphanedPods(ctx kapi.Context) {
+	pods, err := c.kubeClient.ListPods(ctx, labels.Everything())
+	if err != nil {
+		glog.Errorf("Error listing pods for garbage collection: %v", err)
+		return
+	}
+
+	for _, pod := range pods.Items {
+		kind, id, uid, ok := ownerref.Get(pod.Labels)
+		if !ok {
+			continue
+		}
+		checker, ok := ownerCheckers[kind]
+		if !ok {
+			continue
+		}
+
+		exists, err := checker(ctx, c.osClient, id, uid)
+		if err != nil {
+			glog.Errorf("Error checking whether %s %s still exists for pod %s: %v", kind, id, pod.ID, err)
+			continue
+		}
+		if exists {
+			continue
+		}
+
+		glog.Infof("Deleting pod %s: owning %s %s no longer exists", pod.ID, kind, id)
+		if err := c.kubeClient.DeletePod(ctx, pod.ID); err != nil {
+			glog.Errorf("Error deleting orphaned pod %s: %v", pod.ID, err)
+		}
+	}
+}
+
+// sweepTerminatingBuilds clears the pod-cleanup finalizer on Builds that are pending
+// deletion once their pod has actually been removed, letting the delete complete.
+func (c *Controller) sweepTerminatingBuilds(ctx kapi.Context) {
+	builds, err := c.osClient.ListBuilds(ctx, labels.Everything())
+	if err != nil {
+		glog.Errorf("Error listing builds for garbage collection: %v", err)
+		return
+	}
+
+	for i := range builds.Items {
+		build := &builds.Items[i]
+		if build.DeletionTimestamp.IsZero() || !hasFinalizer(build, buildapi.PodCleanupFinalizer) {
+			continue
+		}
+		if podExists(ctx, c.kubeClient, build.PodID) {
+			continue
+		}
+
+		glog.Infof("Clearing pod-cleanup finalizer on build %s: pod %s is gone", build.ID, build.PodID)
+		build.Finalizers = removeFinalizer(build.Finalizers, buildapi.PodCleanupFinalizer)
+		if _, err := c.osClient.FinalizeBuild(ctx, build); err != nil {
+			glog.Errorf("Error finalizing build %s: %v", build.ID, err)
+		}
+	}
+}
+
+// sweepCompletedBuildPods deletes the pod for any Build that reached a terminal status more
+// than c.podRetentionPeriod ago, so a busy project doesn't accumulate a pod per historical
+// build forever. A Failed or Error build's pod is skipped when c.keepFailedPods is set, since
+// its logs are often the only record of why the build failed.
+func (c *Controller) sweepCompletedBuildPods(ctx kapi.Context) {
+	builds, err := c.osClient.ListBuilds(ctx, labels.Everything())
+	if err != nil {
+		glog.Errorf("Error listing builds for pod cleanup: %v", err)
+		return
+	}
+
+	for i := range builds.Items {
+		build := &builds.Items[i]
+		if build.CompletionTimestamp == nil || len(build.PodID) == 0 {
+			continue
+		}
+		if c.keepFailedPods && (build.Status == buildapi.BuildFailed || build.Status == buildapi.BuildError) {
+			continue
+		}
+		if time.Since(build.CompletionTimestamp.Time) < c.podRetentionPeriod {
+			continue
+		}
+		if !podExists(ctx, c.kubeClient, build.PodID) {
+			continue
+		}
+
+		glog.Infof("Deleting pod %s: build %s completed more than %s ago", build.PodID, build.ID, c.podRetentionPeriod)
+		if err := c.kubeClient.DeletePod(ctx, build.PodID); err != nil {
+			glog.Errorf("Error deleting pod %s for completed build %s: %v", build.PodID, build.ID, err)
+		}
+	}
+}
+
+func podExists(ctx kapi.Context, kubeClient kubeclient.Interface, id string) bool {
+	if len(id) == 0 {
+		return false
+	}
+	_, err := kubeClient.GetPod(ctx, id)
+	return err == nil
+}
+
+func hasFinalizer(build *buildapi.Build, name string) bool {
+	for _, f := range build.Finalizers {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+func removeFinalizer(finalizers []string, name string) []string {
+	kept := make([]string, 0, len(finalizers))
+	for _, f := range finalizers {
+		if f != name {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
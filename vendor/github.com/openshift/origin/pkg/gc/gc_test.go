@@ -0,0 +1,127 @@
+package gc
+
+import (
+	"testing"
+	"time"
+
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	kubeclient "github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	osclient "github.com/openshift/origin/pkg/client"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	"github.com/openshift/origin/pkg/util/ownerref"
+)
+
+// fakeOsClient reports the current UID for every Build and Deployment ID in existing, and
+// every other ID as deleted. ListBuilds returns builds, for tests that exercise the
+// completed-build-pod sweep.
+type fakeOsClient struct {
+	osclient.Fake
+	existing map[string]string
+	builds   []buildapi.Build
+}
+
+func (c *fakeOsClient) GetBuild(ctx kapi.Context, id string) (*buildapi.Build, error) {
+	if uid, ok := c.existing[id]; ok {
+		return &buildapi.Build{UID: uid}, nil
+	}
+	return nil, errors.NewNotFound("build", id)
+}
+
+func (c *fakeOsClient) GetDeployment(ctx kapi.Context, id string) (*deployapi.Deployment, error) {
+	if uid, ok := c.existing[id]; ok {
+		return &deployapi.Deployment{UID: uid}, nil
+	}
+	return nil, errors.NewNotFound("deployment", id)
+}
+
+func (c *fakeOsClient) ListBuilds(ctx kapi.Context, selector labels.Selector) (*buildapi.BuildList, error) {
+	return &buildapi.BuildList{Items: c.builds}, nil
+}
+
+func TestSweepDeletesOrphans(t *testing.T) {
+	kube := &kubeclient.Fake{
+		Pods: kapi.PodList{
+			Items: []kapi.Pod{
+				{JSONBase: kapi.JSONBase{ID: "build-pod"}, Labels: ownerref.Set(nil, "Build", "live-build", "live-build-uid")},
+				{JSONBase: kapi.JSONBase{ID: "orphan-build-pod"}, Labels: ownerref.Set(nil, "Build", "gone-build", "gone-build-uid")},
+				{JSONBase: kapi.JSONBase{ID: "deploy-pod"}, Labels: ownerref.Set(nil, "Deployment", "gone-deployment", "gone-deployment-uid")},
+				{JSONBase: kapi.JSONBase{ID: "stale-build-pod"}, Labels: ownerref.Set(nil, "Build", "reused-build", "old-build-uid")},
+				{JSONBase: kapi.JSONBase{ID: "unrelated-pod"}},
+			},
+		},
+	}
+	os := &fakeOsClient{existing: map[string]string{
+		"live-build":   "live-build-uid",
+		"reused-build": "new-build-uid", // a different Build now owns this id
+	}}
+
+	c := NewController(kube, os, time.Hour, false)
+	c.sweep(kapi.NewContext())
+
+	deleted := map[string]bool{}
+	for _, action := range kube.Actions {
+		if action.Action == "delete-pod" {
+			deleted[action.Value.(string)] = true
+		}
+	}
+
+	if !deleted["orphan-build-pod"] {
+		t.Errorf("expected orphan-build-pod to be deleted")
+	}
+	if !deleted["deploy-pod"] {
+		t.Errorf("expected deploy-pod to be deleted")
+	}
+	if !deleted["stale-build-pod"] {
+		t.Errorf("expected stale-build-pod, whose owner id was reused by a different build, to be deleted")
+	}
+	if deleted["build-pod"] {
+		t.Errorf("expected build-pod, whose build still exists with a matching uid, not to be deleted")
+	}
+	if deleted["unrelated-pod"] {
+		t.Errorf("expected unrelated-pod, which has no owner label, not to be deleted")
+	}
+}
+
+func TestSweepCompletedBuildPods(t *testing.T) {
+	old := util.Time{Time: time.Now().Add(-2 * time.Hour)}
+	recent := util.Time{Time: time.Now().Add(-time.Minute)}
+
+	kube := &kubeclient.Fake{}
+	os := &fakeOsClient{
+		existing: map[string]string{},
+		builds: []buildapi.Build{
+			{JSONBase: kapi.JSONBase{ID: "old-complete"}, Status: buildapi.BuildComplete, PodID: "old-complete-pod", CompletionTimestamp: &old},
+			{JSONBase: kapi.JSONBase{ID: "old-failed"}, Status: buildapi.BuildFailed, PodID: "old-failed-pod", CompletionTimestamp: &old},
+			{JSONBase: kapi.JSONBase{ID: "recent-complete"}, Status: buildapi.BuildComplete, PodID: "recent-complete-pod", CompletionTimestamp: &recent},
+			{JSONBase: kapi.JSONBase{ID: "still-running"}, Status: buildapi.BuildRunning, PodID: "still-running-pod"},
+		},
+	}
+
+	c := NewController(kube, os, time.Hour, true)
+	c.sweep(kapi.NewContext())
+
+	deleted := map[string]bool{}
+	for _, action := range kube.Actions {
+		if action.Action == "delete-pod" {
+			deleted[action.Value.(string)] = true
+		}
+	}
+
+	if !deleted["old-complete-pod"] {
+		t.Errorf("expected old-complete-pod, whose build completed over the retention period ago, to be deleted")
+	}
+	if deleted["old-failed-pod"] {
+		t.Errorf("expected old-failed-pod not to be deleted: keepFailedPods is set")
+	}
+	if deleted["recent-complete-pod"] {
+		t.Errorf("expected recent-complete-pod, which is within the retention period, not to be deleted")
+	}
+	if deleted["still-running-pod"] {
+		t.Errorf("expected still-running-pod, whose build hasn't completed, not to be deleted")
+	}
+}
@@ -0,0 +1,34 @@
+package api
+
+import "strings"
+
+// RegistryHostname returns the registry host:port portion of a Docker image reference such
+// as "quay.io/openshift/origin:latest" or "mysql:5.6". References with no registry segment
+// (the common "library/name" or "name" form) are assumed to come from the default Docker
+// registry, "docker.io".
+func RegistryHostname(imageRef string) string {
+	firstSlash := strings.Index(imageRef, "/")
+	if firstSlash == -1 {
+		return "docker.io"
+	}
+	candidate := imageRef[:firstSlash]
+	if strings.ContainsAny(candidate, ".:") || candidate == "localhost" {
+		return candidate
+	}
+	return "docker.io"
+}
+
+// RegistryAllowed reports whether imageRef's registry appears in allowed. An empty allowed
+// list permits any registry.
+func RegistryAllowed(imageRef string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	host := RegistryHostname(imageRef)
+	for _, registry := range allowed {
+		if registry == host {
+			return true
+		}
+	}
+	return false
+}
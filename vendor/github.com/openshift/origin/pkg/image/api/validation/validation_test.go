@@ -6,6 +6,7 @@ import (
 	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
 	"github.com/openshift/origin/pkg/image/api"
+	validationutil "github.com/openshift/origin/pkg/util/validation"
 )
 
 func TestValidateImageOK(t *testing.T) {
@@ -30,18 +31,7 @@ func TestValidateImageMissingFields(t *testing.T) {
 
 	for k, v := range errorCases {
 		errs := ValidateImage(&v.I)
-		if len(errs) == 0 {
-			t.Errorf("Expected failure for %s", k)
-			continue
-		}
-		for i := range errs {
-			if errs[i].(errors.ValidationError).Type != v.T {
-				t.Errorf("%s: expected errors to have type %s: %v", k, v.T, errs[i])
-			}
-			if errs[i].(errors.ValidationError).Field != v.F {
-				t.Errorf("%s: expected errors to have field %s: %v", k, v.F, errs[i])
-			}
-		}
+		validationutil.ExpectInvalid(t, k, errs, v.T, v.F)
 	}
 }
 
@@ -79,7 +69,7 @@ func TestValidateImageRepositoryMappingNotOK(t *testing.T) {
 		},
 		"missing image attributes": {
 			api.ImageRepositoryMapping{
-				Tag: "latest",
+				Tag:                   "latest",
 				DockerImageRepository: "openshift/ruby-19-centos",
 				Image: api.Image{
 					DockerImageReference: "openshift/ruby-19-centos",
@@ -92,17 +82,6 @@ func TestValidateImageRepositoryMappingNotOK(t *testing.T) {
 
 	for k, v := range errorCases {
 		errs := ValidateImageRepositoryMapping(&v.I)
-		if len(errs) == 0 {
-			t.Errorf("Expected failure for %s", k)
-			continue
-		}
-		for i := range errs {
-			if errs[i].(errors.ValidationError).Type != v.T {
-				t.Errorf("%s: expected errors to have type %s: %v", k, v.T, errs[i])
-			}
-			if errs[i].(errors.ValidationError).Field != v.F {
-				t.Errorf("%s: expected errors to have field %s: %v", k, v.F, errs[i])
-			}
-		}
+		validationutil.ExpectInvalid(t, k, errs, v.T, v.F)
 	}
 }
@@ -431,7 +431,7 @@ func TestEtcdCreateImageRepository(t *testing.T) {
 		},
 		Labels:                map[string]string{"a": "b"},
 		DockerImageRepository: "c/d",
-		Tags: map[string]string{"t1": "v1"},
+		Tags:                  map[string]string{"t1": "v1"},
 	})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -52,7 +52,7 @@ func (s *REST) Get(ctx kubeapi.Context, id string) (runtime.Object, error) {
 func (s *REST) Watch(ctx kubeapi.Context, label, field labels.Selector, resourceVersion uint64) (watch.Interface, error) {
 	return s.registry.WatchImageRepositories(resourceVersion, func(repo *api.ImageRepository) bool {
 		fields := labels.Set{
-			"ID": repo.ID,
+			"ID":                    repo.ID,
 			"DockerImageRepository": repo.DockerImageRepository,
 		}
 		return label.Matches(labels.Set(repo.Labels)) && field.Matches(fields)
@@ -0,0 +1,55 @@
+package limits
+
+import (
+	"sync"
+)
+
+// Limits defines size and count ceilings enforced by validators, keeping a single
+// object from growing large enough to threaten etcd, which enforces no such limits
+// of its own. For now these are global; eventually they may be scoped per namespace
+// or user.
+type Limits struct {
+	// MaxLabelsSize is the maximum total size, in bytes, of an object's label keys and
+	// values combined. This API version has no separate annotations field, so this
+	// limit also stands in for an annotations-size guard until one exists.
+	MaxLabelsSize int
+
+	// MaxEnvVarsPerContainer is the maximum number of environment variables a single
+	// container may declare.
+	MaxEnvVarsPerContainer int
+
+	// MaxContainersPerPod is the maximum number of containers a single pod manifest may
+	// declare.
+	MaxContainersPerPod int
+
+	// MaxTemplateItems is the maximum number of objects a single Template may contain.
+	MaxTemplateItems int
+}
+
+var once sync.Once
+var limits *Limits
+
+// Initialize the limit set. This can only be done once per binary, subsequent calls are ignored.
+func Initialize(l Limits) {
+	once.Do(func() {
+		limits = &l
+	})
+}
+
+// SetForTests. Convenience method for testing. This should only be called from tests.
+func SetForTests(l Limits) {
+	limits = &l
+}
+
+// Get returns a read-only copy of the configured limits.
+func Get() Limits {
+	if limits == nil {
+		Initialize(Limits{
+			MaxLabelsSize:          256 * 1024,
+			MaxEnvVarsPerContainer: 100,
+			MaxContainersPerPod:    100,
+			MaxTemplateItems:       1000,
+		})
+	}
+	return *limits
+}
@@ -0,0 +1,75 @@
+// Package migrate implements a storage migration utility that reads every origin object
+// stored under a set of etcd key prefixes, decodes it into its current internal version, and
+// rewrites it. Decoding and re-encoding always go through an EtcdHelper's Codec, so running
+// this over the whole registry brings every stored object up to date with the latest
+// registered version, even one written by an older release. This must be run (and succeed)
+// before an incompatible change to a stored type ships, so no reader is left holding data it
+// can no longer decode.
+package migrate
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
+)
+
+// Resource identifies a class of objects stored under a single etcd key prefix, all of which
+// decode into the same api object type.
+type Resource struct {
+	// Name labels failures reported for this resource, e.g. "build" or "deployment".
+	Name string
+	// Prefix is the etcd key prefix objects of this resource are stored under, e.g.
+	// "/registry/builds".
+	Prefix string
+	// NewObject returns a new, empty instance of the type stored under Prefix.
+	NewObject func() runtime.Object
+}
+
+// Failure records a single object that could not be migrated.
+type Failure struct {
+	Resource string
+	Key      string
+	Err      error
+}
+
+// Error implements error so a Failure can be returned or logged like any other error.
+func (f Failure) Error() string {
+	return fmt.Sprintf("%s %s: %v", f.Resource, f.Key, f.Err)
+}
+
+// Migrate walks each Resource's key prefix in helper, decoding and rewriting every object it
+// finds. An object that fails to decode or rewrite is recorded in the returned failures and
+// skipped; it does not stop the migration of the remaining objects.
+func Migrate(helper tools.EtcdHelper, resources []Resource) []Failure {
+	var failures []Failure
+	for _, resource := range resources {
+		response, err := helper.Client.Get(resource.Prefix, false, true)
+		if err != nil {
+			if tools.IsEtcdNotFound(err) {
+				continue
+			}
+			failures = append(failures, Failure{Resource: resource.Name, Key: resource.Prefix, Err: err})
+			continue
+		}
+		if response.Node == nil {
+			continue
+		}
+		for _, node := range response.Node.Nodes {
+			obj := resource.NewObject()
+			if err := helper.Codec.DecodeInto([]byte(node.Value), obj); err != nil {
+				failures = append(failures, Failure{Resource: resource.Name, Key: node.Key, Err: err})
+				continue
+			}
+			if helper.ResourceVersioner != nil {
+				// Being unable to set the version does not prevent the object from being
+				// migrated; SetObj will just fall back to an unconditional write.
+				_ = helper.ResourceVersioner.SetResourceVersion(obj, node.ModifiedIndex)
+			}
+			if err := helper.SetObj(node.Key, obj); err != nil {
+				failures = append(failures, Failure{Resource: resource.Name, Key: node.Key, Err: err})
+			}
+		}
+	}
+	return failures
+}
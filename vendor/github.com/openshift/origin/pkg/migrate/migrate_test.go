@@ -0,0 +1,81 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/latest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
+	"github.com/coreos/go-etcd/etcd"
+)
+
+func newHelper(t tools.TestLogger) (*tools.FakeEtcdClient, tools.EtcdHelper) {
+	fakeClient := tools.NewFakeEtcdClient(t)
+	fakeClient.TestIndex = true
+	helper := tools.EtcdHelper{Client: fakeClient, Codec: latest.Codec, ResourceVersioner: runtime.NewJSONBaseResourceVersioner()}
+	return fakeClient, helper
+}
+
+// addChild registers a node both in its parent's recursive listing and as an
+// independently addressable key, matching how a real etcd tree behaves.
+func addChild(fakeClient *tools.FakeEtcdClient, parent string, node *etcd.Node) {
+	entry := fakeClient.Data[parent]
+	if entry.R == nil {
+		entry.R = &etcd.Response{Node: &etcd.Node{}}
+	}
+	entry.R.Node.Nodes = append(entry.R.Node.Nodes, node)
+	fakeClient.Data[parent] = entry
+	fakeClient.Data[node.Key] = tools.EtcdResponseWithError{R: &etcd.Response{Node: node}}
+}
+
+func TestMigrateRewritesEachObject(t *testing.T) {
+	fakeClient, helper := newHelper(t)
+	addChild(fakeClient, "/registry/builds", &etcd.Node{Key: "/registry/builds/foo", Value: `{"id":"foo"}`, ModifiedIndex: 1})
+	addChild(fakeClient, "/registry/builds", &etcd.Node{Key: "/registry/builds/bar", Value: `{"id":"bar"}`, ModifiedIndex: 2})
+
+	failures := Migrate(helper, []Resource{
+		{Name: "build", Prefix: "/registry/builds", NewObject: func() runtime.Object { return &api.Pod{} }},
+	})
+	if len(failures) != 0 {
+		t.Fatalf("Unexpected failures: %v", failures)
+	}
+
+	for _, key := range []string{"/registry/builds/foo", "/registry/builds/bar"} {
+		entry := fakeClient.Data[key]
+		if entry.R == nil || entry.R.Node.CreatedIndex == entry.R.Node.ModifiedIndex {
+			t.Errorf("Expected %s to have been rewritten, got %#v", key, entry)
+		}
+	}
+}
+
+func TestMigrateReportsDecodeFailures(t *testing.T) {
+	fakeClient, helper := newHelper(t)
+	addChild(fakeClient, "/registry/builds", &etcd.Node{Key: "/registry/builds/foo", Value: `{"id":"foo"}`, ModifiedIndex: 1})
+	addChild(fakeClient, "/registry/builds", &etcd.Node{Key: "/registry/builds/bad", Value: `{`, ModifiedIndex: 2})
+
+	failures := Migrate(helper, []Resource{
+		{Name: "build", Prefix: "/registry/builds", NewObject: func() runtime.Object { return &api.Pod{} }},
+	})
+	if len(failures) != 1 {
+		t.Fatalf("Expected exactly one failure, got %v", failures)
+	}
+	if failures[0].Key != "/registry/builds/bad" {
+		t.Errorf("Expected the failure to name the bad key, got %v", failures[0])
+	}
+	entry := fakeClient.Data["/registry/builds/foo"]
+	if entry.R == nil || entry.R.Node.CreatedIndex == entry.R.Node.ModifiedIndex {
+		t.Errorf("Expected the good object to still be migrated despite the other failure")
+	}
+}
+
+func TestMigrateSkipsMissingPrefix(t *testing.T) {
+	fakeClient, helper := newHelper(t)
+	fakeClient.ExpectNotFoundGet("/registry/builds")
+	failures := Migrate(helper, []Resource{
+		{Name: "build", Prefix: "/registry/builds", NewObject: func() runtime.Object { return &api.Pod{} }},
+	})
+	if len(failures) != 0 {
+		t.Fatalf("Unexpected failures: %v", failures)
+	}
+}
@@ -2,6 +2,7 @@ package v1beta1
 
 import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
 )
 
 type AccessToken struct {
@@ -17,6 +18,16 @@ type AccessToken struct {
 
 	// RefreshToken is the value by which this token can be renewed. Can be blank.
 	RefreshToken string `json:"refreshToken,omitempty" yaml:"refreshToken,omitempty"`
+
+	// ClientDisplayName is a cached, human-readable name for the client that created this
+	// token, so an "authorized applications" page can render it without a client lookup.
+	ClientDisplayName string `json:"clientDisplayName,omitempty" yaml:"clientDisplayName,omitempty"`
+
+	// CreationIP is the IP address the token was issued to.
+	CreationIP string `json:"creationIP,omitempty" yaml:"creationIP,omitempty"`
+
+	// LastUsedTimestamp is updated each time the token is presented to authenticate a request.
+	LastUsedTimestamp util.Time `json:"lastUsedTimestamp,omitempty" yaml:"lastUsedTimestamp,omitempty"`
 }
 
 type AuthorizeToken struct {
@@ -55,11 +66,19 @@ type Client struct {
 	// Name is the unique identifier of the client
 	Name string `json:"name,omitempty" yaml:"name,omitempty"`
 
+	// DisplayName is a human-readable name for the client shown on login and consent pages
+	// and in an end user's list of authorized applications, in place of Name.
+	DisplayName string `json:"displayName,omitempty" yaml:"displayName,omitempty"`
+
 	// Secret is the unique secret associated with a client
 	Secret string `json:"secret,omitempty" yaml:"secret,omitempty"`
 
 	// RedirectURIs is the valid redirection URIs associated with a client
 	RedirectURIs []string `json:"redirectURIs,omitempty" yaml:"redirectURIs,omitempty"`
+
+	// Trusted marks a first-party client as pre-authorized, so the consent page is skipped
+	// for scopes it requests.
+	Trusted bool `json:"trusted,omitempty" yaml:"trusted,omitempty"`
 }
 
 type ClientAuthorization struct {
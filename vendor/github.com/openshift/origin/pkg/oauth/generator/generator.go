@@ -0,0 +1,52 @@
+// Package generator produces the random secret values used for OAuth AuthorizeTokens and
+// AccessTokens. Every value carries a version prefix ahead of its random portion, so a
+// later change to the format - a different length, a different charset, hashed storage, or
+// an entirely different scheme like JWTs - can be introduced under a new prefix while
+// tokens issued under the old one, which are looked up by their exact value, keep working
+// until they expire.
+package generator
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// charset is the set of characters used for the random portion of a generated token. It
+// avoids characters that need escaping in URLs, headers, or shells.
+const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// DefaultPrefix identifies the current token format.
+const DefaultPrefix = "sha256~"
+
+// DefaultLength is the number of random characters generated after the prefix.
+const DefaultLength = 32
+
+// Generator produces new token values.
+type Generator interface {
+	// Generate returns a new token value.
+	Generate() (string, error)
+}
+
+// prefixedGenerator generates tokens of the form "<prefix><length random characters>".
+type prefixedGenerator struct {
+	prefix string
+	length int
+}
+
+// New returns a Generator whose tokens begin with prefix, identifying their format,
+// followed by length random characters from charset.
+func New(prefix string, length int) Generator {
+	return &prefixedGenerator{prefix: prefix, length: length}
+}
+
+func (g *prefixedGenerator) Generate() (string, error) {
+	raw := make([]byte, g.length)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("unable to generate token: %v", err)
+	}
+	value := make([]byte, g.length)
+	for i, b := range raw {
+		value[i] = charset[int(b)%len(charset)]
+	}
+	return g.prefix + string(value), nil
+}
@@ -0,0 +1,109 @@
+// Package jwt implements a minimal, self-contained JSON Web Token encoder and verifier for
+// origin's optional JWT access token mode. Unlike an opaque token minted by
+// pkg/oauth/generator, a JWT carries the user's identity and scope in a signed payload, so a
+// resource server can verify a request's token on its own - checking the signature and
+// expiry - without a round trip back to the access token registry. Only HMAC-SHA256 signing is
+// supported; this snapshot has no vendored asymmetric crypto or JOSE library to build on.
+package jwt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// header is the fixed JOSE header for every token this package produces. KeyID identifies
+// which signing key was used, so a verifier can select the right key during rotation.
+type header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+	KeyID     string `json:"kid"`
+}
+
+// Claims carries the identity and scope information an access token vouches for. It mirrors
+// the fields TokenAuthenticator would otherwise have looked up from an api.AccessToken in the
+// registry.
+type Claims struct {
+	UserName  string `json:"userName"`
+	UserUID   string `json:"userUID"`
+	Scope     string `json:"scope"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Expired reports whether c has passed its expiry time.
+func (c *Claims) Expired() bool {
+	return time.Unix(c.ExpiresAt, 0).Before(time.Now())
+}
+
+var encoding = base64.RawURLEncoding
+
+// Encode signs claims with key under keyID and returns the resulting compact JWT, in the
+// standard "header.payload.signature" form.
+func Encode(claims Claims, keyID string, key []byte) (string, error) {
+	headerJSON, err := json.Marshal(header{Algorithm: "HS256", Type: "JWT", KeyID: keyID})
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := encoding.EncodeToString(headerJSON) + "." + encoding.EncodeToString(claimsJSON)
+	return signingInput + "." + encoding.EncodeToString(sign(signingInput, key)), nil
+}
+
+// Decode verifies token's signature using the key returned for its header's key ID and, if
+// valid, returns its claims. keyForID is expected to return ok=false for an unknown key ID.
+func Decode(token string, keyForID func(keyID string) (key []byte, ok bool)) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("jwt: malformed token")
+	}
+	signingInput := parts[0] + "." + parts[1]
+
+	headerJSON, err := encoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("jwt: invalid header encoding: %v", err)
+	}
+	var h header
+	if err := json.Unmarshal(headerJSON, &h); err != nil {
+		return nil, fmt.Errorf("jwt: invalid header: %v", err)
+	}
+	if h.Algorithm != "HS256" {
+		return nil, fmt.Errorf("jwt: unsupported algorithm %q", h.Algorithm)
+	}
+
+	key, ok := keyForID(h.KeyID)
+	if !ok {
+		return nil, fmt.Errorf("jwt: unknown key id %q", h.KeyID)
+	}
+
+	signature, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("jwt: invalid signature encoding: %v", err)
+	}
+	if !hmac.Equal(signature, sign(signingInput, key)) {
+		return nil, errors.New("jwt: signature mismatch")
+	}
+
+	claimsJSON, err := encoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("jwt: invalid claims encoding: %v", err)
+	}
+	claims := &Claims{}
+	if err := json.Unmarshal(claimsJSON, claims); err != nil {
+		return nil, fmt.Errorf("jwt: invalid claims: %v", err)
+	}
+	return claims, nil
+}
+
+func sign(signingInput string, key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(signingInput))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,108 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	claims := Claims{
+		UserName:  "bob",
+		UserUID:   "1",
+		Scope:     "user:full",
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}
+	key := []byte("secret")
+
+	token, err := Encode(claims, "key1", key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	decoded, err := Decode(token, func(keyID string) ([]byte, bool) {
+		if keyID != "key1" {
+			return nil, false
+		}
+		return key, true
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *decoded != claims {
+		t.Errorf("expected %#v, got %#v", claims, *decoded)
+	}
+}
+
+func TestDecodeRejectsWrongKey(t *testing.T) {
+	token, err := Encode(Claims{UserName: "bob"}, "key1", []byte("secret"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = Decode(token, func(keyID string) ([]byte, bool) {
+		return []byte("wrong secret"), true
+	})
+	if err == nil {
+		t.Errorf("expected an error verifying with the wrong key")
+	}
+}
+
+func TestDecodeRejectsUnknownKeyID(t *testing.T) {
+	token, err := Encode(Claims{UserName: "bob"}, "key1", []byte("secret"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = Decode(token, func(keyID string) ([]byte, bool) {
+		return nil, false
+	})
+	if err == nil {
+		t.Errorf("expected an error for an unknown key id")
+	}
+}
+
+func TestClaimsExpired(t *testing.T) {
+	expired := Claims{ExpiresAt: time.Now().Add(-time.Minute).Unix()}
+	if !expired.Expired() {
+		t.Errorf("expected claims to be expired")
+	}
+
+	valid := Claims{ExpiresAt: time.Now().Add(time.Minute).Unix()}
+	if valid.Expired() {
+		t.Errorf("expected claims to not be expired")
+	}
+}
+
+func TestKeyStoreRotation(t *testing.T) {
+	store, err := NewKeyStore("key1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	claims := Claims{UserName: "bob", ExpiresAt: time.Now().Add(time.Hour).Unix()}
+	oldToken, err := store.Sign(claims)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := store.Rotate("key2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := store.Verify(oldToken); err != nil {
+		t.Errorf("expected a token signed before rotation to still verify: %v", err)
+	}
+
+	newToken, err := store.Sign(claims)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := store.Verify(newToken); err != nil {
+		t.Errorf("expected a token signed with the current key to verify: %v", err)
+	}
+
+	store.RetireKey("key1")
+	if _, err := store.Verify(oldToken); err == nil {
+		t.Errorf("expected a token signed with a retired key to no longer verify")
+	}
+}
@@ -0,0 +1,73 @@
+package jwt
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+)
+
+// KeyStore holds the signing key currently used to mint JWTs, plus any keys retired by a
+// previous call to Rotate. Retired keys are kept only for verification, so a token issued just
+// before a rotation still verifies until it expires.
+type KeyStore struct {
+	lock      sync.RWMutex
+	currentID string
+	keys      map[string][]byte
+}
+
+// NewKeyStore returns a KeyStore whose current signing key is a freshly generated random
+// value identified by keyID.
+func NewKeyStore(keyID string) (*KeyStore, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("unable to generate signing key: %v", err)
+	}
+	return &KeyStore{
+		currentID: keyID,
+		keys:      map[string][]byte{keyID: key},
+	}, nil
+}
+
+// Rotate generates a new signing key identified by keyID and makes it current. Tokens signed
+// by the previous key remain verifiable until it is retired by RetireKey.
+func (s *KeyStore) Rotate(keyID string) error {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return fmt.Errorf("unable to generate signing key: %v", err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.currentID = keyID
+	s.keys[keyID] = key
+	return nil
+}
+
+// RetireKey removes a previously rotated-out key so it can no longer verify tokens. It has no
+// effect on the current signing key.
+func (s *KeyStore) RetireKey(keyID string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if keyID == s.currentID {
+		return
+	}
+	delete(s.keys, keyID)
+}
+
+// Sign encodes claims as a JWT using the current signing key.
+func (s *KeyStore) Sign(claims Claims) (string, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return Encode(claims, s.currentID, s.keys[s.currentID])
+}
+
+// Verify decodes and checks token against every key this store still knows about, current or
+// retired-but-not-yet-removed.
+func (s *KeyStore) Verify(token string) (*Claims, error) {
+	return Decode(token, func(keyID string) ([]byte, bool) {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+		key, ok := s.keys[keyID]
+		return key, ok
+	})
+}
@@ -1,7 +1,9 @@
 package accesstoken
 
 import (
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
 
 	"github.com/openshift/origin/pkg/oauth/api"
 )
@@ -9,13 +11,16 @@ import (
 // Registry is an interface for things that know how to store AccessToken objects.
 type Registry interface {
 	// ListAccessTokens obtains a list of access tokens that match a selector.
-	ListAccessTokens(selector labels.Selector) (*api.AccessTokenList, error)
+	ListAccessTokens(ctx kubeapi.Context, selector labels.Selector) (*api.AccessTokenList, error)
 	// GetAccessToken retrieves a specific access token.
-	GetAccessToken(id string) (*api.AccessToken, error)
+	GetAccessToken(ctx kubeapi.Context, id string) (*api.AccessToken, error)
 	// CreateAccessToken creates a new access token.
-	CreateAccessToken(token *api.AccessToken) error
+	CreateAccessToken(ctx kubeapi.Context, token *api.AccessToken) error
 	// UpdateAccessToken updates an access token.
-	UpdateAccessToken(token *api.AccessToken) error
+	UpdateAccessToken(ctx kubeapi.Context, token *api.AccessToken) error
 	// DeleteAccessToken deletes an access token.
-	DeleteAccessToken(id string) error
+	DeleteAccessToken(ctx kubeapi.Context, id string) error
+	// TouchAccessToken cheaply records that a token was just used, without requiring callers
+	// to read-modify-write the whole object.
+	TouchAccessToken(ctx kubeapi.Context, id string, when util.Time) error
 }
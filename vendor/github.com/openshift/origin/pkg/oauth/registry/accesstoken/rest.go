@@ -29,7 +29,7 @@ func (s *REST) New() runtime.Object {
 
 // Get retrieves an AccessToken by id.
 func (s *REST) Get(ctx kubeapi.Context, id string) (runtime.Object, error) {
-	token, err := s.registry.GetAccessToken(id)
+	token, err := s.registry.GetAccessToken(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +38,7 @@ func (s *REST) Get(ctx kubeapi.Context, id string) (runtime.Object, error) {
 
 // List retrieves a list of AccessTokens that match selector.
 func (s *REST) List(ctx kubeapi.Context, selector, fields labels.Selector) (runtime.Object, error) {
-	tokens, err := s.registry.ListAccessTokens(selector)
+	tokens, err := s.registry.ListAccessTokens(ctx, selector)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +60,7 @@ func (s *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.O
 	// }
 
 	return apiserver.MakeAsync(func() (runtime.Object, error) {
-		if err := s.registry.CreateAccessToken(token); err != nil {
+		if err := s.registry.CreateAccessToken(ctx, token); err != nil {
 			return nil, err
 		}
 		return s.Get(ctx, token.Name)
@@ -75,6 +75,6 @@ func (s *REST) Update(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.O
 // Delete asynchronously deletes an AccessToken specified by its id.
 func (s *REST) Delete(ctx kubeapi.Context, id string) (<-chan runtime.Object, error) {
 	return apiserver.MakeAsync(func() (runtime.Object, error) {
-		return &kubeapi.Status{Status: kubeapi.StatusSuccess}, s.registry.DeleteAccessToken(id)
+		return &kubeapi.Status{Status: kubeapi.StatusSuccess}, s.registry.DeleteAccessToken(ctx, id)
 	}), nil
 }
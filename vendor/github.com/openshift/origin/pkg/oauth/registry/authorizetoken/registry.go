@@ -1,6 +1,7 @@
 package authorizetoken
 
 import (
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
 
 	"github.com/openshift/origin/pkg/oauth/api"
@@ -9,13 +10,13 @@ import (
 // Registry is an interface for things that know how to store AuthorizeToken objects.
 type Registry interface {
 	// ListAuthorizeTokens obtains a list of authorize tokens that match a selector.
-	ListAuthorizeTokens(selector labels.Selector) (*api.AuthorizeTokenList, error)
+	ListAuthorizeTokens(ctx kubeapi.Context, selector labels.Selector) (*api.AuthorizeTokenList, error)
 	// GetAuthorizeToken retrieves a specific authorize token.
-	GetAuthorizeToken(name string) (*api.AuthorizeToken, error)
+	GetAuthorizeToken(ctx kubeapi.Context, name string) (*api.AuthorizeToken, error)
 	// CreateAuthorizeToken creates a new authorize token.
-	CreateAuthorizeToken(token *api.AuthorizeToken) error
+	CreateAuthorizeToken(ctx kubeapi.Context, token *api.AuthorizeToken) error
 	// UpdateAuthorizeToken updates an authorize token.
-	UpdateAuthorizeToken(token *api.AuthorizeToken) error
+	UpdateAuthorizeToken(ctx kubeapi.Context, token *api.AuthorizeToken) error
 	// DeleteAuthorizeToken deletes an authorize token.
-	DeleteAuthorizeToken(name string) error
+	DeleteAuthorizeToken(ctx kubeapi.Context, name string) error
 }
@@ -30,7 +30,7 @@ func (s *REST) New() runtime.Object {
 
 // Get retrieves an AuthorizeToken by id.
 func (s *REST) Get(ctx kubeapi.Context, id string) (runtime.Object, error) {
-	token, err := s.registry.GetAuthorizeToken(id)
+	token, err := s.registry.GetAuthorizeToken(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +39,7 @@ func (s *REST) Get(ctx kubeapi.Context, id string) (runtime.Object, error) {
 
 // List retrieves a list of AuthorizeTokens that match selector.
 func (s *REST) List(ctx kubeapi.Context, selector, fields labels.Selector) (runtime.Object, error) {
-	tokens, err := s.registry.ListAuthorizeTokens(selector)
+	tokens, err := s.registry.ListAuthorizeTokens(ctx, selector)
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +61,7 @@ func (s *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.O
 	// }
 
 	return apiserver.MakeAsync(func() (runtime.Object, error) {
-		if err := s.registry.CreateAuthorizeToken(token); err != nil {
+		if err := s.registry.CreateAuthorizeToken(ctx, token); err != nil {
 			return nil, err
 		}
 		return s.Get(ctx, token.Name)
@@ -76,6 +76,6 @@ func (s *REST) Update(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.O
 // Delete asynchronously deletes an AuthorizeToken specified by its id.
 func (s *REST) Delete(ctx kubeapi.Context, id string) (<-chan runtime.Object, error) {
 	return apiserver.MakeAsync(func() (runtime.Object, error) {
-		return &kubeapi.Status{Status: kubeapi.StatusSuccess}, s.registry.DeleteAuthorizeToken(id)
+		return &kubeapi.Status{Status: kubeapi.StatusSuccess}, s.registry.DeleteAuthorizeToken(ctx, id)
 	}), nil
 }
@@ -0,0 +1,190 @@
+// Package cache provides read-through, TTL-based caches for the Client and AccessToken
+// registries, so that authenticating a request doesn't hit etcd on every call.
+//
+// Neither registry currently exposes a Watch method (unlike, say, pkg/util/memstore), so
+// there is no way to invalidate an entry the instant it changes elsewhere in the cluster.
+// Instead, entries are invalidated eagerly on any Create/Update/Delete made through the
+// cache itself, and fall back to the TTL to pick up changes made through another instance
+// or directly against etcd. Callers that need strict read-your-writes consistency across
+// multiple apiserver processes should keep the TTL short or bypass the cache.
+package cache
+
+import (
+	"sync"
+	"time"
+
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/registry/accesstoken"
+	"github.com/openshift/origin/pkg/oauth/registry/client"
+)
+
+// ClientRegistry wraps a client.Registry, caching GetClient lookups for ttl.
+type ClientRegistry struct {
+	client.Registry
+	ttl   time.Duration
+	lock  sync.Mutex
+	items map[string]clientCacheEntry
+}
+
+type clientCacheEntry struct {
+	client  *api.Client
+	expires time.Time
+}
+
+// NewClientRegistry returns a client.Registry that caches GetClient results from registry
+// for up to ttl.
+func NewClientRegistry(registry client.Registry, ttl time.Duration) *ClientRegistry {
+	return &ClientRegistry{
+		Registry: registry,
+		ttl:      ttl,
+		items:    make(map[string]clientCacheEntry),
+	}
+}
+
+// GetClient returns the named client, using a cached copy if one is present and unexpired.
+func (c *ClientRegistry) GetClient(ctx kubeapi.Context, id string) (*api.Client, error) {
+	c.lock.Lock()
+	entry, ok := c.items[id]
+	c.lock.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.client, nil
+	}
+
+	found, err := c.Registry.GetClient(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	c.items[id] = clientCacheEntry{client: found, expires: time.Now().Add(c.ttl)}
+	c.lock.Unlock()
+	return found, nil
+}
+
+// CreateClient creates the client and caches it, avoiding an extra round trip on the next Get.
+func (c *ClientRegistry) CreateClient(ctx kubeapi.Context, newClient *api.Client) error {
+	if err := c.Registry.CreateClient(ctx, newClient); err != nil {
+		return err
+	}
+	c.lock.Lock()
+	c.items[newClient.Name] = clientCacheEntry{client: newClient, expires: time.Now().Add(c.ttl)}
+	c.lock.Unlock()
+	return nil
+}
+
+// UpdateClient updates the client and invalidates the cached copy.
+func (c *ClientRegistry) UpdateClient(ctx kubeapi.Context, updated *api.Client) error {
+	if err := c.Registry.UpdateClient(ctx, updated); err != nil {
+		return err
+	}
+	c.invalidate(updated.Name)
+	return nil
+}
+
+// DeleteClient deletes the client and invalidates the cached copy.
+func (c *ClientRegistry) DeleteClient(ctx kubeapi.Context, id string) error {
+	if err := c.Registry.DeleteClient(ctx, id); err != nil {
+		return err
+	}
+	c.invalidate(id)
+	return nil
+}
+
+func (c *ClientRegistry) invalidate(id string) {
+	c.lock.Lock()
+	delete(c.items, id)
+	c.lock.Unlock()
+}
+
+// AccessTokenRegistry wraps an accesstoken.Registry, caching GetAccessToken lookups for ttl.
+type AccessTokenRegistry struct {
+	accesstoken.Registry
+	ttl   time.Duration
+	lock  sync.Mutex
+	items map[string]accessTokenCacheEntry
+}
+
+type accessTokenCacheEntry struct {
+	token   *api.AccessToken
+	expires time.Time
+}
+
+// NewAccessTokenRegistry returns an accesstoken.Registry that caches GetAccessToken results
+// from registry for up to ttl.
+func NewAccessTokenRegistry(registry accesstoken.Registry, ttl time.Duration) *AccessTokenRegistry {
+	return &AccessTokenRegistry{
+		Registry: registry,
+		ttl:      ttl,
+		items:    make(map[string]accessTokenCacheEntry),
+	}
+}
+
+// GetAccessToken returns the named access token, using a cached copy if one is present and
+// unexpired.
+func (c *AccessTokenRegistry) GetAccessToken(ctx kubeapi.Context, id string) (*api.AccessToken, error) {
+	c.lock.Lock()
+	entry, ok := c.items[id]
+	c.lock.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.token, nil
+	}
+
+	found, err := c.Registry.GetAccessToken(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	c.items[id] = accessTokenCacheEntry{token: found, expires: time.Now().Add(c.ttl)}
+	c.lock.Unlock()
+	return found, nil
+}
+
+// CreateAccessToken creates the token and caches it, avoiding an extra round trip on the
+// next Get.
+func (c *AccessTokenRegistry) CreateAccessToken(ctx kubeapi.Context, token *api.AccessToken) error {
+	if err := c.Registry.CreateAccessToken(ctx, token); err != nil {
+		return err
+	}
+	c.lock.Lock()
+	c.items[token.Name] = accessTokenCacheEntry{token: token, expires: time.Now().Add(c.ttl)}
+	c.lock.Unlock()
+	return nil
+}
+
+// DeleteAccessToken deletes the token and invalidates the cached copy.
+func (c *AccessTokenRegistry) DeleteAccessToken(ctx kubeapi.Context, id string) error {
+	if err := c.Registry.DeleteAccessToken(ctx, id); err != nil {
+		return err
+	}
+	c.invalidate(id)
+	return nil
+}
+
+// UpdateAccessToken updates the token and invalidates the cached copy.
+func (c *AccessTokenRegistry) UpdateAccessToken(ctx kubeapi.Context, token *api.AccessToken) error {
+	if err := c.Registry.UpdateAccessToken(ctx, token); err != nil {
+		return err
+	}
+	c.invalidate(token.Name)
+	return nil
+}
+
+// TouchAccessToken records that the token was used and invalidates the cached copy, since
+// the cached LastUsedTimestamp is now stale.
+func (c *AccessTokenRegistry) TouchAccessToken(ctx kubeapi.Context, id string, when util.Time) error {
+	if err := c.Registry.TouchAccessToken(ctx, id, when); err != nil {
+		return err
+	}
+	c.invalidate(id)
+	return nil
+}
+
+func (c *AccessTokenRegistry) invalidate(id string) {
+	c.lock.Lock()
+	delete(c.items, id)
+	c.lock.Unlock()
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+)
+
+// TokenAndAuthorizationRegistry is the subset of the access token, authorize token, and client
+// authorization registries needed to garbage collect a deleted client's dependents.
+type TokenAndAuthorizationRegistry interface {
+	ListAccessTokens(ctx kubeapi.Context, selector labels.Selector) (*oauthapi.AccessTokenList, error)
+	DeleteAccessToken(ctx kubeapi.Context, id string) error
+	ListAuthorizeTokens(ctx kubeapi.Context, selector labels.Selector) (*oauthapi.AuthorizeTokenList, error)
+	DeleteAuthorizeToken(ctx kubeapi.Context, id string) error
+	ListClientAuthorizations(ctx kubeapi.Context, label, field labels.Selector) (*oauthapi.ClientAuthorizationList, error)
+	DeleteClientAuthorization(ctx kubeapi.Context, id string) error
+}
+
+// DeleteTokensAndAuthorizationsForClient removes every AccessToken, AuthorizeToken, and
+// ClientAuthorization that references clientName, so deleting a client does not leave orphaned
+// tokens usable by no one.
+func DeleteTokensAndAuthorizationsForClient(ctx kubeapi.Context, registry TokenAndAuthorizationRegistry, clientName string) error {
+	accessTokens, err := registry.ListAccessTokens(ctx, labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, token := range accessTokens.Items {
+		if token.AuthorizeToken.ClientName == clientName {
+			if err := registry.DeleteAccessToken(ctx, token.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	authorizeTokens, err := registry.ListAuthorizeTokens(ctx, labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, token := range authorizeTokens.Items {
+		if token.ClientName == clientName {
+			if err := registry.DeleteAuthorizeToken(ctx, token.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	authorizations, err := registry.ListClientAuthorizations(ctx, labels.Everything(), labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, authorization := range authorizations.Items {
+		if authorization.ClientName == clientName {
+			if err := registry.DeleteClientAuthorization(ctx, authorization.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
 
 	"github.com/openshift/origin/pkg/oauth/api"
@@ -9,13 +10,13 @@ import (
 // Registry is an interface for things that know how to store Client objects.
 type Registry interface {
 	// ListClients obtains a list of clients that match a selector.
-	ListClients(selector labels.Selector) (*api.ClientList, error)
+	ListClients(ctx kubeapi.Context, selector labels.Selector) (*api.ClientList, error)
 	// GetClient retrieves a specific client.
-	GetClient(id string) (*api.Client, error)
+	GetClient(ctx kubeapi.Context, id string) (*api.Client, error)
 	// CreateClient creates a new client.
-	CreateClient(client *api.Client) error
+	CreateClient(ctx kubeapi.Context, client *api.Client) error
 	// UpdateClient updates an client.
-	UpdateClient(client *api.Client) error
+	UpdateClient(ctx kubeapi.Context, client *api.Client) error
 	// DeleteClient deletes an client.
-	DeleteClient(id string) error
+	DeleteClient(ctx kubeapi.Context, id string) error
 }
@@ -16,11 +16,13 @@ import (
 // REST implements the RESTStorage interface in terms of an Registry.
 type REST struct {
 	registry Registry
+	tokens   TokenAndAuthorizationRegistry
 }
 
-// NewStorage returns a new REST.
-func NewREST(registry Registry) apiserver.RESTStorage {
-	return &REST{registry}
+// NewREST returns a new REST. tokens is used to garbage collect a client's tokens and
+// authorizations when the client itself is deleted.
+func NewREST(registry Registry, tokens TokenAndAuthorizationRegistry) apiserver.RESTStorage {
+	return &REST{registry, tokens}
 }
 
 // New returns a new Client for use with Create and Update.
@@ -30,7 +32,7 @@ func (s *REST) New() runtime.Object {
 
 // Get retrieves an Client by id.
 func (s *REST) Get(ctx kubeapi.Context, id string) (runtime.Object, error) {
-	client, err := s.registry.GetClient(id)
+	client, err := s.registry.GetClient(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +41,7 @@ func (s *REST) Get(ctx kubeapi.Context, id string) (runtime.Object, error) {
 
 // List retrieves a list of Clients that match selector.
 func (s *REST) List(ctx kubeapi.Context, selector, fields labels.Selector) (runtime.Object, error) {
-	clients, err := s.registry.ListClients(selector)
+	clients, err := s.registry.ListClients(ctx, selector)
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +63,7 @@ func (s *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.O
 	// }
 
 	return apiserver.MakeAsync(func() (runtime.Object, error) {
-		if err := s.registry.CreateClient(client); err != nil {
+		if err := s.registry.CreateClient(ctx, client); err != nil {
 			return nil, err
 		}
 		return s.Get(ctx, client.Name)
@@ -73,9 +75,13 @@ func (s *REST) Update(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.O
 	return nil, fmt.Errorf("Clients may not be changed.")
 }
 
-// Delete asynchronously deletes an Client specified by its id.
+// Delete asynchronously deletes an Client specified by its id, along with any AccessTokens,
+// AuthorizeTokens, and ClientAuthorizations that reference it.
 func (s *REST) Delete(ctx kubeapi.Context, id string) (<-chan runtime.Object, error) {
 	return apiserver.MakeAsync(func() (runtime.Object, error) {
-		return &kubeapi.Status{Status: kubeapi.StatusSuccess}, s.registry.DeleteClient(id)
+		if err := DeleteTokensAndAuthorizationsForClient(ctx, s.tokens, id); err != nil {
+			return nil, err
+		}
+		return &kubeapi.Status{Status: kubeapi.StatusSuccess}, s.registry.DeleteClient(ctx, id)
 	}), nil
 }
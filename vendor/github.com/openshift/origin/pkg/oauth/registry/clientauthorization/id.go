@@ -0,0 +1,22 @@
+package clientauthorization
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// MakeID returns a stable identifier for the ClientAuthorization scoped to userName and
+// clientName. Both components are percent-encoded before being joined so that a
+// separator character embedded in either one can never collide with a different
+// (userName, clientName) pair.
+func MakeID(userName, clientName string) string {
+	return fmt.Sprintf("%s:%s", url.QueryEscape(userName), url.QueryEscape(clientName))
+}
+
+// LegacyID returns the identifier a ClientAuthorization for userName and clientName would
+// have received before MakeID started percent-encoding its components. It exists so
+// records written under the old scheme can still be found and updated in place instead of
+// being orphaned.
+func LegacyID(userName, clientName string) string {
+	return fmt.Sprintf("%s:%s", userName, clientName)
+}
@@ -0,0 +1,19 @@
+package clientauthorization
+
+import "testing"
+
+func TestMakeIDEscapesComponents(t *testing.T) {
+	id := MakeID("user:name", "client:name")
+	if id == LegacyID("user:name", "client:name") {
+		t.Errorf("expected MakeID to percent-encode components differently than LegacyID, got %q for both", id)
+	}
+	if id != "user%3Aname:client%3Aname" {
+		t.Errorf("unexpected MakeID result: %q", id)
+	}
+}
+
+func TestLegacyIDMatchesOldScheme(t *testing.T) {
+	if id := LegacyID("user", "client"); id != "user:client" {
+		t.Errorf("unexpected LegacyID result: %q", id)
+	}
+}
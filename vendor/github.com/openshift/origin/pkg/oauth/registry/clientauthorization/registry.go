@@ -1,6 +1,7 @@
 package clientauthorization
 
 import (
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
 
 	"github.com/openshift/origin/pkg/oauth/api"
@@ -9,9 +10,13 @@ import (
 // Registry is an interface for things that know how to store ClientAuthorization objects.
 type Registry interface {
 	ClientAuthorizationID(userName, clientName string) string
-	ListClientAuthorizations(label, field labels.Selector) (*api.ClientAuthorizationList, error)
-	GetClientAuthorization(id string) (*api.ClientAuthorization, error)
-	CreateClientAuthorization(token *api.ClientAuthorization) error
-	UpdateClientAuthorization(token *api.ClientAuthorization) error
-	DeleteClientAuthorization(id string) error
+	// FindClientAuthorization looks up the ClientAuthorization for a (userName, clientName)
+	// tuple without the caller needing to know how the ID is constructed, so lookups keep
+	// working across ID scheme migrations.
+	FindClientAuthorization(ctx kubeapi.Context, userName, clientName string) (*api.ClientAuthorization, error)
+	ListClientAuthorizations(ctx kubeapi.Context, label, field labels.Selector) (*api.ClientAuthorizationList, error)
+	GetClientAuthorization(ctx kubeapi.Context, id string) (*api.ClientAuthorization, error)
+	CreateClientAuthorization(ctx kubeapi.Context, token *api.ClientAuthorization) error
+	UpdateClientAuthorization(ctx kubeapi.Context, token *api.ClientAuthorization) error
+	DeleteClientAuthorization(ctx kubeapi.Context, id string) error
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
 
 	"github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/util/apierrors"
 )
 
 // REST implements the RESTStorage interface in terms of an Registry.
@@ -29,7 +30,7 @@ func (s *REST) New() runtime.Object {
 
 // Get retrieves an ClientAuthorization by id.
 func (s *REST) Get(ctx kubeapi.Context, id string) (runtime.Object, error) {
-	authorization, err := s.registry.GetClientAuthorization(id)
+	authorization, err := s.registry.GetClientAuthorization(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -38,21 +39,26 @@ func (s *REST) Get(ctx kubeapi.Context, id string) (runtime.Object, error) {
 
 // List retrieves a list of ClientAuthorizations that match selector.
 func (s *REST) List(ctx kubeapi.Context, label, fields labels.Selector) (runtime.Object, error) {
-	return s.registry.ListClientAuthorizations(label, labels.Everything())
+	return s.registry.ListClientAuthorizations(ctx, label, labels.Everything())
 }
 
 // Create registers the given ClientAuthorization.
 func (s *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
 	authorization, ok := obj.(*api.ClientAuthorization)
 	if !ok {
-		return nil, fmt.Errorf("not an authorization: %#v", obj)
+		return nil, apierrors.NewBadRequest("clientAuthorization", fmt.Sprintf("not an authorization: %#v", obj))
 	}
 
 	if authorization.UserName == "" || authorization.ClientName == "" {
-		return nil, fmt.Errorf("invalid authorization")
+		return nil, apierrors.NewBadRequest("clientAuthorization", "invalid authorization")
 	}
 
 	authorization.ID = s.registry.ClientAuthorizationID(authorization.UserName, authorization.ClientName)
+	if existing, err := s.registry.FindClientAuthorization(ctx, authorization.UserName, authorization.ClientName); err == nil {
+		// reuse the existing identifier, which may predate the current ID scheme, so
+		// re-approving a client updates the previous record instead of orphaning it
+		authorization.ID = existing.ID
+	}
 	authorization.CreationTimestamp = util.Now()
 
 	// if errs := validation.ValidateClientAuthorization(authorization); len(errs) > 0 {
@@ -60,7 +66,7 @@ func (s *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.O
 	// }
 
 	return apiserver.MakeAsync(func() (runtime.Object, error) {
-		if err := s.registry.CreateClientAuthorization(authorization); err != nil {
+		if err := s.registry.CreateClientAuthorization(ctx, authorization); err != nil {
 			return nil, err
 		}
 		return s.Get(ctx, authorization.ID)
@@ -75,6 +81,6 @@ func (s *REST) Update(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.O
 // Delete asynchronously deletes an ClientAuthorization specified by its id.
 func (s *REST) Delete(ctx kubeapi.Context, id string) (<-chan runtime.Object, error) {
 	return apiserver.MakeAsync(func() (runtime.Object, error) {
-		return &kubeapi.Status{Status: kubeapi.StatusSuccess}, s.registry.DeleteClientAuthorization(id)
+		return &kubeapi.Status{Status: kubeapi.StatusSuccess}, s.registry.DeleteClientAuthorization(ctx, id)
 	}), nil
 }
@@ -3,11 +3,17 @@ package etcd
 import (
 	"errors"
 	"fmt"
+	"time"
 
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	apierrors "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
 	etcderrs "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors/etcd"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
 	"github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/registry/clientauthorization"
 )
 
 // Etcd implements the AccessToken, AuthorizeToken, and Client registries backed by etcd.
@@ -22,17 +28,17 @@ func New(helper tools.EtcdHelper) *Etcd {
 	}
 }
 
-func makeAccessTokenKey(id string) string {
+func makeAccessTokenKey(ctx kubeapi.Context, id string) string {
 	return "/accessTokens/" + id
 }
 
-func (r *Etcd) GetAccessToken(name string) (token *api.AccessToken, err error) {
+func (r *Etcd) GetAccessToken(ctx kubeapi.Context, name string) (token *api.AccessToken, err error) {
 	token = &api.AccessToken{}
-	err = etcderrs.InterpretGetError(r.ExtractObj(makeAccessTokenKey(name), token, false), "accessToken", name)
+	err = etcderrs.InterpretGetError(r.ExtractObj(makeAccessTokenKey(ctx, name), token, false), "accessToken", name)
 	return
 }
 
-func (r *Etcd) ListAccessTokens(selector labels.Selector) (*api.AccessTokenList, error) {
+func (r *Etcd) ListAccessTokens(ctx kubeapi.Context, selector labels.Selector) (*api.AccessTokenList, error) {
 	list := api.AccessTokenList{}
 	err := r.ExtractList("/accessTokens", &list.Items, &list.ResourceVersion)
 	if err != nil && !tools.IsEtcdNotFound(err) {
@@ -48,32 +54,47 @@ func (r *Etcd) ListAccessTokens(selector labels.Selector) (*api.AccessTokenList,
 	return &list, nil
 }
 
-func (r *Etcd) CreateAccessToken(token *api.AccessToken) error {
-	err := etcderrs.InterpretCreateError(r.CreateObj(makeAccessTokenKey(token.Name), token, 0), "accessToken", token.Name)
+func (r *Etcd) CreateAccessToken(ctx kubeapi.Context, token *api.AccessToken) error {
+	err := etcderrs.InterpretCreateError(r.CreateObj(makeAccessTokenKey(ctx, token.Name), token, uint64(token.AuthorizeToken.ExpiresIn)), "accessToken", token.Name)
 	return err
 }
 
-func (r *Etcd) UpdateAccessToken(*api.AccessToken) error {
+func (r *Etcd) UpdateAccessToken(ctx kubeapi.Context, token *api.AccessToken) error {
 	return errors.New("not supported")
 }
 
-func (r *Etcd) DeleteAccessToken(name string) error {
-	key := makeAccessTokenKey(name)
+// TouchAccessToken cheaply updates only the LastUsedTimestamp of an access token, avoiding a
+// full read-modify-write of the token's other fields on every authenticated request.
+func (r *Etcd) TouchAccessToken(ctx kubeapi.Context, name string, when util.Time) error {
+	key := makeAccessTokenKey(ctx, name)
+	err := r.AtomicUpdate(key, &api.AccessToken{}, func(obj runtime.Object) (runtime.Object, error) {
+		token, ok := obj.(*api.AccessToken)
+		if !ok {
+			return nil, fmt.Errorf("not an access token: %#v", obj)
+		}
+		token.LastUsedTimestamp = when
+		return token, nil
+	})
+	return etcderrs.InterpretUpdateError(err, "accessToken", name)
+}
+
+func (r *Etcd) DeleteAccessToken(ctx kubeapi.Context, name string) error {
+	key := makeAccessTokenKey(ctx, name)
 	err := etcderrs.InterpretDeleteError(r.Delete(key, false), "accessToken", name)
 	return err
 }
 
-func makeAuthorizeTokenKey(id string) string {
+func makeAuthorizeTokenKey(ctx kubeapi.Context, id string) string {
 	return "/authorizeTokens/" + id
 }
 
-func (r *Etcd) GetAuthorizeToken(name string) (token *api.AuthorizeToken, err error) {
+func (r *Etcd) GetAuthorizeToken(ctx kubeapi.Context, name string) (token *api.AuthorizeToken, err error) {
 	token = &api.AuthorizeToken{}
-	err = etcderrs.InterpretGetError(r.ExtractObj(makeAuthorizeTokenKey(name), token, false), "authorizeToken", name)
+	err = etcderrs.InterpretGetError(r.ExtractObj(makeAuthorizeTokenKey(ctx, name), token, false), "authorizeToken", name)
 	return
 }
 
-func (r *Etcd) ListAuthorizeTokens(selector labels.Selector) (*api.AuthorizeTokenList, error) {
+func (r *Etcd) ListAuthorizeTokens(ctx kubeapi.Context, selector labels.Selector) (*api.AuthorizeTokenList, error) {
 	list := api.AuthorizeTokenList{}
 	err := r.ExtractList("/authorizeTokens", &list.Items, &list.ResourceVersion)
 	if err != nil && !tools.IsEtcdNotFound(err) {
@@ -82,32 +103,90 @@ func (r *Etcd) ListAuthorizeTokens(selector labels.Selector) (*api.AuthorizeToke
 	return &list, nil
 }
 
-func (r *Etcd) CreateAuthorizeToken(token *api.AuthorizeToken) error {
-	err := etcderrs.InterpretCreateError(r.CreateObj(makeAuthorizeTokenKey(token.Name), token, 0), "authorizeToken", token.Name)
+func (r *Etcd) CreateAuthorizeToken(ctx kubeapi.Context, token *api.AuthorizeToken) error {
+	err := etcderrs.InterpretCreateError(r.CreateObj(makeAuthorizeTokenKey(ctx, token.Name), token, uint64(token.ExpiresIn)), "authorizeToken", token.Name)
 	return err
 }
 
-func (r *Etcd) UpdateAuthorizeToken(*api.AuthorizeToken) error {
+func (r *Etcd) UpdateAuthorizeToken(ctx kubeapi.Context, token *api.AuthorizeToken) error {
 	return errors.New("not supported")
 }
 
-func (r *Etcd) DeleteAuthorizeToken(name string) error {
-	key := makeAuthorizeTokenKey(name)
+func (r *Etcd) DeleteAuthorizeToken(ctx kubeapi.Context, name string) error {
+	key := makeAuthorizeTokenKey(ctx, name)
 	err := etcderrs.InterpretDeleteError(r.Delete(key, false), "authorizeToken", name)
 	return err
 }
 
-func makeClientKey(id string) string {
+// PruneExpiredAuthorizeTokens deletes up to batchSize authorize tokens whose ExpiresIn has
+// elapsed. It is a fallback for backends where the native etcd TTL set on creation has not
+// (yet) reaped the key, and is safe to run repeatedly from a periodic job. It still lists
+// every authorize token on each call; batchSize only bounds how many deletes one call issues,
+// not the cost of finding them, since the vendored EtcdHelper has no paginated list.
+func (r *Etcd) PruneExpiredAuthorizeTokens(batchSize int) (int, error) {
+	ctx := kubeapi.NewContext()
+	list, err := r.ListAuthorizeTokens(ctx, labels.Everything())
+	if err != nil {
+		return 0, err
+	}
+	pruned := 0
+	for _, token := range list.Items {
+		if pruned >= batchSize {
+			break
+		}
+		if !tokenExpired(token.CreationTimestamp, token.ExpiresIn) {
+			continue
+		}
+		if err := r.DeleteAuthorizeToken(ctx, token.Name); err != nil && !tools.IsEtcdNotFound(err) {
+			return pruned, err
+		}
+		pruned++
+	}
+	return pruned, nil
+}
+
+// PruneExpiredAccessTokens deletes up to batchSize access tokens whose ExpiresIn has elapsed,
+// mirroring PruneExpiredAuthorizeTokens for the access token half of the flow.
+func (r *Etcd) PruneExpiredAccessTokens(batchSize int) (int, error) {
+	ctx := kubeapi.NewContext()
+	list, err := r.ListAccessTokens(ctx, labels.Everything())
+	if err != nil {
+		return 0, err
+	}
+	pruned := 0
+	for _, token := range list.Items {
+		if pruned >= batchSize {
+			break
+		}
+		if !tokenExpired(token.CreationTimestamp, token.AuthorizeToken.ExpiresIn) {
+			continue
+		}
+		if err := r.DeleteAccessToken(ctx, token.Name); err != nil && !tools.IsEtcdNotFound(err) {
+			return pruned, err
+		}
+		pruned++
+	}
+	return pruned, nil
+}
+
+func tokenExpired(created util.Time, expiresIn int64) bool {
+	if expiresIn <= 0 {
+		return false
+	}
+	return created.Add(time.Duration(expiresIn) * time.Second).Before(time.Now())
+}
+
+func makeClientKey(ctx kubeapi.Context, id string) string {
 	return "/clients/" + id
 }
 
-func (r *Etcd) GetClient(name string) (client *api.Client, err error) {
+func (r *Etcd) GetClient(ctx kubeapi.Context, name string) (client *api.Client, err error) {
 	client = &api.Client{}
-	err = etcderrs.InterpretGetError(r.ExtractObj(makeClientKey(name), client, false), "client", name)
+	err = etcderrs.InterpretGetError(r.ExtractObj(makeClientKey(ctx, name), client, false), "client", name)
 	return
 }
 
-func (r *Etcd) ListClients(selector labels.Selector) (*api.ClientList, error) {
+func (r *Etcd) ListClients(ctx kubeapi.Context, selector labels.Selector) (*api.ClientList, error) {
 	list := api.ClientList{}
 	err := r.ExtractList("/clients", &list.Items, &list.ResourceVersion)
 	if err != nil && !tools.IsEtcdNotFound(err) {
@@ -123,36 +202,50 @@ func (r *Etcd) ListClients(selector labels.Selector) (*api.ClientList, error) {
 	return &list, nil
 }
 
-func (r *Etcd) CreateClient(client *api.Client) error {
-	err := etcderrs.InterpretCreateError(r.CreateObj(makeClientKey(client.Name), client, 0), "client", client.Name)
+func (r *Etcd) CreateClient(ctx kubeapi.Context, client *api.Client) error {
+	err := etcderrs.InterpretCreateError(r.CreateObj(makeClientKey(ctx, client.Name), client, 0), "client", client.Name)
 	return err
 }
 
-func (r *Etcd) UpdateClient(_ *api.Client) error {
+func (r *Etcd) UpdateClient(ctx kubeapi.Context, client *api.Client) error {
 	return errors.New("not supported")
 }
 
-func (r *Etcd) DeleteClient(name string) error {
-	key := makeClientKey(name)
+func (r *Etcd) DeleteClient(ctx kubeapi.Context, name string) error {
+	key := makeClientKey(ctx, name)
 	err := etcderrs.InterpretDeleteError(r.Delete(key, false), "client", name)
 	return err
 }
 
-func makeClientAuthorizationKey(id string) string {
+func makeClientAuthorizationKey(ctx kubeapi.Context, id string) string {
 	return "/clientAuthorizations/" + id
 }
 
 func (r *Etcd) ClientAuthorizationID(userName, clientName string) string {
-	return fmt.Sprintf("%s:%s", userName, clientName)
+	return clientauthorization.MakeID(userName, clientName)
 }
 
-func (r *Etcd) GetClientAuthorization(name string) (client *api.ClientAuthorization, err error) {
+func (r *Etcd) GetClientAuthorization(ctx kubeapi.Context, name string) (client *api.ClientAuthorization, err error) {
 	client = &api.ClientAuthorization{}
-	err = etcderrs.InterpretGetError(r.ExtractObj(makeClientAuthorizationKey(name), client, false), "clientAuthorization", name)
+	err = etcderrs.InterpretGetError(r.ExtractObj(makeClientAuthorizationKey(ctx, name), client, false), "clientAuthorization", name)
 	return
 }
 
-func (r *Etcd) ListClientAuthorizations(label, field labels.Selector) (*api.ClientAuthorizationList, error) {
+// FindClientAuthorization implements clientauthorization.Registry
+func (r *Etcd) FindClientAuthorization(ctx kubeapi.Context, userName, clientName string) (*api.ClientAuthorization, error) {
+	found, err := r.GetClientAuthorization(ctx, clientauthorization.MakeID(userName, clientName))
+	if err == nil {
+		return found, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	// fall back to the pre-escaping ID scheme so authorizations created before MakeID
+	// started percent-encoding its components are not orphaned
+	return r.GetClientAuthorization(ctx, clientauthorization.LegacyID(userName, clientName))
+}
+
+func (r *Etcd) ListClientAuthorizations(ctx kubeapi.Context, label, field labels.Selector) (*api.ClientAuthorizationList, error) {
 	list := api.ClientAuthorizationList{}
 	err := r.ExtractList("/clients", &list.Items, &list.ResourceVersion)
 	if err != nil && !tools.IsEtcdNotFound(err) {
@@ -161,17 +254,17 @@ func (r *Etcd) ListClientAuthorizations(label, field labels.Selector) (*api.Clie
 	return &list, nil
 }
 
-func (r *Etcd) CreateClientAuthorization(client *api.ClientAuthorization) error {
-	err := etcderrs.InterpretCreateError(r.CreateObj(makeClientAuthorizationKey(client.ID), client, 0), "clientAuthorization", client.ID)
+func (r *Etcd) CreateClientAuthorization(ctx kubeapi.Context, client *api.ClientAuthorization) error {
+	err := etcderrs.InterpretCreateError(r.CreateObj(makeClientAuthorizationKey(ctx, client.ID), client, 0), "clientAuthorization", client.ID)
 	return err
 }
 
-func (r *Etcd) UpdateClientAuthorization(*api.ClientAuthorization) error {
+func (r *Etcd) UpdateClientAuthorization(ctx kubeapi.Context, client *api.ClientAuthorization) error {
 	return errors.New("not supported")
 }
 
-func (r *Etcd) DeleteClientAuthorization(name string) error {
-	key := makeClientAuthorizationKey(name)
+func (r *Etcd) DeleteClientAuthorization(ctx kubeapi.Context, name string) error {
+	key := makeClientAuthorizationKey(ctx, name)
 	err := etcderrs.InterpretDeleteError(r.Delete(key, false), "clientAuthorization", name)
 	return err
 }
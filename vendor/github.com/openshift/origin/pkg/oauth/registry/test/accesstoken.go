@@ -1,7 +1,9 @@
 package test
 
 import (
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
 
 	"github.com/openshift/origin/pkg/oauth/api"
 )
@@ -11,25 +13,31 @@ type AccessTokenRegistry struct {
 	AccessTokens         *api.AccessTokenList
 	AccessToken          *api.AccessToken
 	DeletedAccessTokenId string
+	TouchedAccessTokenId string
 }
 
-func (r *AccessTokenRegistry) ListAccessTokens(labels labels.Selector) (*api.AccessTokenList, error) {
+func (r *AccessTokenRegistry) ListAccessTokens(ctx kubeapi.Context, labels labels.Selector) (*api.AccessTokenList, error) {
 	return r.AccessTokens, r.Err
 }
 
-func (r *AccessTokenRegistry) GetAccessToken(id string) (*api.AccessToken, error) {
+func (r *AccessTokenRegistry) GetAccessToken(ctx kubeapi.Context, id string) (*api.AccessToken, error) {
 	return r.AccessToken, r.Err
 }
 
-func (r *AccessTokenRegistry) CreateAccessToken(token *api.AccessToken) error {
+func (r *AccessTokenRegistry) CreateAccessToken(ctx kubeapi.Context, token *api.AccessToken) error {
 	return r.Err
 }
 
-func (r *AccessTokenRegistry) UpdateAccessToken(token *api.AccessToken) error {
+func (r *AccessTokenRegistry) UpdateAccessToken(ctx kubeapi.Context, token *api.AccessToken) error {
 	return r.Err
 }
 
-func (r *AccessTokenRegistry) DeleteAccessToken(id string) error {
+func (r *AccessTokenRegistry) DeleteAccessToken(ctx kubeapi.Context, id string) error {
 	r.DeletedAccessTokenId = id
 	return r.Err
 }
+
+func (r *AccessTokenRegistry) TouchAccessToken(ctx kubeapi.Context, id string, when util.Time) error {
+	r.TouchedAccessTokenId = id
+	return r.Err
+}
@@ -1,6 +1,7 @@
 package test
 
 import (
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
 
 	"github.com/openshift/origin/pkg/oauth/api"
@@ -13,23 +14,23 @@ type AuthorizeTokenRegistry struct {
 	DeletedAuthorizeTokenId string
 }
 
-func (r *AuthorizeTokenRegistry) ListAuthorizeTokens(labels labels.Selector) (*api.AuthorizeTokenList, error) {
+func (r *AuthorizeTokenRegistry) ListAuthorizeTokens(ctx kubeapi.Context, labels labels.Selector) (*api.AuthorizeTokenList, error) {
 	return r.AuthorizeTokens, r.Err
 }
 
-func (r *AuthorizeTokenRegistry) GetAuthorizeToken(id string) (*api.AuthorizeToken, error) {
+func (r *AuthorizeTokenRegistry) GetAuthorizeToken(ctx kubeapi.Context, id string) (*api.AuthorizeToken, error) {
 	return r.AuthorizeToken, r.Err
 }
 
-func (r *AuthorizeTokenRegistry) CreateAuthorizeToken(token *api.AuthorizeToken) error {
+func (r *AuthorizeTokenRegistry) CreateAuthorizeToken(ctx kubeapi.Context, token *api.AuthorizeToken) error {
 	return r.Err
 }
 
-func (r *AuthorizeTokenRegistry) UpdateAuthorizeToken(token *api.AuthorizeToken) error {
+func (r *AuthorizeTokenRegistry) UpdateAuthorizeToken(ctx kubeapi.Context, token *api.AuthorizeToken) error {
 	return r.Err
 }
 
-func (r *AuthorizeTokenRegistry) DeleteAuthorizeToken(id string) error {
+func (r *AuthorizeTokenRegistry) DeleteAuthorizeToken(ctx kubeapi.Context, id string) error {
 	r.DeletedAuthorizeTokenId = id
 	return r.Err
 }
@@ -1,6 +1,7 @@
 package test
 
 import (
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
 
 	"github.com/openshift/origin/pkg/oauth/api"
@@ -13,23 +14,23 @@ type ClientRegistry struct {
 	DeletedClientId string
 }
 
-func (r *ClientRegistry) ListClients(labels labels.Selector) (*api.ClientList, error) {
+func (r *ClientRegistry) ListClients(ctx kubeapi.Context, labels labels.Selector) (*api.ClientList, error) {
 	return r.Clients, r.Err
 }
 
-func (r *ClientRegistry) GetClient(id string) (*api.Client, error) {
+func (r *ClientRegistry) GetClient(ctx kubeapi.Context, id string) (*api.Client, error) {
 	return r.Client, r.Err
 }
 
-func (r *ClientRegistry) CreateClient(client *api.Client) error {
+func (r *ClientRegistry) CreateClient(ctx kubeapi.Context, client *api.Client) error {
 	return r.Err
 }
 
-func (r *ClientRegistry) UpdateClient(client *api.Client) error {
+func (r *ClientRegistry) UpdateClient(ctx kubeapi.Context, client *api.Client) error {
 	return r.Err
 }
 
-func (r *ClientRegistry) DeleteClient(id string) error {
+func (r *ClientRegistry) DeleteClient(ctx kubeapi.Context, id string) error {
 	r.DeletedClientId = id
 	return r.Err
 }
@@ -1,11 +1,11 @@
 package test
 
 import (
-	"fmt"
-
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
 
 	"github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/registry/clientauthorization"
 )
 
 type ClientAuthorizationRegistry struct {
@@ -16,26 +16,30 @@ type ClientAuthorizationRegistry struct {
 }
 
 func (r *ClientAuthorizationRegistry) ClientAuthorizationID(userName, clientName string) string {
-	return fmt.Sprintf("%s:%s", userName, clientName)
+	return clientauthorization.MakeID(userName, clientName)
+}
+
+func (r *ClientAuthorizationRegistry) FindClientAuthorization(ctx kubeapi.Context, userName, clientName string) (*api.ClientAuthorization, error) {
+	return r.ClientAuthorization, r.Err
 }
 
-func (r *ClientAuthorizationRegistry) ListClientAuthorizations(label, field labels.Selector) (*api.ClientAuthorizationList, error) {
+func (r *ClientAuthorizationRegistry) ListClientAuthorizations(ctx kubeapi.Context, label, field labels.Selector) (*api.ClientAuthorizationList, error) {
 	return r.ClientAuthorizations, r.Err
 }
 
-func (r *ClientAuthorizationRegistry) GetClientAuthorization(id string) (*api.ClientAuthorization, error) {
+func (r *ClientAuthorizationRegistry) GetClientAuthorization(ctx kubeapi.Context, id string) (*api.ClientAuthorization, error) {
 	return r.ClientAuthorization, r.Err
 }
 
-func (r *ClientAuthorizationRegistry) CreateClientAuthorization(grant *api.ClientAuthorization) error {
+func (r *ClientAuthorizationRegistry) CreateClientAuthorization(ctx kubeapi.Context, grant *api.ClientAuthorization) error {
 	return r.Err
 }
 
-func (r *ClientAuthorizationRegistry) UpdateClientAuthorization(grant *api.ClientAuthorization) error {
+func (r *ClientAuthorizationRegistry) UpdateClientAuthorization(ctx kubeapi.Context, grant *api.ClientAuthorization) error {
 	return r.Err
 }
 
-func (r *ClientAuthorizationRegistry) DeleteClientAuthorization(id string) error {
+func (r *ClientAuthorizationRegistry) DeleteClientAuthorization(ctx kubeapi.Context, id string) error {
 	r.DeletedClientAuthorizationId = id
 	return r.Err
 }
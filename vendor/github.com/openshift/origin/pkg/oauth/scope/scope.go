@@ -5,6 +5,25 @@ import (
 	"strings"
 )
 
+const (
+	// UserFull grants the same access as the user who authorized the token.
+	UserFull = "user:full"
+	// UserInfo grants read-only access to the authorizing user's identity, enough for CLI
+	// automation that only needs to know who it is running as.
+	UserInfo = "user:info"
+)
+
+// DefaultSupportedScopes lists the scopes the server knows how to enforce. A client requesting
+// a scope outside this list should be rejected rather than silently granted broader access.
+func DefaultSupportedScopes() []string {
+	return []string{UserFull, UserInfo}
+}
+
+// IsSupported reports whether every element of requested is a scope the server understands.
+func IsSupported(requested []string) bool {
+	return Covers(DefaultSupportedScopes(), requested)
+}
+
 func Split(scope string) []string {
 	scope = strings.TrimSpace(scope)
 	if scope == "" {
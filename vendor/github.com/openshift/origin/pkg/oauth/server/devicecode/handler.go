@@ -0,0 +1,222 @@
+// Package devicecode implements the device authorization grant (RFC 8628), letting a client
+// running on a host with no browser - a CLI on a headless server, for example - obtain an
+// access token by directing its user to enter a short code on another device instead of
+// handling a redirect itself. The vendored osin OAuth2 server only knows the grant types fixed
+// at its own AllowedAccessTypes, so this flow is implemented as hand-rolled endpoints that
+// mint tokens directly through the access token registry, the same way selfaccesstoken does.
+package devicecode
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/generator"
+	"github.com/openshift/origin/pkg/oauth/registry/accesstoken"
+	"github.com/openshift/origin/pkg/oauth/registry/client"
+	"github.com/openshift/origin/pkg/oauth/scope"
+)
+
+// deviceGrantType is the grant_type value a device uses to poll the token endpoint, per
+// RFC 8628.
+const deviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// codeTTL is how long a device code and its paired user code remain valid for approval.
+const codeTTL = 10 * time.Minute
+
+// pollInterval is the minimum number of seconds a device is told to wait between polls.
+const pollInterval = 5
+
+// accessTokenExpireSeconds is how long a token issued by this flow remains valid.
+const accessTokenExpireSeconds = 86400
+
+// Handler serves the device and token endpoints of the device authorization grant.
+type Handler struct {
+	clients      client.Registry
+	accessTokens accesstoken.Registry
+	tokens       generator.Generator
+	store        *Store
+
+	// verificationPath is the path of the page a user is told to visit to enter their user
+	// code, resolved to an absolute URL against each code request.
+	verificationPath string
+}
+
+// New returns a Handler that validates clients against clients, mints tokens into
+// accessTokens once store records a user's approval, and tells devices to direct their user to
+// verificationPath.
+func New(clients client.Registry, accessTokens accesstoken.Registry, store *Store, verificationPath string) *Handler {
+	return &Handler{
+		clients:          clients,
+		accessTokens:     accessTokens,
+		tokens:           generator.New(generator.DefaultPrefix, generator.DefaultLength),
+		store:            store,
+		verificationPath: verificationPath,
+	}
+}
+
+// Install registers the handler's endpoints under prefix, which MUST NOT end in a slash.
+func (h *Handler) Install(mux Mux, prefix string) {
+	prefix = strings.TrimRight(prefix, "/")
+	mux.HandleFunc(prefix+"/code", h.handleCode)
+	mux.HandleFunc(prefix+"/token", h.handleToken)
+}
+
+type codeResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// handleCode issues a new device code and user code for a client.
+func (h *Handler) handleCode(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "POST" {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	req.ParseForm()
+
+	clientID := req.FormValue("client_id")
+	if len(clientID) == 0 {
+		http.Error(w, "client_id is required", http.StatusBadRequest)
+		return
+	}
+	if _, err := h.clients.GetClient(kapi.NewContext(), clientID); err != nil {
+		http.Error(w, "invalid client_id", http.StatusBadRequest)
+		return
+	}
+	requestedScope := req.FormValue("scope")
+
+	deviceCode, err := h.tokens.Generate()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	userCode, err := generateUserCode()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	h.store.New(deviceCode, userCode, clientID, requestedScope, codeTTL)
+
+	verificationURI, err := absoluteURL(req, h.verificationPath)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(codeResponse{
+		DeviceCode:              deviceCode,
+		UserCode:                userCode,
+		VerificationURI:         verificationURI,
+		VerificationURIComplete: verificationURI + "?user_code=" + url.QueryEscape(userCode),
+		ExpiresIn:               int(codeTTL.Seconds()),
+		Interval:                pollInterval,
+	})
+}
+
+// absoluteURL resolves path to an absolute URL using the scheme and host of req, the same way
+// pkg/auth/server/login derives the base URL it redirects back to.
+func absoluteURL(req *http.Request, path string) (string, error) {
+	uri, err := url.Parse(path)
+	if err != nil {
+		return "", err
+	}
+	uri.Scheme, uri.Host = req.URL.Scheme, req.URL.Host
+	if len(uri.Host) == 0 {
+		uri.Host = req.Host
+	}
+	if len(uri.Scheme) == 0 {
+		uri.Scheme = "http"
+	}
+	return uri.String(), nil
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token,omitempty"`
+	TokenType   string `json:"token_type,omitempty"`
+	ExpiresIn   int64  `json:"expires_in,omitempty"`
+	Scope       string `json:"scope,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// handleToken lets a device poll for the token its user code was approved for. The error
+// values returned match RFC 8628 so an existing device-flow client library can drive the poll
+// loop without knowing this is a hand-rolled implementation.
+func (h *Handler) handleToken(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "POST" {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	req.ParseForm()
+
+	if req.FormValue("grant_type") != deviceGrantType {
+		writeTokenError(w, "unsupported_grant_type")
+		return
+	}
+	deviceCode := req.FormValue("device_code")
+	if len(deviceCode) == 0 {
+		writeTokenError(w, "invalid_request")
+		return
+	}
+
+	status, clientID, requestedScope, user := h.store.Poll(deviceCode)
+	switch status {
+	case StatusExpired:
+		writeTokenError(w, "expired_token")
+		return
+	case StatusDenied:
+		writeTokenError(w, "access_denied")
+		return
+	case StatusPending:
+		writeTokenError(w, "authorization_pending")
+		return
+	}
+
+	accessToken, err := h.tokens.Generate()
+	if err != nil {
+		writeTokenError(w, "server_error")
+		return
+	}
+	token := &api.AccessToken{
+		JSONBase: kapi.JSONBase{
+			CreationTimestamp: util.Now(),
+		},
+		Name: accessToken,
+		AuthorizeToken: api.AuthorizeToken{
+			ClientName: clientID,
+			ExpiresIn:  accessTokenExpireSeconds,
+			Scopes:     scope.Split(requestedScope),
+			UserName:   user.GetName(),
+			UserUID:    user.GetUID(),
+		},
+	}
+	if err := h.accessTokens.CreateAccessToken(kapi.NewContext(), token); err != nil {
+		writeTokenError(w, "server_error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "bearer",
+		ExpiresIn:   token.AuthorizeToken.ExpiresIn,
+		Scope:       scope.Join(token.AuthorizeToken.Scopes),
+	})
+}
+
+func writeTokenError(w http.ResponseWriter, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(tokenResponse{Error: code})
+}
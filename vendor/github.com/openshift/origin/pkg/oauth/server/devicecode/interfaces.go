@@ -0,0 +1,9 @@
+package devicecode
+
+import "net/http"
+
+// Mux is an object that can register http handlers.
+type Mux interface {
+	Handle(pattern string, handler http.Handler)
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+}
@@ -0,0 +1,144 @@
+package devicecode
+
+import (
+	"sync"
+	"time"
+
+	"github.com/openshift/origin/pkg/auth/api"
+)
+
+// pending is the server-side record of one device authorization request, from the moment a
+// device asks for a code until the code is redeemed, denied, or expires.
+type pending struct {
+	deviceCode string
+	userCode   string
+	clientID   string
+	scope      string
+	expiresAt  time.Time
+
+	// user is set once someone has approved this request on the verification page.
+	user api.UserInfo
+	// denied is set if the user rejected the request instead of approving it.
+	denied bool
+}
+
+func (p *pending) expired() bool {
+	return time.Now().After(p.expiresAt)
+}
+
+// Status describes the current state of a device authorization request, as seen by the
+// device polling the token endpoint.
+type Status int
+
+const (
+	// StatusPending means the user has not yet visited the verification page and
+	// approved or denied the request.
+	StatusPending Status = iota
+	// StatusApproved means the user approved the request; a token can now be issued.
+	StatusApproved
+	// StatusDenied means the user rejected the request.
+	StatusDenied
+	// StatusExpired means the device code was not approved before its expiry, or is unknown.
+	StatusExpired
+)
+
+// Store holds pending device authorization requests in memory, keyed by both the device code
+// the polling device holds and the user code a human enters on the verification page. Device
+// codes live for a few minutes at most, so there is no need to persist them the way access
+// tokens are.
+type Store struct {
+	lock     sync.Mutex
+	byDevice map[string]*pending
+	byUser   map[string]*pending
+}
+
+// NewStore returns an empty Store.
+func NewStore() *Store {
+	return &Store{
+		byDevice: map[string]*pending{},
+		byUser:   map[string]*pending{},
+	}
+}
+
+// New records a new pending device authorization request for clientID and scope, identified
+// by deviceCode and userCode, that expires after ttl.
+func (s *Store) New(deviceCode, userCode, clientID, scope string, ttl time.Duration) {
+	p := &pending{
+		deviceCode: deviceCode,
+		userCode:   userCode,
+		clientID:   clientID,
+		scope:      scope,
+		expiresAt:  time.Now().Add(ttl),
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.byDevice[deviceCode] = p
+	s.byUser[userCode] = p
+}
+
+// Lookup returns the client and scope a still-pending userCode was requested for, so the
+// verification page can describe what is being authorized before asking the user to decide.
+func (s *Store) Lookup(userCode string) (clientID, scope string, ok bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	p, found := s.byUser[userCode]
+	if !found || p.expired() {
+		return "", "", false
+	}
+	return p.clientID, p.scope, true
+}
+
+// Approve marks the pending request identified by userCode as approved by user. It returns
+// false if userCode does not identify a still-pending request.
+func (s *Store) Approve(userCode string, user api.UserInfo) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	p, ok := s.byUser[userCode]
+	if !ok || p.expired() || p.denied || p.user != nil {
+		return false
+	}
+	p.user = user
+	return true
+}
+
+// Deny marks the pending request identified by userCode as denied.
+func (s *Store) Deny(userCode string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	p, ok := s.byUser[userCode]
+	if !ok || p.expired() || p.denied || p.user != nil {
+		return false
+	}
+	p.denied = true
+	return true
+}
+
+// Poll reports the current status of the request identified by deviceCode. Once a terminal
+// status (anything but StatusPending) is returned, the request is removed from the store, so
+// a device code can be redeemed for a token at most once. clientID, scope, and user are only
+// meaningful when the returned status is StatusApproved.
+func (s *Store) Poll(deviceCode string) (status Status, clientID, scope string, user api.UserInfo) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	p, ok := s.byDevice[deviceCode]
+	if !ok || p.expired() {
+		return StatusExpired, "", "", nil
+	}
+	switch {
+	case p.denied:
+		s.remove(p)
+		return StatusDenied, "", "", nil
+	case p.user != nil:
+		s.remove(p)
+		return StatusApproved, p.clientID, p.scope, p.user
+	default:
+		return StatusPending, "", "", nil
+	}
+}
+
+func (s *Store) remove(p *pending) {
+	delete(s.byDevice, p.deviceCode)
+	delete(s.byUser, p.userCode)
+}
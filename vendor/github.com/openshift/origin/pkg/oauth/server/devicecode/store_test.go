@@ -0,0 +1,66 @@
+package devicecode
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift/origin/pkg/auth/api"
+)
+
+func TestApproveThenPoll(t *testing.T) {
+	store := NewStore()
+	store.New("device1", "user1", "client1", "user:info", time.Hour)
+
+	if status, _, _, _ := store.Poll("device1"); status != StatusPending {
+		t.Fatalf("expected StatusPending, got %v", status)
+	}
+
+	user := &api.DefaultUserInfo{Name: "bob"}
+	if !store.Approve("user1", user) {
+		t.Fatalf("expected Approve to succeed")
+	}
+
+	status, clientID, scope, approvedUser := store.Poll("device1")
+	if status != StatusApproved {
+		t.Fatalf("expected StatusApproved, got %v", status)
+	}
+	if clientID != "client1" || scope != "user:info" || approvedUser.GetName() != "bob" {
+		t.Errorf("unexpected poll result: %q %q %v", clientID, scope, approvedUser)
+	}
+
+	// A device code is redeemable only once.
+	if status, _, _, _ := store.Poll("device1"); status != StatusExpired {
+		t.Errorf("expected a second poll to report StatusExpired, got %v", status)
+	}
+}
+
+func TestDeny(t *testing.T) {
+	store := NewStore()
+	store.New("device1", "user1", "client1", "", time.Hour)
+
+	if !store.Deny("user1") {
+		t.Fatalf("expected Deny to succeed")
+	}
+	if status, _, _, _ := store.Poll("device1"); status != StatusDenied {
+		t.Errorf("expected StatusDenied, got %v", status)
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	store := NewStore()
+	store.New("device1", "user1", "client1", "", -time.Minute)
+
+	if status, _, _, _ := store.Poll("device1"); status != StatusExpired {
+		t.Errorf("expected StatusExpired, got %v", status)
+	}
+	if store.Approve("user1", &api.DefaultUserInfo{Name: "bob"}) {
+		t.Errorf("expected Approve to fail for an expired code")
+	}
+}
+
+func TestLookupUnknownCode(t *testing.T) {
+	store := NewStore()
+	if _, _, ok := store.Lookup("nope"); ok {
+		t.Errorf("expected Lookup to fail for an unknown user code")
+	}
+}
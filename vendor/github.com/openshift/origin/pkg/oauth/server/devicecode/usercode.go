@@ -0,0 +1,36 @@
+package devicecode
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// userCodeCharset excludes characters that are easily confused when read off a screen and
+// typed on another device (no 0/O, 1/I/L, etc).
+const userCodeCharset = "BCDFGHJKMPQRTVWXY2346789"
+
+// userCodeGroupLength is the number of characters between hyphens in a generated user code,
+// e.g. "WDJB-MJHT".
+const userCodeGroupLength = 4
+
+// userCodeGroups is the number of hyphen-separated groups in a generated user code.
+const userCodeGroups = 2
+
+// generateUserCode returns a short, human-typeable code for a user to enter on the
+// verification page.
+func generateUserCode() (string, error) {
+	length := userCodeGroupLength * userCodeGroups
+	raw := make([]byte, length)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("unable to generate user code: %v", err)
+	}
+
+	code := make([]byte, 0, length+userCodeGroups-1)
+	for i, b := range raw {
+		if i > 0 && i%userCodeGroupLength == 0 {
+			code = append(code, '-')
+		}
+		code = append(code, userCodeCharset[int(b)%len(userCodeCharset)])
+	}
+	return string(code), nil
+}
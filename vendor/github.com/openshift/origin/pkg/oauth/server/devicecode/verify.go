@@ -0,0 +1,178 @@
+package devicecode
+
+import (
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/golang/glog"
+
+	"github.com/openshift/origin/pkg/auth/api"
+	"github.com/openshift/origin/pkg/auth/authenticator"
+	"github.com/openshift/origin/pkg/auth/oauth/handlers"
+)
+
+// CSRF generates a token to embed in the rendered verification form, binding it to the
+// session the form was rendered for.
+type CSRF interface {
+	Generate() (string, error)
+	Check(string) (bool, error)
+}
+
+// CSRFStore binds a CSRF to the session carried by a particular request.
+type CSRFStore interface {
+	New(w http.ResponseWriter, req *http.Request) CSRF
+}
+
+// VerifyFormRenderer renders the page shown to a user asked to approve or deny a device's
+// authorization request.
+type VerifyFormRenderer interface {
+	Render(form VerifyForm, w http.ResponseWriter, req *http.Request)
+}
+
+type VerifyForm struct {
+	Error  string
+	Values VerifyFormValues
+}
+
+type VerifyFormValues struct {
+	CSRF     string
+	UserCode string
+	ClientID string
+	Scopes   string
+}
+
+// Verify serves the user-facing verification page: it asks an authenticated user to enter the
+// code shown on their device, then to approve or deny it.
+type Verify struct {
+	auth   authenticator.Request
+	needed handlers.AuthenticationHandler
+	csrf   CSRFStore
+	store  *Store
+	render VerifyFormRenderer
+}
+
+// NewVerify returns a Verify page backed by store. Requests from a user auth cannot identify
+// are handed to needed, the same way an unauthenticated authorize request is.
+func NewVerify(auth authenticator.Request, needed handlers.AuthenticationHandler, csrf CSRFStore, store *Store, render VerifyFormRenderer) *Verify {
+	return &Verify{auth: auth, needed: needed, csrf: csrf, store: store, render: render}
+}
+
+// Install registers the verification page into mux at path.
+func (v *Verify) Install(mux Mux, path string) {
+	mux.HandleFunc(path, v.ServeHTTP)
+}
+
+func (v *Verify) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	user, ok, err := v.auth.AuthenticateRequest(req)
+	if err != nil {
+		v.needed.AuthenticationError(err, w, req)
+		return
+	}
+	if !ok {
+		v.needed.AuthenticationNeeded(w, req)
+		return
+	}
+
+	switch req.Method {
+	case "GET":
+		v.handleVerifyForm(w, req)
+	case "POST":
+		v.handleVerify(w, req, user)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (v *Verify) handleVerifyForm(w http.ResponseWriter, req *http.Request) {
+	form := VerifyForm{}
+	userCode := strings.TrimSpace(req.URL.Query().Get("user_code"))
+	if len(userCode) > 0 {
+		clientID, scopes, ok := v.store.Lookup(userCode)
+		if !ok {
+			form.Error = "That code is invalid or has expired."
+		} else {
+			form.Values.UserCode = userCode
+			form.Values.ClientID = clientID
+			form.Values.Scopes = scopes
+		}
+	}
+
+	csrf, err := v.csrf.New(w, req).Generate()
+	if err != nil {
+		glog.Errorf("Unable to generate CSRF token: %v", err)
+	}
+	form.Values.CSRF = csrf
+
+	v.render.Render(form, w, req)
+}
+
+func (v *Verify) handleVerify(w http.ResponseWriter, req *http.Request, user api.UserInfo) {
+	if ok, err := v.csrf.New(w, req).Check(req.FormValue("csrf")); !ok || err != nil {
+		if err != nil {
+			glog.Errorf("Unable to check CSRF token: %v", err)
+		}
+		v.render.Render(VerifyForm{Error: "Token expired, please try again."}, w, req)
+		return
+	}
+
+	userCode := strings.TrimSpace(req.FormValue("user_code"))
+	clientID, scopes, ok := v.store.Lookup(userCode)
+	if !ok {
+		v.render.Render(VerifyForm{Error: "That code is invalid or has expired."}, w, req)
+		return
+	}
+
+	if len(req.FormValue("deny")) > 0 {
+		v.store.Deny(userCode)
+		v.render.Render(VerifyForm{Error: "Access denied."}, w, req)
+		return
+	}
+	if !v.store.Approve(userCode, user) {
+		v.render.Render(VerifyForm{Error: "That code is invalid or has expired."}, w, req)
+		return
+	}
+
+	form := VerifyForm{
+		Values: VerifyFormValues{
+			UserCode: userCode,
+			ClientID: clientID,
+			Scopes:   scopes,
+		},
+	}
+	form.Error = "Device authorized. You may close this page."
+	v.render.Render(form, w, req)
+}
+
+var DefaultVerifyFormRenderer = verifyTemplateRenderer{template.Must(template.New("verifyForm").Parse(verifyTemplateDefault))}
+
+type verifyTemplateRenderer struct {
+	template *template.Template
+}
+
+func (r verifyTemplateRenderer) Render(form VerifyForm, w http.ResponseWriter, req *http.Request) {
+	w.Header().Add("Content-Type", "text/html")
+	w.WriteHeader(http.StatusOK)
+	if err := r.template.Execute(w, form); err != nil {
+		glog.Errorf("Unable to render device verification template: %v", err)
+	}
+}
+
+const verifyTemplateDefault = `
+{{ if .Error }}<div class="message">{{ .Error }}</div>{{ end }}
+{{ if .Values.ClientID }}
+<p>{{ .Values.ClientID }} is requesting access to your account.</p>
+<p>Requested permissions: {{ .Values.Scopes }}</p>
+<form action="" method="POST">
+  <input type="hidden" name="user_code" value="{{ .Values.UserCode }}">
+  <input type="hidden" name="csrf" value="{{ .Values.CSRF }}">
+  <input type="submit" name="approve" value="Allow">
+  <input type="submit" name="deny" value="Deny">
+</form>
+{{ else }}
+<form action="" method="GET">
+  <label>Code: <input type="text" name="user_code" value="{{ .Values.UserCode }}"></label>
+  <input type="submit" value="Submit">
+</form>
+{{ end }}
+`
@@ -6,6 +6,9 @@ import (
 
 	"github.com/RangelReale/osin"
 	"github.com/golang/glog"
+
+	"github.com/openshift/origin/pkg/oauth/generator"
+	"github.com/openshift/origin/pkg/oauth/jwt"
 )
 
 type Server struct {
@@ -16,14 +19,31 @@ type Server struct {
 }
 
 func New(config *osin.ServerConfig, storage osin.Storage, authorize AuthorizeHandler, access AccessHandler) *Server {
+	server := osin.NewServer(config, storage)
+	// Use our own versioned token format instead of osin's default raw base64-encoded UUID,
+	// so the format can change later (hashed storage, JWTs) without breaking tokens issued
+	// under the current one.
+	server.AuthorizeTokenGen = authorizeTokenGen{generator.New(generator.DefaultPrefix, generator.DefaultLength)}
+	server.AccessTokenGen = accessTokenGen{generator.New(generator.DefaultPrefix, generator.DefaultLength)}
 	return &Server{
 		config:    config,
-		server:    osin.NewServer(config, storage),
+		server:    server,
 		authorize: authorize,
 		access:    access,
 	}
 }
 
+// UseJWTAccessTokens switches s to issue access tokens as signed JWTs, keyed by keys, rather
+// than opaque values looked up through storage. It is opt-in: a resource server that wants to
+// verify tokens itself, without querying back through this server, needs the issuer to be
+// running in this mode. Refresh tokens are unaffected and remain opaque.
+func (s *Server) UseJWTAccessTokens(keys *jwt.KeyStore) {
+	s.server.AccessTokenGen = jwtAccessTokenGen{
+		keys:    keys,
+		refresh: generator.New(generator.DefaultPrefix, generator.DefaultLength),
+	}
+}
+
 // Install registers the Server OAuth handlers into a mux. It is expected that the
 // provided prefix will serve all operations. Path MUST NOT end in a slash.
 func (s *Server) Install(mux Mux, paths ...string) {
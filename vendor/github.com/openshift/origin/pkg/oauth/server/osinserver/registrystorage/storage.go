@@ -63,6 +63,10 @@ func (w *clientWrapper) GetUserData() interface{} {
 	return nil
 }
 
+func (w *clientWrapper) GetTrusted() bool {
+	return w.client.Trusted
+}
+
 // Clone the storage if needed. For example, using mgo, you can clone the session with session.Clone
 // to avoid concurrent access problems.
 // This is to avoid cloning the connection at each method access.
@@ -77,7 +81,7 @@ func (s *storage) Close() {
 
 // GetClient loads the client by id (client_id)
 func (s *storage) GetClient(id string) (osin.Client, error) {
-	c, err := s.client.GetClient(id)
+	c, err := s.client.GetClient(kapi.NewContext(), id)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			return nil, nil
@@ -103,14 +107,14 @@ func (s *storage) SaveAuthorize(data *osin.AuthorizeData) error {
 	if err := s.user.ConvertToAuthorizeToken(data.UserData, token); err != nil {
 		return err
 	}
-	return s.authorizetoken.CreateAuthorizeToken(token)
+	return s.authorizetoken.CreateAuthorizeToken(kapi.NewContext(), token)
 }
 
 // LoadAuthorize looks up AuthorizeData by a code.
 // Client information MUST be loaded together.
 // Optionally can return error if expired.
 func (s *storage) LoadAuthorize(code string) (*osin.AuthorizeData, error) {
-	authorize, err := s.authorizetoken.GetAuthorizeToken(code)
+	authorize, err := s.authorizetoken.GetAuthorizeToken(kapi.NewContext(), code)
 	if err != nil {
 		return nil, err
 	}
@@ -118,7 +122,7 @@ func (s *storage) LoadAuthorize(code string) (*osin.AuthorizeData, error) {
 	if err != nil {
 		return nil, err
 	}
-	client, err := s.client.GetClient(authorize.ClientName)
+	client, err := s.client.GetClient(kapi.NewContext(), authorize.ClientName)
 	if err != nil {
 		return nil, err
 	}
@@ -138,7 +142,7 @@ func (s *storage) LoadAuthorize(code string) (*osin.AuthorizeData, error) {
 // RemoveAuthorize revokes or deletes the authorization code.
 func (s *storage) RemoveAuthorize(code string) error {
 	// TODO: return no error if registry returns IsNotFound
-	return s.authorizetoken.DeleteAuthorizeToken(code)
+	return s.authorizetoken.DeleteAuthorizeToken(kapi.NewContext(), code)
 }
 
 // SaveAccess writes AccessData.
@@ -160,14 +164,14 @@ func (s *storage) SaveAccess(data *osin.AccessData) error {
 	if err := s.user.ConvertToAccessToken(data.UserData, token); err != nil {
 		return err
 	}
-	return s.accesstoken.CreateAccessToken(token)
+	return s.accesstoken.CreateAccessToken(kapi.NewContext(), token)
 }
 
 // LoadAccess retrieves access data by token. Client information MUST be loaded together.
 // AuthorizeData and AccessData DON'T NEED to be loaded if not easily available.
 // Optionally can return error if expired.
 func (s *storage) LoadAccess(token string) (*osin.AccessData, error) {
-	access, err := s.accesstoken.GetAccessToken(token)
+	access, err := s.accesstoken.GetAccessToken(kapi.NewContext(), token)
 	if err != nil {
 		return nil, err
 	}
@@ -175,7 +179,7 @@ func (s *storage) LoadAccess(token string) (*osin.AccessData, error) {
 	if err != nil {
 		return nil, err
 	}
-	client, err := s.client.GetClient(access.AuthorizeToken.ClientName)
+	client, err := s.client.GetClient(kapi.NewContext(), access.AuthorizeToken.ClientName)
 	if err != nil {
 		return nil, err
 	}
@@ -195,7 +199,7 @@ func (s *storage) LoadAccess(token string) (*osin.AccessData, error) {
 // RemoveAccess revokes or deletes an AccessData.
 func (s *storage) RemoveAccess(token string) error {
 	// TODO: return no error if registry returns IsNotFound
-	return s.accesstoken.DeleteAccessToken(token)
+	return s.accesstoken.DeleteAccessToken(kapi.NewContext(), token)
 }
 
 // LoadRefresh retrieves refresh AccessData. Client information MUST be loaded together.
@@ -0,0 +1,76 @@
+package osinserver
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RangelReale/osin"
+
+	"github.com/openshift/origin/pkg/auth/api"
+	"github.com/openshift/origin/pkg/oauth/generator"
+	"github.com/openshift/origin/pkg/oauth/jwt"
+)
+
+// authorizeTokenGen adapts a generator.Generator to osin.AuthorizeTokenGen, so authorize
+// codes get the same versioned token format as access tokens.
+type authorizeTokenGen struct {
+	generator.Generator
+}
+
+// GenerateAuthorizeToken implements osin.AuthorizeTokenGen.
+func (g authorizeTokenGen) GenerateAuthorizeToken(data *osin.AuthorizeData) (string, error) {
+	return g.Generate()
+}
+
+// accessTokenGen adapts a generator.Generator to osin.AccessTokenGen. The refresh token,
+// when requested, is drawn from the same generator as the access token.
+type accessTokenGen struct {
+	generator.Generator
+}
+
+// GenerateAccessToken implements osin.AccessTokenGen.
+func (g accessTokenGen) GenerateAccessToken(data *osin.AccessData, generateRefresh bool) (accessToken, refreshToken string, err error) {
+	if accessToken, err = g.Generate(); err != nil {
+		return "", "", err
+	}
+	if generateRefresh {
+		if refreshToken, err = g.Generate(); err != nil {
+			return "", "", err
+		}
+	}
+	return accessToken, refreshToken, nil
+}
+
+// jwtAccessTokenGen implements osin.AccessTokenGen by signing a JWT that carries the
+// requesting user's identity and scope, so it can be verified without a round trip through
+// this server's storage. Refresh tokens are never handed to a resource server for
+// verification, so they keep the opaque format from refresh.
+type jwtAccessTokenGen struct {
+	keys    *jwt.KeyStore
+	refresh generator.Generator
+}
+
+// GenerateAccessToken implements osin.AccessTokenGen.
+func (g jwtAccessTokenGen) GenerateAccessToken(data *osin.AccessData, generateRefresh bool) (accessToken, refreshToken string, err error) {
+	user, ok := data.UserData.(api.UserInfo)
+	if !ok {
+		return "", "", errors.New("jwt access token: no user information available to sign")
+	}
+
+	claims := jwt.Claims{
+		UserName:  user.GetName(),
+		UserUID:   user.GetUID(),
+		Scope:     data.Scope,
+		ExpiresAt: data.CreatedAt.Add(time.Duration(data.ExpiresIn) * time.Second).Unix(),
+	}
+	if accessToken, err = g.keys.Sign(claims); err != nil {
+		return "", "", err
+	}
+
+	if generateRefresh {
+		if refreshToken, err = g.refresh.Generate(); err != nil {
+			return "", "", err
+		}
+	}
+	return accessToken, refreshToken, nil
+}
@@ -0,0 +1,106 @@
+// Package selfaccesstoken serves a self-service endpoint that lets a caller list and delete
+// their own OAuth AccessTokens, identified by the bearer token on the request itself rather
+// than by a name they supply. It exists because the generic AccessToken RESTStorage is
+// reached through the vendored apiserver, which does not thread any per-request identity
+// into the context a RESTStorage sees - so "delete my own tokens" can't be enforced there.
+package selfaccesstoken
+
+import (
+	"encoding/json"
+	"net/http"
+
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+
+	"github.com/openshift/origin/pkg/auth/authenticator"
+	"github.com/openshift/origin/pkg/oauth/registry/accesstoken"
+)
+
+// Handler serves GET (list) and DELETE (revoke) against the AccessTokens belonging to
+// whichever user auth identifies the caller as, so a user can enumerate or log out of
+// their own sessions without needing broader access-token permissions.
+type Handler struct {
+	auth     authenticator.Request
+	registry accesstoken.Registry
+}
+
+// New returns a Handler that identifies callers with auth and reads/deletes their tokens
+// through registry.
+func New(auth authenticator.Request, registry accesstoken.Registry) *Handler {
+	return &Handler{auth: auth, registry: registry}
+}
+
+// Install registers the handler into mux at path.
+func (h *Handler) Install(mux Mux, path string) {
+	mux.HandleFunc(path, h.ServeHTTP)
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	user, ok, err := h.auth.AuthenticateRequest(req)
+	if err != nil || !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	switch req.Method {
+	case "GET":
+		h.list(w, user.GetName())
+	case "DELETE":
+		h.delete(w, req, user.GetName())
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// list responds with the AccessTokens whose AuthorizeToken.UserName matches userName.
+func (h *Handler) list(w http.ResponseWriter, userName string) {
+	tokens, err := h.registry.ListAccessTokens(kubeapi.NewContext(), labels.Everything())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	mine := tokens.Items[:0]
+	for _, token := range tokens.Items {
+		if token.AuthorizeToken.UserName == userName {
+			mine = append(mine, token)
+		}
+	}
+	tokens.Items = mine
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(tokens); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// delete removes the AccessToken named by the "name" query parameter, but only if it
+// belongs to userName. A token belonging to someone else is reported as not found, so a
+// caller can't use this endpoint to discover other users' token names.
+func (h *Handler) delete(w http.ResponseWriter, req *http.Request, userName string) {
+	name := req.URL.Query().Get("name")
+	if len(name) == 0 {
+		http.Error(w, "name is required", http.StatusBadRequest)
+		return
+	}
+
+	token, err := h.registry.GetAccessToken(kubeapi.NewContext(), name)
+	if errors.IsNotFound(err) {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if token.AuthorizeToken.UserName != userName {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	if err := h.registry.DeleteAccessToken(kubeapi.NewContext(), name); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
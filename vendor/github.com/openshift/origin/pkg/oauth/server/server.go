@@ -22,7 +22,7 @@ func NewServer(helper tools.EtcdHelper) *Server {
 		storage: map[string]apiserver.RESTStorage{
 			"accessTokens":         accesstoken.NewREST(registry),
 			"authorizeTokens":      authorizetoken.NewREST(registry),
-			"clients":              client.NewREST(registry),
+			"clients":              client.NewREST(registry, registry),
 			"clientAuthorizations": clientauthorization.NewREST(registry),
 		},
 	}
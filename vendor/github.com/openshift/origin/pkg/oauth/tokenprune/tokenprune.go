@@ -0,0 +1,50 @@
+// Package tokenprune periodically deletes expired OAuth authorize and access tokens, as a
+// fallback for backends where etcd's native TTL on token keys hasn't (yet) reaped them.
+package tokenprune
+
+import (
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	"github.com/golang/glog"
+)
+
+// Registry is implemented by a token registry capable of deleting its own expired tokens in
+// bounded batches.
+type Registry interface {
+	PruneExpiredAuthorizeTokens(batchSize int) (int, error)
+	PruneExpiredAccessTokens(batchSize int) (int, error)
+}
+
+// Controller periodically prunes expired tokens from a Registry.
+type Controller struct {
+	registry  Registry
+	batchSize int
+}
+
+// NewController creates a new Controller. batchSize bounds how many expired tokens of each
+// type are deleted per sweep.
+func NewController(registry Registry, batchSize int) *Controller {
+	return &Controller{
+		registry:  registry,
+		batchSize: batchSize,
+	}
+}
+
+// Run begins periodically sweeping for expired tokens, every period, until the process exits.
+func (c *Controller) Run(period time.Duration) {
+	go util.Forever(c.prune, period)
+}
+
+func (c *Controller) prune() {
+	if pruned, err := c.registry.PruneExpiredAuthorizeTokens(c.batchSize); err != nil {
+		glog.Errorf("Error pruning expired OAuth authorize tokens: %v", err)
+	} else if pruned > 0 {
+		glog.V(4).Infof("Pruned %d expired OAuth authorize tokens", pruned)
+	}
+	if pruned, err := c.registry.PruneExpiredAccessTokens(c.batchSize); err != nil {
+		glog.Errorf("Error pruning expired OAuth access tokens: %v", err)
+	} else if pruned > 0 {
+		glog.V(4).Infof("Pruned %d expired OAuth access tokens", pruned)
+	}
+}
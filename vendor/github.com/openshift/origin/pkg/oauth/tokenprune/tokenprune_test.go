@@ -0,0 +1,44 @@
+package tokenprune
+
+import (
+	"errors"
+	"testing"
+)
+
+type fakeRegistry struct {
+	prunedAuthorize, prunedAccess   int
+	authorizeErr, accessErr         error
+	sawAuthorizeCall, sawAccessCall bool
+}
+
+func (r *fakeRegistry) PruneExpiredAuthorizeTokens(batchSize int) (int, error) {
+	r.sawAuthorizeCall = true
+	return r.prunedAuthorize, r.authorizeErr
+}
+
+func (r *fakeRegistry) PruneExpiredAccessTokens(batchSize int) (int, error) {
+	r.sawAccessCall = true
+	return r.prunedAccess, r.accessErr
+}
+
+func TestPrunePrunesBothTokenTypes(t *testing.T) {
+	registry := &fakeRegistry{prunedAuthorize: 2, prunedAccess: 3}
+	c := NewController(registry, 100)
+
+	c.prune()
+
+	if !registry.sawAuthorizeCall || !registry.sawAccessCall {
+		t.Errorf("expected both token types to be pruned: %#v", registry)
+	}
+}
+
+func TestPruneContinuesAfterAuthorizeError(t *testing.T) {
+	registry := &fakeRegistry{authorizeErr: errors.New("etcd unavailable"), prunedAccess: 1}
+	c := NewController(registry, 100)
+
+	c.prune()
+
+	if !registry.sawAccessCall {
+		t.Errorf("expected access tokens to still be pruned after an authorize token error: %#v", registry)
+	}
+}
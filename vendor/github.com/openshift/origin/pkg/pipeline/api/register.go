@@ -0,0 +1,15 @@
+package api
+
+import (
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+)
+
+func init() {
+	api.Scheme.AddKnownTypes("",
+		&Pipeline{},
+		&PipelineList{},
+	)
+}
+
+func (*Pipeline) IsAnAPIObject()     {}
+func (*PipelineList) IsAnAPIObject() {}
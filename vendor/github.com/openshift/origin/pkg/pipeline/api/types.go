@@ -0,0 +1,55 @@
+package api
+
+import (
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+)
+
+// PipelineStageKind identifies the kind of resource a PipelineStage advances.
+type PipelineStageKind string
+
+const (
+	PipelineStageKindBuild      PipelineStageKind = "Build"
+	PipelineStageKindDeployment PipelineStageKind = "Deployment"
+	PipelineStagePod            PipelineStageKind = "Pod"
+)
+
+// PipelineStage names a single resource the Pipeline waits on before advancing to the next
+// stage. Kind selects how the controller interprets Name: for PipelineStageKindBuild, Name is a
+// Build ID; for PipelineStageKindDeployment, Name is a Deployment ID; for PipelineStagePod, Name
+// is a Pod ID the controller creates from PodTemplate to run as a one-shot verification step.
+// Builds and Deployments are expected to already be triggered by the time a Pipeline references
+// them -- the controller only sequences and gates on their completion.
+type PipelineStage struct {
+	Kind        PipelineStageKind      `json:"kind,omitempty" yaml:"kind,omitempty"`
+	Name        string                 `json:"name,omitempty" yaml:"name,omitempty"`
+	PodTemplate *api.ContainerManifest `json:"podTemplate,omitempty" yaml:"podTemplate,omitempty"`
+}
+
+// PipelineStatus describes the possible states a Pipeline can be in.
+type PipelineStatus string
+
+const (
+	PipelineNew      PipelineStatus = "new"
+	PipelineRunning  PipelineStatus = "running"
+	PipelineComplete PipelineStatus = "complete"
+	PipelineFailed   PipelineStatus = "failed"
+)
+
+// A Pipeline chains a fixed, ordered sequence of Stages -- builds, deployments, and
+// verification pods -- and advances CurrentStage as each underlying resource reaches a terminal
+// success. It replaces external glue scripts that poll build and deployment status by hand.
+type Pipeline struct {
+	api.JSONBase `json:",inline" yaml:",inline"`
+	Labels       map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Stages       []PipelineStage   `json:"stages,omitempty" yaml:"stages,omitempty"`
+	// CurrentStage is the index into Stages the controller is currently waiting on. It is
+	// advanced by the controller only; clients should treat it as read-only.
+	CurrentStage int            `json:"currentStage,omitempty" yaml:"currentStage,omitempty"`
+	Status       PipelineStatus `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+// A PipelineList is a collection of Pipelines.
+type PipelineList struct {
+	api.JSONBase `json:",inline" yaml:",inline"`
+	Items        []Pipeline `json:"items,omitempty" yaml:"items,omitempty"`
+}
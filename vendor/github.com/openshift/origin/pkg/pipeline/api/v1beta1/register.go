@@ -0,0 +1,15 @@
+package v1beta1
+
+import (
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+)
+
+func init() {
+	api.Scheme.AddKnownTypes("v1beta1",
+		&Pipeline{},
+		&PipelineList{},
+	)
+}
+
+func (*Pipeline) IsAnAPIObject()     {}
+func (*PipelineList) IsAnAPIObject() {}
@@ -0,0 +1,42 @@
+package validation
+
+import (
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	pipelineapi "github.com/openshift/origin/pkg/pipeline/api"
+)
+
+// ValidatePipeline tests required fields on a Pipeline.
+func ValidatePipeline(pipeline *pipelineapi.Pipeline) errors.ErrorList {
+	result := errors.ErrorList{}
+
+	if len(pipeline.Stages) == 0 {
+		result = append(result, errors.NewFieldRequired("Stages", pipeline.Stages))
+	}
+
+	for i, stage := range pipeline.Stages {
+		result = append(result, validatePipelineStage(&stage).PrefixIndex(i).Prefix("Stages")...)
+	}
+
+	return result
+}
+
+func validatePipelineStage(stage *pipelineapi.PipelineStage) errors.ErrorList {
+	result := errors.ErrorList{}
+
+	if len(stage.Kind) == 0 {
+		result = append(result, errors.NewFieldRequired("Kind", stage.Kind))
+	}
+
+	switch stage.Kind {
+	case pipelineapi.PipelineStageKindBuild, pipelineapi.PipelineStageKindDeployment:
+		if len(stage.Name) == 0 {
+			result = append(result, errors.NewFieldRequired("Name", stage.Name))
+		}
+	case pipelineapi.PipelineStagePod:
+		if stage.PodTemplate == nil {
+			result = append(result, errors.NewFieldRequired("PodTemplate", stage.PodTemplate))
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,168 @@
+// Package pipeline sequences a fixed, ordered list of build, deployment, and verification-pod
+// stages, advancing a Pipeline's CurrentStage as each underlying resource reaches a terminal
+// success and marking the Pipeline Failed the moment one doesn't. Stages reference resources
+// created elsewhere (a build triggered by a webhook, a deployment rolled out by a config change
+// trigger); Pipeline only sequences and gates on them, replacing the external glue scripts that
+// would otherwise poll their status by hand.
+package pipeline
+
+import (
+	"fmt"
+	"time"
+
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	kubeclient "github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	"github.com/golang/glog"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	osclient "github.com/openshift/origin/pkg/client"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	"github.com/openshift/origin/pkg/pipeline/api"
+)
+
+// PipelineController watches Pipeline resources stored in etcd and advances each one's
+// CurrentStage as the resource named by that stage completes.
+type PipelineController struct {
+	osClient   osclient.Interface
+	kubeClient kubeclient.Interface
+}
+
+// NewPipelineController creates a new PipelineController.
+func NewPipelineController(kubeClient kubeclient.Interface, osClient osclient.Interface) *PipelineController {
+	return &PipelineController{
+		osClient:   osClient,
+		kubeClient: kubeClient,
+	}
+}
+
+// Run begins watching and synchronizing Pipeline stages.
+func (pc *PipelineController) Run(period time.Duration) {
+	ctx := kapi.NewContext()
+	go util.Forever(func() { pc.synchronize(ctx) }, period)
+}
+
+// The main sync loop. Iterates over current pipelines and advances each in turn.
+func (pc *PipelineController) synchronize(ctx kapi.Context) {
+	pipelines, err := pc.osClient.ListPipelines(ctx, labels.Everything())
+	if err != nil {
+		glog.Errorf("Error listing pipelines: %v (%#v)", err, err)
+		return
+	}
+
+	for i := range pipelines.Items {
+		pipeline := &pipelines.Items[i]
+		if pipeline.Status == api.PipelineComplete || pipeline.Status == api.PipelineFailed {
+			continue
+		}
+		if err := pc.advance(ctx, pipeline); err != nil {
+			glog.Errorf("Error advancing pipeline ID %v: %#v", pipeline.ID, err)
+		}
+	}
+}
+
+// advance checks the resource named by pipeline's current stage and, if it has finished
+// successfully, moves the pipeline on to the next stage (or Complete, if it was the last).
+// A stage that finished unsuccessfully fails the whole pipeline; later stages are never started.
+func (pc *PipelineController) advance(ctx kapi.Context, pipeline *api.Pipeline) error {
+	if pipeline.CurrentStage >= len(pipeline.Stages) {
+		return nil
+	}
+	stage := pipeline.Stages[pipeline.CurrentStage]
+
+	done, failed, err := pc.checkStage(ctx, &stage)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case failed:
+		glog.Infof("Pipeline %s failed at stage %d (%s %s)", pipeline.ID, pipeline.CurrentStage, stage.Kind, stage.Name)
+		pipeline.Status = api.PipelineFailed
+	case done:
+		pipeline.CurrentStage++
+		if pipeline.CurrentStage >= len(pipeline.Stages) {
+			glog.Infof("Pipeline %s completed", pipeline.ID)
+			pipeline.Status = api.PipelineComplete
+		} else {
+			glog.Infof("Pipeline %s advancing to stage %d", pipeline.ID, pipeline.CurrentStage)
+			pipeline.Status = api.PipelineRunning
+		}
+	default:
+		pipeline.Status = api.PipelineRunning
+		return nil
+	}
+
+	_, err = pc.osClient.UpdatePipeline(ctx, pipeline)
+	return err
+}
+
+// checkStage reports whether the resource named by stage has finished (done), and if so whether
+// it finished unsuccessfully (failed).
+func (pc *PipelineController) checkStage(ctx kapi.Context, stage *api.PipelineStage) (done, failed bool, err error) {
+	switch stage.Kind {
+	case api.PipelineStageKindBuild:
+		build, err := pc.osClient.GetBuild(ctx, stage.Name)
+		if err != nil {
+			return false, false, err
+		}
+		switch build.Status {
+		case buildapi.BuildComplete:
+			return true, false, nil
+		case buildapi.BuildFailed, buildapi.BuildError:
+			return true, true, nil
+		default:
+			return false, false, nil
+		}
+	case api.PipelineStageKindDeployment:
+		deployment, err := pc.osClient.GetDeployment(ctx, stage.Name)
+		if err != nil {
+			return false, false, err
+		}
+		switch deployment.State {
+		case deployapi.DeploymentComplete:
+			return true, false, nil
+		case deployapi.DeploymentFailed:
+			return true, true, nil
+		default:
+			return false, false, nil
+		}
+	case api.PipelineStagePod:
+		return pc.checkPodStage(ctx, stage)
+	default:
+		return false, true, fmt.Errorf("unknown pipeline stage kind: %s", stage.Kind)
+	}
+}
+
+// checkPodStage creates stage's verification pod if it hasn't been started yet, then reports
+// whether it has terminated and whether it exited non-zero.
+func (pc *PipelineController) checkPodStage(ctx kapi.Context, stage *api.PipelineStage) (done, failed bool, err error) {
+	pod, err := pc.kubeClient.GetPod(ctx, stage.Name)
+	if err != nil {
+		if stage.PodTemplate == nil {
+			return false, false, err
+		}
+		manifest := *stage.PodTemplate
+		manifest.RestartPolicy = kapi.RestartPolicy{Never: &kapi.RestartPolicyNever{}}
+		pod = &kapi.Pod{
+			JSONBase:     kapi.JSONBase{ID: stage.Name},
+			DesiredState: kapi.PodState{Manifest: manifest},
+		}
+		if _, err := pc.kubeClient.CreatePod(ctx, pod); err != nil {
+			return false, false, err
+		}
+		return false, false, nil
+	}
+
+	if pod.CurrentState.Status != kapi.PodTerminated {
+		return false, false, nil
+	}
+
+	for _, info := range pod.CurrentState.Info {
+		if info.State.Termination != nil && info.State.Termination.ExitCode != 0 {
+			return true, true, nil
+		}
+	}
+	return true, false, nil
+}
@@ -0,0 +1,140 @@
+package pipeline
+
+import (
+	"errors"
+	"testing"
+
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	kubeclient "github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	osclient "github.com/openshift/origin/pkg/client"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	"github.com/openshift/origin/pkg/pipeline/api"
+)
+
+type fakeOsClient struct {
+	osclient.Fake
+	build      *buildapi.Build
+	deployment *deployapi.Deployment
+}
+
+func (c *fakeOsClient) GetBuild(ctx kapi.Context, id string) (*buildapi.Build, error) {
+	if c.build == nil {
+		return nil, errors.New("no such build")
+	}
+	return c.build, nil
+}
+
+func (c *fakeOsClient) GetDeployment(ctx kapi.Context, id string) (*deployapi.Deployment, error) {
+	if c.deployment == nil {
+		return nil, errors.New("no such deployment")
+	}
+	return c.deployment, nil
+}
+
+type fakeKubeClient struct {
+	kubeclient.Fake
+	pod *kapi.Pod
+}
+
+func (c *fakeKubeClient) GetPod(ctx kapi.Context, id string) (*kapi.Pod, error) {
+	if c.pod == nil {
+		return nil, errors.New("no such pod")
+	}
+	return c.pod, nil
+}
+
+func TestCheckStageBuildComplete(t *testing.T) {
+	ctrl := NewPipelineController(&fakeKubeClient{}, &fakeOsClient{build: &buildapi.Build{Status: buildapi.BuildComplete}})
+	done, failed, err := ctrl.checkStage(kapi.NewContext(), &api.PipelineStage{Kind: api.PipelineStageKindBuild, Name: "b1"})
+	if err != nil || !done || failed {
+		t.Errorf("Expected done, not failed, got done=%v failed=%v err=%v", done, failed, err)
+	}
+}
+
+func TestCheckStageBuildRunning(t *testing.T) {
+	ctrl := NewPipelineController(&fakeKubeClient{}, &fakeOsClient{build: &buildapi.Build{Status: buildapi.BuildRunning}})
+	done, failed, err := ctrl.checkStage(kapi.NewContext(), &api.PipelineStage{Kind: api.PipelineStageKindBuild, Name: "b1"})
+	if err != nil || done || failed {
+		t.Errorf("Expected not done, got done=%v failed=%v err=%v", done, failed, err)
+	}
+}
+
+func TestCheckStageBuildFailed(t *testing.T) {
+	ctrl := NewPipelineController(&fakeKubeClient{}, &fakeOsClient{build: &buildapi.Build{Status: buildapi.BuildFailed}})
+	done, failed, err := ctrl.checkStage(kapi.NewContext(), &api.PipelineStage{Kind: api.PipelineStageKindBuild, Name: "b1"})
+	if err != nil || !done || !failed {
+		t.Errorf("Expected done and failed, got done=%v failed=%v err=%v", done, failed, err)
+	}
+}
+
+func TestCheckStageDeploymentComplete(t *testing.T) {
+	ctrl := NewPipelineController(&fakeKubeClient{}, &fakeOsClient{deployment: &deployapi.Deployment{State: deployapi.DeploymentComplete}})
+	done, failed, err := ctrl.checkStage(kapi.NewContext(), &api.PipelineStage{Kind: api.PipelineStageKindDeployment, Name: "d1"})
+	if err != nil || !done || failed {
+		t.Errorf("Expected done, not failed, got done=%v failed=%v err=%v", done, failed, err)
+	}
+}
+
+func TestAdvancePipelineToNextStage(t *testing.T) {
+	pipeline := &api.Pipeline{
+		JSONBase: kapi.JSONBase{ID: "p1"},
+		Stages: []api.PipelineStage{
+			{Kind: api.PipelineStageKindBuild, Name: "b1"},
+			{Kind: api.PipelineStageKindDeployment, Name: "d1"},
+		},
+		CurrentStage: 0,
+		Status:       api.PipelineRunning,
+	}
+	ctrl := NewPipelineController(&fakeKubeClient{}, &fakeOsClient{build: &buildapi.Build{Status: buildapi.BuildComplete}})
+	if err := ctrl.advance(kapi.NewContext(), pipeline); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if pipeline.CurrentStage != 1 {
+		t.Errorf("Expected CurrentStage 1, got %d", pipeline.CurrentStage)
+	}
+	if pipeline.Status != api.PipelineRunning {
+		t.Errorf("Expected status running, got %v", pipeline.Status)
+	}
+}
+
+func TestAdvancePipelineToComplete(t *testing.T) {
+	pipeline := &api.Pipeline{
+		JSONBase: kapi.JSONBase{ID: "p1"},
+		Stages: []api.PipelineStage{
+			{Kind: api.PipelineStageKindDeployment, Name: "d1"},
+		},
+		CurrentStage: 0,
+		Status:       api.PipelineRunning,
+	}
+	ctrl := NewPipelineController(&fakeKubeClient{}, &fakeOsClient{deployment: &deployapi.Deployment{State: deployapi.DeploymentComplete}})
+	if err := ctrl.advance(kapi.NewContext(), pipeline); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if pipeline.Status != api.PipelineComplete {
+		t.Errorf("Expected status complete, got %v", pipeline.Status)
+	}
+}
+
+func TestAdvancePipelineFails(t *testing.T) {
+	pipeline := &api.Pipeline{
+		JSONBase: kapi.JSONBase{ID: "p1"},
+		Stages: []api.PipelineStage{
+			{Kind: api.PipelineStageKindBuild, Name: "b1"},
+			{Kind: api.PipelineStageKindDeployment, Name: "d1"},
+		},
+		CurrentStage: 0,
+		Status:       api.PipelineRunning,
+	}
+	ctrl := NewPipelineController(&fakeKubeClient{}, &fakeOsClient{build: &buildapi.Build{Status: buildapi.BuildFailed}})
+	if err := ctrl.advance(kapi.NewContext(), pipeline); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if pipeline.Status != api.PipelineFailed {
+		t.Errorf("Expected status failed, got %v", pipeline.Status)
+	}
+	if pipeline.CurrentStage != 0 {
+		t.Errorf("Expected CurrentStage to stay at 0, got %d", pipeline.CurrentStage)
+	}
+}
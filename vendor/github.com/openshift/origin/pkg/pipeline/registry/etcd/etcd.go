@@ -0,0 +1,73 @@
+package etcd
+
+import (
+	etcderr "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors/etcd"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
+
+	"github.com/openshift/origin/pkg/pipeline/api"
+)
+
+// Etcd implements pipeline.Registry backed by etcd.
+type Etcd struct {
+	tools.EtcdHelper
+}
+
+// New creates an etcd registry.
+func New(helper tools.EtcdHelper) *Etcd {
+	return &Etcd{
+		EtcdHelper: helper,
+	}
+}
+
+func makePipelineKey(id string) string {
+	return "/pipelines/" + id
+}
+
+// ListPipelines obtains a list of Pipelines.
+func (r *Etcd) ListPipelines(selector labels.Selector) (*api.PipelineList, error) {
+	pipelines := api.PipelineList{}
+	err := r.ExtractList("/pipelines", &pipelines.Items, &pipelines.ResourceVersion)
+	if err != nil {
+		return nil, err
+	}
+	filtered := []api.Pipeline{}
+	for _, item := range pipelines.Items {
+		if selector.Matches(labels.Set(item.Labels)) {
+			filtered = append(filtered, item)
+		}
+	}
+
+	pipelines.Items = filtered
+	return &pipelines, err
+}
+
+// GetPipeline gets a specific Pipeline specified by its ID.
+func (r *Etcd) GetPipeline(id string) (*api.Pipeline, error) {
+	var pipeline api.Pipeline
+	key := makePipelineKey(id)
+	err := r.ExtractObj(key, &pipeline, false)
+	if err != nil {
+		return nil, etcderr.InterpretGetError(err, "pipeline", id)
+	}
+	return &pipeline, nil
+}
+
+// CreatePipeline creates a new Pipeline.
+func (r *Etcd) CreatePipeline(pipeline *api.Pipeline) error {
+	err := r.CreateObj(makePipelineKey(pipeline.ID), pipeline, 0)
+	return etcderr.InterpretCreateError(err, "pipeline", pipeline.ID)
+}
+
+// UpdatePipeline replaces an existing Pipeline.
+func (r *Etcd) UpdatePipeline(pipeline *api.Pipeline) error {
+	err := r.SetObj(makePipelineKey(pipeline.ID), pipeline)
+	return etcderr.InterpretUpdateError(err, "pipeline", pipeline.ID)
+}
+
+// DeletePipeline deletes a Pipeline specified by its ID.
+func (r *Etcd) DeletePipeline(id string) error {
+	key := makePipelineKey(id)
+	err := r.Delete(key, false)
+	return etcderr.InterpretDeleteError(err, "pipeline", id)
+}
@@ -0,0 +1,165 @@
+package etcd
+
+import (
+	"fmt"
+	"testing"
+
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
+	"github.com/coreos/go-etcd/etcd"
+
+	"github.com/openshift/origin/pkg/api/latest"
+	"github.com/openshift/origin/pkg/pipeline/api"
+)
+
+func NewTestEtcd(client tools.EtcdClient) *Etcd {
+	return New(tools.EtcdHelper{client, latest.Codec, latest.ResourceVersioner})
+}
+
+func TestEtcdListEmptyPipelines(t *testing.T) {
+	fakeClient := tools.NewFakeEtcdClient(t)
+	key := "/pipelines"
+	fakeClient.Data[key] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Nodes: []*etcd.Node{},
+			},
+		},
+		E: nil,
+	}
+	registry := NewTestEtcd(fakeClient)
+	pipelines, err := registry.ListPipelines(labels.Everything())
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if len(pipelines.Items) != 0 {
+		t.Errorf("Unexpected pipelines list: %#v", pipelines)
+	}
+}
+
+func TestEtcdListErrorPipelines(t *testing.T) {
+	fakeClient := tools.NewFakeEtcdClient(t)
+	key := "/pipelines"
+	fakeClient.Data[key] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: nil,
+		},
+		E: fmt.Errorf("some error"),
+	}
+	registry := NewTestEtcd(fakeClient)
+	pipelines, err := registry.ListPipelines(labels.Everything())
+	if err == nil {
+		t.Error("unexpected nil error")
+	}
+
+	if pipelines != nil {
+		t.Errorf("Unexpected non-nil pipelines: %#v", pipelines)
+	}
+}
+
+func TestEtcdListEverythingPipelines(t *testing.T) {
+	fakeClient := tools.NewFakeEtcdClient(t)
+	key := "/pipelines"
+	fakeClient.Data[key] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Nodes: []*etcd.Node{
+					{
+						Value: runtime.EncodeOrDie(latest.Codec, &api.Pipeline{JSONBase: kubeapi.JSONBase{ID: "foo"}}),
+					},
+					{
+						Value: runtime.EncodeOrDie(latest.Codec, &api.Pipeline{JSONBase: kubeapi.JSONBase{ID: "bar"}}),
+					},
+				},
+			},
+		},
+		E: nil,
+	}
+	registry := NewTestEtcd(fakeClient)
+	pipelines, err := registry.ListPipelines(labels.Everything())
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if len(pipelines.Items) != 2 || pipelines.Items[0].ID != "foo" || pipelines.Items[1].ID != "bar" {
+		t.Errorf("Unexpected pipelines list: %#v", pipelines)
+	}
+}
+
+func TestEtcdGetPipelines(t *testing.T) {
+	fakeClient := tools.NewFakeEtcdClient(t)
+	fakeClient.Set("/pipelines/foo", runtime.EncodeOrDie(latest.Codec, &api.Pipeline{JSONBase: kubeapi.JSONBase{ID: "foo"}}), 0)
+	registry := NewTestEtcd(fakeClient)
+	pipeline, err := registry.GetPipeline("foo")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if pipeline.ID != "foo" {
+		t.Errorf("Unexpected pipeline: %#v", pipeline)
+	}
+}
+
+func TestEtcdCreatePipeline(t *testing.T) {
+	fakeClient := tools.NewFakeEtcdClient(t)
+	fakeClient.TestIndex = true
+	registry := NewTestEtcd(fakeClient)
+	err := registry.CreatePipeline(&api.Pipeline{JSONBase: kubeapi.JSONBase{ID: "foo"}})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	resp, err := fakeClient.Get("/pipelines/foo", false, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var pipeline api.Pipeline
+	err = latest.Codec.DecodeInto([]byte(resp.Node.Value), &pipeline)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if pipeline.ID != "foo" {
+		t.Errorf("Unexpected pipeline: %#v %s", pipeline, resp.Node.Value)
+	}
+}
+
+func TestEtcdUpdateOkPipeline(t *testing.T) {
+	fakeClient := tools.NewFakeEtcdClient(t)
+	registry := NewTestEtcd(fakeClient)
+	err := registry.UpdatePipeline(&api.Pipeline{JSONBase: kubeapi.JSONBase{ID: "foo"}, Status: api.PipelineComplete})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	resp, err := fakeClient.Get("/pipelines/foo", false, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var pipeline api.Pipeline
+	err = latest.Codec.DecodeInto([]byte(resp.Node.Value), &pipeline)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if pipeline.Status != api.PipelineComplete {
+		t.Errorf("Unexpected pipeline: %#v", pipeline)
+	}
+}
+
+func TestEtcdDeletePipeline(t *testing.T) {
+	fakeClient := tools.NewFakeEtcdClient(t)
+	fakeClient.Set("/pipelines/foo", runtime.EncodeOrDie(latest.Codec, &api.Pipeline{JSONBase: kubeapi.JSONBase{ID: "foo"}}), 0)
+	registry := NewTestEtcd(fakeClient)
+	err := registry.DeletePipeline("foo")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(fakeClient.DeletedKeys) != 1 {
+		t.Errorf("Expected 1 delete, found %#v", fakeClient.DeletedKeys)
+	}
+	if fakeClient.DeletedKeys[0] != "/pipelines/foo" {
+		t.Errorf("Unexpected key: %s, expected %s", fakeClient.DeletedKeys[0], "/pipelines/foo")
+	}
+}
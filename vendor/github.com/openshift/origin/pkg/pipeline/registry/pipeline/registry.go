@@ -0,0 +1,15 @@
+package pipeline
+
+import (
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	api "github.com/openshift/origin/pkg/pipeline/api"
+)
+
+// Registry is an interface for things that know how to store Pipelines.
+type Registry interface {
+	ListPipelines(selector labels.Selector) (*api.PipelineList, error)
+	GetPipeline(id string) (*api.Pipeline, error)
+	CreatePipeline(pipeline *api.Pipeline) error
+	UpdatePipeline(pipeline *api.Pipeline) error
+	DeletePipeline(id string) error
+}
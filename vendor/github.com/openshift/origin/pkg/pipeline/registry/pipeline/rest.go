@@ -0,0 +1,104 @@
+package pipeline
+
+import (
+	"fmt"
+
+	"code.google.com/p/go-uuid/uuid"
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	kubeerrors "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/apiserver"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/golang/glog"
+
+	pipelineapi "github.com/openshift/origin/pkg/pipeline/api"
+	"github.com/openshift/origin/pkg/pipeline/api/validation"
+)
+
+// REST is an implementation of RESTStorage for the api server.
+type REST struct {
+	registry Registry
+}
+
+func NewREST(registry Registry) apiserver.RESTStorage {
+	return &REST{
+		registry: registry,
+	}
+}
+
+// New creates a new Pipeline for use with Create and Update
+func (s *REST) New() runtime.Object {
+	return &pipelineapi.Pipeline{}
+}
+
+// List obtains a list of Pipelines that match selector.
+func (s *REST) List(ctx kubeapi.Context, selector, fields labels.Selector) (runtime.Object, error) {
+	pipelines, err := s.registry.ListPipelines(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	return pipelines, nil
+}
+
+// Get obtains the Pipeline specified by its id.
+func (s *REST) Get(ctx kubeapi.Context, id string) (runtime.Object, error) {
+	pipeline, err := s.registry.GetPipeline(id)
+	if err != nil {
+		return nil, err
+	}
+	return pipeline, err
+}
+
+// Delete asynchronously deletes the Pipeline specified by its id.
+func (s *REST) Delete(ctx kubeapi.Context, id string) (<-chan runtime.Object, error) {
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		return &kubeapi.Status{Status: kubeapi.StatusSuccess}, s.registry.DeletePipeline(id)
+	}), nil
+}
+
+// Create registers a given new Pipeline instance to s.registry.
+func (s *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
+	pipeline, ok := obj.(*pipelineapi.Pipeline)
+	if !ok {
+		return nil, fmt.Errorf("not a pipeline: %#v", obj)
+	}
+
+	glog.Infof("Creating pipeline with ID: %v", pipeline.ID)
+
+	if len(pipeline.ID) == 0 {
+		pipeline.ID = uuid.NewUUID().String()
+	}
+	pipeline.Status = pipelineapi.PipelineNew
+	pipeline.CurrentStage = 0
+
+	if errs := validation.ValidatePipeline(pipeline); len(errs) > 0 {
+		return nil, kubeerrors.NewInvalid("pipeline", pipeline.ID, errs)
+	}
+
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		err := s.registry.CreatePipeline(pipeline)
+		if err != nil {
+			return nil, err
+		}
+		return pipeline, nil
+	}), nil
+}
+
+// Update replaces a given Pipeline instance with an existing instance in s.registry.
+func (s *REST) Update(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
+	pipeline, ok := obj.(*pipelineapi.Pipeline)
+	if !ok {
+		return nil, fmt.Errorf("not a pipeline: %#v", obj)
+	}
+	if len(pipeline.ID) == 0 {
+		return nil, fmt.Errorf("id is unspecified: %#v", pipeline)
+	}
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		err := s.registry.UpdatePipeline(pipeline)
+		if err != nil {
+			return nil, err
+		}
+		return pipeline, nil
+	}), nil
+}
@@ -0,0 +1,237 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/openshift/origin/pkg/pipeline/api"
+	"github.com/openshift/origin/pkg/pipeline/registry/test"
+)
+
+func TestListPipelinesError(t *testing.T) {
+	mockRegistry := test.NewPipelineRegistry()
+	mockRegistry.Err = fmt.Errorf("test error")
+
+	storage := REST{
+		registry: mockRegistry,
+	}
+
+	pipelines, err := storage.List(nil, nil, nil)
+	if err != mockRegistry.Err {
+		t.Errorf("Expected %#v, Got %#v", mockRegistry.Err, err)
+	}
+
+	if pipelines != nil {
+		t.Errorf("Unexpected non-nil pipelines list: %#v", pipelines)
+	}
+}
+
+func TestListPipelinesEmptyList(t *testing.T) {
+	mockRegistry := test.NewPipelineRegistry()
+	mockRegistry.Pipelines = &api.PipelineList{
+		Items: []api.Pipeline{},
+	}
+
+	storage := REST{
+		registry: mockRegistry,
+	}
+
+	pipelines, err := storage.List(nil, labels.Everything(), labels.Everything())
+	if err != nil {
+		t.Errorf("Unexpected non-nil error: %#v", err)
+	}
+
+	if len(pipelines.(*api.PipelineList).Items) != 0 {
+		t.Errorf("Unexpected non-zero pipelines list: %#v", pipelines)
+	}
+}
+
+func TestCreatePipelineBadObject(t *testing.T) {
+	storage := REST{}
+
+	channel, err := storage.Create(nil, &api.PipelineList{})
+	if channel != nil {
+		t.Errorf("Expected nil, got %v", channel)
+	}
+	if strings.Index(err.Error(), "not a pipeline") == -1 {
+		t.Errorf("Expected 'not a pipeline' error, got '%v'", err.Error())
+	}
+}
+
+func okStages() []api.PipelineStage {
+	return []api.PipelineStage{
+		{Kind: api.PipelineStageKindBuild, Name: "build1"},
+		{Kind: api.PipelineStageKindDeployment, Name: "deployconfig1"},
+	}
+}
+
+func TestCreateRegistrySaveError(t *testing.T) {
+	mockRegistry := test.NewPipelineRegistry()
+	mockRegistry.Err = fmt.Errorf("test error")
+	storage := REST{registry: mockRegistry}
+
+	channel, err := storage.Create(nil, &api.Pipeline{
+		JSONBase: kubeapi.JSONBase{ID: "foo"},
+		Stages:   okStages(),
+	})
+	if channel == nil {
+		t.Errorf("Expected nil channel, got %v", channel)
+	}
+	if err != nil {
+		t.Errorf("Unexpected non-nil error: %#v", err)
+	}
+
+	select {
+	case result := <-channel:
+		status, ok := result.(*kubeapi.Status)
+		if !ok {
+			t.Errorf("Expected status type, got: %#v", result)
+		}
+		if status.Status != kubeapi.StatusFailure || status.Message != "foo" {
+			t.Errorf("Expected failure status, got %#v", status)
+		}
+	case <-time.After(50 * time.Millisecond):
+		t.Errorf("Timed out waiting for result")
+	default:
+	}
+}
+
+func TestCreatePipelineOK(t *testing.T) {
+	mockRegistry := test.NewPipelineRegistry()
+	storage := REST{registry: mockRegistry}
+
+	channel, err := storage.Create(nil, &api.Pipeline{
+		JSONBase: kubeapi.JSONBase{ID: "foo"},
+		Stages:   okStages(),
+	})
+	if channel == nil {
+		t.Errorf("Expected nil channel, got %v", channel)
+	}
+	if err != nil {
+		t.Errorf("Unexpected non-nil error: %#v", err)
+	}
+
+	select {
+	case result := <-channel:
+		pipeline, ok := result.(*api.Pipeline)
+		if !ok {
+			t.Errorf("Expected pipeline type, got: %#v", result)
+		}
+		if pipeline.ID != "foo" {
+			t.Errorf("Unexpected pipeline: %#v", pipeline)
+		}
+		if pipeline.Status != api.PipelineNew {
+			t.Errorf("Expected status new, got %#v", pipeline.Status)
+		}
+	case <-time.After(50 * time.Millisecond):
+		t.Errorf("Timed out waiting for result")
+	default:
+	}
+}
+
+func TestGetPipelineError(t *testing.T) {
+	mockRegistry := test.NewPipelineRegistry()
+	mockRegistry.Err = fmt.Errorf("bad")
+	storage := REST{registry: mockRegistry}
+
+	pipeline, err := storage.Get(nil, "foo")
+	if pipeline != nil {
+		t.Errorf("Unexpected non-nil pipeline: %#v", pipeline)
+	}
+	if err != mockRegistry.Err {
+		t.Errorf("Expected %#v, got %#v", mockRegistry.Err, err)
+	}
+}
+
+func TestGetPipelineOK(t *testing.T) {
+	mockRegistry := test.NewPipelineRegistry()
+	mockRegistry.Pipeline = &api.Pipeline{
+		JSONBase: kubeapi.JSONBase{ID: "foo"},
+	}
+	storage := REST{registry: mockRegistry}
+
+	pipeline, err := storage.Get(nil, "foo")
+	if pipeline == nil {
+		t.Error("Unexpected nil pipeline")
+	}
+	if err != nil {
+		t.Errorf("Unexpected non-nil error: %v", err)
+	}
+	if pipeline.(*api.Pipeline).ID != "foo" {
+		t.Errorf("Unexpected pipeline: %#v", pipeline)
+	}
+}
+
+func TestUpdatePipelineBadObject(t *testing.T) {
+	storage := REST{}
+
+	channel, err := storage.Update(nil, &api.PipelineList{})
+	if channel != nil {
+		t.Errorf("Expected nil, got %v", channel)
+	}
+	if strings.Index(err.Error(), "not a pipeline:") == -1 {
+		t.Errorf("Expected 'not a pipeline' error, got %v", err)
+	}
+}
+
+func TestUpdatePipelineMissingID(t *testing.T) {
+	storage := REST{}
+
+	channel, err := storage.Update(nil, &api.Pipeline{})
+	if channel != nil {
+		t.Errorf("Expected nil, got %v", channel)
+	}
+	if strings.Index(err.Error(), "id is unspecified:") == -1 {
+		t.Errorf("Expected 'id is unspecified' error, got %v", err)
+	}
+}
+
+func TestUpdatePipelineOK(t *testing.T) {
+	mockRegistry := test.NewPipelineRegistry()
+	storage := REST{registry: mockRegistry}
+
+	channel, err := storage.Update(nil, &api.Pipeline{
+		JSONBase: kubeapi.JSONBase{ID: "bar"},
+	})
+	if err != nil {
+		t.Errorf("Unexpected non-nil error: %#v", err)
+	}
+	result := <-channel
+	pipeline, ok := result.(*api.Pipeline)
+	if !ok {
+		t.Errorf("Expected Pipeline, got %#v", result)
+	}
+	if pipeline.ID != "bar" {
+		t.Errorf("Unexpected pipeline returned: %#v", pipeline)
+	}
+}
+
+func TestDeletePipeline(t *testing.T) {
+	mockRegistry := test.NewPipelineRegistry()
+	storage := REST{registry: mockRegistry}
+	channel, err := storage.Delete(nil, "foo")
+	if channel == nil {
+		t.Error("Unexpected nil channel")
+	}
+	if err != nil {
+		t.Errorf("Unexpected non-nil error: %#v", err)
+	}
+
+	select {
+	case result := <-channel:
+		status, ok := result.(*kubeapi.Status)
+		if !ok {
+			t.Errorf("Expected status type, got: %#v", result)
+		}
+		if status.Status != kubeapi.StatusSuccess {
+			t.Errorf("Expected status=success, got: %#v", status)
+		}
+	case <-time.After(50 * time.Millisecond):
+		t.Errorf("Timed out waiting for result")
+	default:
+	}
+}
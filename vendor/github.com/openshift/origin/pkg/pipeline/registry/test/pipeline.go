@@ -0,0 +1,56 @@
+package test
+
+import (
+	"sync"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/openshift/origin/pkg/pipeline/api"
+)
+
+type PipelineRegistry struct {
+	Err       error
+	Pipeline  *api.Pipeline
+	Pipelines *api.PipelineList
+	sync.Mutex
+}
+
+func NewPipelineRegistry() *PipelineRegistry {
+	return &PipelineRegistry{}
+}
+
+func (r *PipelineRegistry) ListPipelines(selector labels.Selector) (*api.PipelineList, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.Pipelines, r.Err
+}
+
+func (r *PipelineRegistry) GetPipeline(id string) (*api.Pipeline, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.Pipeline, r.Err
+}
+
+func (r *PipelineRegistry) CreatePipeline(pipeline *api.Pipeline) error {
+	r.Lock()
+	defer r.Unlock()
+
+	r.Pipeline = pipeline
+	return r.Err
+}
+
+func (r *PipelineRegistry) UpdatePipeline(pipeline *api.Pipeline) error {
+	r.Lock()
+	defer r.Unlock()
+
+	r.Pipeline = pipeline
+	return r.Err
+}
+
+func (r *PipelineRegistry) DeletePipeline(id string) error {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.Err
+}
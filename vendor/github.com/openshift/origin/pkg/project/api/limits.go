@@ -0,0 +1,46 @@
+package api
+
+import (
+	"fmt"
+
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+)
+
+// LimitError reports that a container's CPU or memory request, whether set explicitly or
+// filled in by a default, exceeds the maximum a ProjectDefaults allows.
+type LimitError struct {
+	Container string
+	Resource  string
+	Requested int
+	Max       int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("container %q requested %s %d, which exceeds the maximum of %d", e.Container, e.Resource, e.Requested, e.Max)
+}
+
+// ApplyLimits fills in CPU and Memory on any container of pod that does not already
+// request one, using the CPU and Memory defaults, then verifies that every container's
+// CPU and Memory, whether defaulted or explicitly set, is within MaxCPU and MaxMemory. A
+// zero default or maximum is treated as unset. It returns a *LimitError for the first
+// container that exceeds a maximum.
+func (r DefaultResources) ApplyLimits(pod *kubeapi.Pod) error {
+	for i := range pod.DesiredState.Manifest.Containers {
+		container := &pod.DesiredState.Manifest.Containers[i]
+
+		if container.CPU == 0 {
+			container.CPU = r.CPU
+		}
+		if container.Memory == 0 {
+			container.Memory = r.Memory
+		}
+
+		if r.MaxCPU > 0 && container.CPU > r.MaxCPU {
+			return &LimitError{Container: container.Name, Resource: "CPU", Requested: container.CPU, Max: r.MaxCPU}
+		}
+		if r.MaxMemory > 0 && container.Memory > r.MaxMemory {
+			return &LimitError{Container: container.Name, Resource: "Memory", Requested: container.Memory, Max: r.MaxMemory}
+		}
+	}
+	return nil
+}
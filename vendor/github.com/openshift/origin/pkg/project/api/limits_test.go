@@ -0,0 +1,71 @@
+package api
+
+import (
+	"testing"
+
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+)
+
+func podWithContainer(cpu, memory int) *kubeapi.Pod {
+	return &kubeapi.Pod{
+		DesiredState: kubeapi.PodState{
+			Manifest: kubeapi.ContainerManifest{
+				Containers: []kubeapi.Container{
+					{Name: "test", CPU: cpu, Memory: memory},
+				},
+			},
+		},
+	}
+}
+
+func TestApplyLimitsFillsInDefaults(t *testing.T) {
+	pod := podWithContainer(0, 0)
+	resources := DefaultResources{CPU: 100, Memory: 1024}
+
+	if err := resources.ApplyLimits(pod); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	container := pod.DesiredState.Manifest.Containers[0]
+	if container.CPU != 100 || container.Memory != 1024 {
+		t.Errorf("expected defaults to be applied, got CPU=%d Memory=%d", container.CPU, container.Memory)
+	}
+}
+
+func TestApplyLimitsLeavesExplicitValues(t *testing.T) {
+	pod := podWithContainer(50, 512)
+	resources := DefaultResources{CPU: 100, Memory: 1024}
+
+	if err := resources.ApplyLimits(pod); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	container := pod.DesiredState.Manifest.Containers[0]
+	if container.CPU != 50 || container.Memory != 512 {
+		t.Errorf("expected explicit values to be preserved, got CPU=%d Memory=%d", container.CPU, container.Memory)
+	}
+}
+
+func TestApplyLimitsRejectsExceededMax(t *testing.T) {
+	pod := podWithContainer(200, 0)
+	resources := DefaultResources{MaxCPU: 100}
+
+	err := resources.ApplyLimits(pod)
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+	limitErr, ok := err.(*LimitError)
+	if !ok {
+		t.Fatalf("expected a *LimitError, got %#v", err)
+	}
+	if limitErr.Container != "test" || limitErr.Resource != "CPU" || limitErr.Requested != 200 || limitErr.Max != 100 {
+		t.Errorf("unexpected LimitError contents: %#v", limitErr)
+	}
+}
+
+func TestApplyLimitsUnlimitedByDefault(t *testing.T) {
+	pod := podWithContainer(1000000, 1000000)
+	resources := DefaultResources{}
+
+	if err := resources.ApplyLimits(pod); err != nil {
+		t.Errorf("expected no error when no maximum is configured, got %v", err)
+	}
+}
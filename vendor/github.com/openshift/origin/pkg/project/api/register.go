@@ -8,8 +8,16 @@ func init() {
 	api.Scheme.AddKnownTypes("",
 		&Project{},
 		&ProjectList{},
+		&ProjectDefaults{},
+		&ProjectDefaultsList{},
+		&ProjectResourceUsage{},
+		&ProjectOverview{},
 	)
 }
 
-func (*Project) IsAnAPIObject()     {}
-func (*ProjectList) IsAnAPIObject() {}
+func (*Project) IsAnAPIObject()              {}
+func (*ProjectList) IsAnAPIObject()          {}
+func (*ProjectDefaults) IsAnAPIObject()      {}
+func (*ProjectDefaultsList) IsAnAPIObject()  {}
+func (*ProjectResourceUsage) IsAnAPIObject() {}
+func (*ProjectOverview) IsAnAPIObject()      {}
@@ -2,6 +2,10 @@ package api
 
 import (
 	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	routeapi "github.com/openshift/origin/pkg/route/api"
 )
 
 // ProjectList is a list of Project objects.
@@ -16,4 +20,122 @@ type Project struct {
 	Labels           map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
 	DisplayName      string            `json:"displayName,omitempty" yaml:"displayName,omitempty"`
 	Description      string            `json:"description,omitempty" yaml:"description,omitempty"`
+
+	// Annotations holds structured, console-facing metadata about the project, such as
+	// who requested it, how it should be ordered in listings, and who to contact about
+	// it. See the ProjectXxxAnnotation constants for the recognized keys.
+	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+}
+
+const (
+	// ProjectRequesterAnnotation records the user who requested the project's creation.
+	ProjectRequesterAnnotation = "requester"
+	// ProjectDisplayOrderAnnotation is a signed integer controlling the project's relative
+	// position in console listings; lower values sort first.
+	ProjectDisplayOrderAnnotation = "displayOrder"
+	// ProjectContactAnnotation records a contact, such as an email address, for the team
+	// that owns the project.
+	ProjectContactAnnotation = "contact"
+)
+
+// ProjectDefaults holds the default settings applied to Builds and Deployments created
+// within a project when the object itself does not specify a value. There is at most one
+// ProjectDefaults per project, named after the project it applies to.
+type ProjectDefaults struct {
+	kubeapi.JSONBase `json:",inline" yaml:",inline"`
+
+	// BuildTimeoutSeconds is the default number of seconds a Build is allowed to run
+	// before it is considered timed out. Zero means the build controller's own default
+	// applies.
+	BuildTimeoutSeconds int `json:"buildTimeoutSeconds,omitempty" yaml:"buildTimeoutSeconds,omitempty"`
+
+	// DeploymentStrategy is the default DeploymentStrategy.Type applied to a
+	// DeploymentConfig that does not specify one.
+	DeploymentStrategy string `json:"deploymentStrategy,omitempty" yaml:"deploymentStrategy,omitempty"`
+
+	// Resources holds the default compute resources applied to build and deployer pods
+	// that do not specify their own.
+	Resources DefaultResources `json:"resources,omitempty" yaml:"resources,omitempty"`
+
+	// PullSecretName is the ID of the Secret used to pull private images when a Build
+	// or Deployment in the project does not name a pull secret of its own.
+	PullSecretName string `json:"pullSecretName,omitempty" yaml:"pullSecretName,omitempty"`
+
+	// AllowedRegistries restricts the registries builds and deployments in the project may
+	// reference images from. Empty means any registry is allowed.
+	AllowedRegistries []string `json:"allowedRegistries,omitempty" yaml:"allowedRegistries,omitempty"`
+
+	// SourceCacheURL, if set, is the URL of a git mirror/cache service the project's builds
+	// should clone from instead of a build's own SourceURI. It cuts external git traffic for
+	// busy repositories and keeps builds running through an upstream outage. Empty means
+	// builds clone directly from their own SourceURI.
+	SourceCacheURL string `json:"sourceCacheURL,omitempty" yaml:"sourceCacheURL,omitempty"`
+}
+
+// DefaultResources mirrors the resource fields on kubeapi.Container so a ProjectDefaults
+// can supply a default value for each, along with the maximum a build or deployer pod's
+// container is allowed to request.
+type DefaultResources struct {
+	// CPU is the default CPU units to request. Zero means unlimited.
+	CPU int `json:"cpu,omitempty" yaml:"cpu,omitempty"`
+	// Memory is the default memory, in bytes, to request. Zero means unlimited.
+	Memory int `json:"memory,omitempty" yaml:"memory,omitempty"`
+
+	// MaxCPU caps the CPU units a container may request, including CPU applied by this
+	// default. Zero means unlimited.
+	MaxCPU int `json:"maxCPU,omitempty" yaml:"maxCPU,omitempty"`
+	// MaxMemory caps the memory, in bytes, a container may request, including memory
+	// applied by this default. Zero means unlimited.
+	MaxMemory int `json:"maxMemory,omitempty" yaml:"maxMemory,omitempty"`
+}
+
+// ProjectDefaultsList is a list of ProjectDefaults objects.
+type ProjectDefaultsList struct {
+	kubeapi.JSONBase `json:",inline" yaml:",inline"`
+	Items            []ProjectDefaults `json:"items,omitempty" yaml:"items,omitempty"`
+}
+
+// ProjectResourceUsage reports aggregate counts of the pods, builds, and deployments
+// running within a project, named after the project it reports on.
+type ProjectResourceUsage struct {
+	kubeapi.JSONBase `json:",inline" yaml:",inline"`
+
+	// PodCount is the number of pods currently in the project.
+	PodCount int `json:"podCount,omitempty" yaml:"podCount,omitempty"`
+
+	// BuildCounts maps each Build status (e.g. "running", "complete") to the number of
+	// Builds in the project with that status.
+	BuildCounts map[string]int `json:"buildCounts,omitempty" yaml:"buildCounts,omitempty"`
+
+	// DeploymentCounts maps each Deployment state (e.g. "running", "complete") to the
+	// number of Deployments in the project with that state.
+	DeploymentCounts map[string]int `json:"deploymentCounts,omitempty" yaml:"deploymentCounts,omitempty"`
+}
+
+// ServiceOverview pairs a Service with the Routes that expose it.
+type ServiceOverview struct {
+	Service kubeapi.Service  `json:"service,omitempty" yaml:"service,omitempty"`
+	Routes  []routeapi.Route `json:"routes,omitempty" yaml:"routes,omitempty"`
+}
+
+// DeploymentConfigOverview pairs a DeploymentConfig with the state of its most recently
+// created Deployment, if any.
+type DeploymentConfigOverview struct {
+	DeploymentConfig       deployapi.DeploymentConfig `json:"deploymentConfig,omitempty" yaml:"deploymentConfig,omitempty"`
+	LatestDeploymentStatus deployapi.DeploymentState  `json:"latestDeploymentStatus,omitempty" yaml:"latestDeploymentStatus,omitempty"`
+}
+
+// ProjectOverview aggregates the resources the web console's project overview page renders —
+// a project's services with their routes, deployment configs with their latest deployment
+// status, and recent builds — so the console can render the page from a single request
+// instead of one request per resource type.
+type ProjectOverview struct {
+	kubeapi.JSONBase `json:",inline" yaml:",inline"`
+
+	Services          []ServiceOverview          `json:"services,omitempty" yaml:"services,omitempty"`
+	DeploymentConfigs []DeploymentConfigOverview `json:"deploymentConfigs,omitempty" yaml:"deploymentConfigs,omitempty"`
+
+	// RecentBuilds lists the project's most recently created builds, newest first, capped
+	// at maxRecentBuilds.
+	RecentBuilds []buildapi.Build `json:"recentBuilds,omitempty" yaml:"recentBuilds,omitempty"`
 }
@@ -2,6 +2,10 @@ package v1beta1
 
 import (
 	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	routeapi "github.com/openshift/origin/pkg/route/api"
 )
 
 // ProjectList is a list of Project objects.
@@ -16,4 +20,67 @@ type Project struct {
 	Labels           map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
 	DisplayName      string            `json:"displayName,omitempty" yaml:"displayName,omitempty"`
 	Description      string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Annotations      map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+}
+
+// ProjectDefaults holds the default settings applied to Builds and Deployments created
+// within a project when the object itself does not specify a value.
+type ProjectDefaults struct {
+	kubeapi.JSONBase    `json:",inline" yaml:",inline"`
+	BuildTimeoutSeconds int              `json:"buildTimeoutSeconds,omitempty" yaml:"buildTimeoutSeconds,omitempty"`
+	DeploymentStrategy  string           `json:"deploymentStrategy,omitempty" yaml:"deploymentStrategy,omitempty"`
+	Resources           DefaultResources `json:"resources,omitempty" yaml:"resources,omitempty"`
+	PullSecretName      string           `json:"pullSecretName,omitempty" yaml:"pullSecretName,omitempty"`
+	AllowedRegistries   []string         `json:"allowedRegistries,omitempty" yaml:"allowedRegistries,omitempty"`
+	SourceCacheURL      string           `json:"sourceCacheURL,omitempty" yaml:"sourceCacheURL,omitempty"`
+}
+
+// DefaultResources mirrors the resource fields on kubeapi.Container so a ProjectDefaults
+// can supply a default value for each, along with the maximum a build or deployer pod's
+// container is allowed to request.
+type DefaultResources struct {
+	CPU    int `json:"cpu,omitempty" yaml:"cpu,omitempty"`
+	Memory int `json:"memory,omitempty" yaml:"memory,omitempty"`
+
+	MaxCPU    int `json:"maxCPU,omitempty" yaml:"maxCPU,omitempty"`
+	MaxMemory int `json:"maxMemory,omitempty" yaml:"maxMemory,omitempty"`
+}
+
+// ProjectDefaultsList is a list of ProjectDefaults objects.
+type ProjectDefaultsList struct {
+	kubeapi.JSONBase `json:",inline" yaml:",inline"`
+	Items            []ProjectDefaults `json:"items,omitempty" yaml:"items,omitempty"`
+}
+
+// ProjectResourceUsage reports aggregate counts of the pods, builds, and deployments
+// running within a project.
+type ProjectResourceUsage struct {
+	kubeapi.JSONBase `json:",inline" yaml:",inline"`
+	PodCount         int            `json:"podCount,omitempty" yaml:"podCount,omitempty"`
+	BuildCounts      map[string]int `json:"buildCounts,omitempty" yaml:"buildCounts,omitempty"`
+	DeploymentCounts map[string]int `json:"deploymentCounts,omitempty" yaml:"deploymentCounts,omitempty"`
+}
+
+// ServiceOverview pairs a Service with the Routes that expose it.
+type ServiceOverview struct {
+	Service kubeapi.Service  `json:"service,omitempty" yaml:"service,omitempty"`
+	Routes  []routeapi.Route `json:"routes,omitempty" yaml:"routes,omitempty"`
+}
+
+// DeploymentConfigOverview pairs a DeploymentConfig with the state of its most recently
+// created Deployment, if any.
+type DeploymentConfigOverview struct {
+	DeploymentConfig       deployapi.DeploymentConfig `json:"deploymentConfig,omitempty" yaml:"deploymentConfig,omitempty"`
+	LatestDeploymentStatus deployapi.DeploymentState  `json:"latestDeploymentStatus,omitempty" yaml:"latestDeploymentStatus,omitempty"`
+}
+
+// ProjectOverview aggregates the resources the web console's project overview page renders —
+// a project's services with their routes, deployment configs with their latest deployment
+// status, and recent builds — so the console can render the page from a single request
+// instead of one request per resource type.
+type ProjectOverview struct {
+	kubeapi.JSONBase  `json:",inline" yaml:",inline"`
+	Services          []ServiceOverview          `json:"services,omitempty" yaml:"services,omitempty"`
+	DeploymentConfigs []DeploymentConfigOverview `json:"deploymentConfigs,omitempty" yaml:"deploymentConfigs,omitempty"`
+	RecentBuilds      []buildapi.Build           `json:"recentBuilds,omitempty" yaml:"recentBuilds,omitempty"`
 }
@@ -1,12 +1,19 @@
 package validation
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
 	"github.com/openshift/origin/pkg/project/api"
-	"strings"
 )
 
+// maxAnnotationValueLength bounds a single project annotation value so console listings
+// cannot be used to smuggle arbitrarily large data into etcd.
+const maxAnnotationValueLength = 512
+
 // ValidateProject tests required fields for a Project.
 func ValidateProject(project *api.Project) errors.ErrorList {
 	result := errors.ErrorList{}
@@ -24,6 +31,96 @@ func ValidateProject(project *api.Project) errors.ErrorList {
 	if !validateNoNewLineOrTab(project.Description) {
 		result = append(result, errors.NewFieldInvalid("Description", project.Description))
 	}
+	result = append(result, validateProjectAnnotations(project.Annotations)...)
+	return result
+}
+
+// ValidateProjectUpdate tests that an update to a Project only changes mutable fields,
+// such as its annotations, and that the resulting Project is itself valid.
+func ValidateProjectUpdate(older, project *api.Project) errors.ErrorList {
+	result := errors.ErrorList{}
+	if project.ID != older.ID {
+		result = append(result, errors.NewFieldInvalid("ID", project.ID))
+	}
+	if project.Namespace != older.Namespace {
+		result = append(result, errors.NewFieldInvalid("Namespace", project.Namespace))
+	}
+	result = append(result, ValidateProject(project)...)
+	return result
+}
+
+// ValidateProjectDefaults tests required fields for a ProjectDefaults.
+func ValidateProjectDefaults(defaults *api.ProjectDefaults) errors.ErrorList {
+	result := errors.ErrorList{}
+	if len(defaults.ID) == 0 {
+		result = append(result, errors.NewFieldRequired("ID", defaults.ID))
+	}
+	if defaults.BuildTimeoutSeconds < 0 {
+		result = append(result, errors.NewFieldInvalid("BuildTimeoutSeconds", defaults.BuildTimeoutSeconds))
+	}
+	if !validateNoNewLineOrTab(defaults.DeploymentStrategy) {
+		result = append(result, errors.NewFieldInvalid("DeploymentStrategy", defaults.DeploymentStrategy))
+	}
+	if defaults.Resources.CPU < 0 {
+		result = append(result, errors.NewFieldInvalid("Resources.CPU", defaults.Resources.CPU))
+	}
+	if defaults.Resources.Memory < 0 {
+		result = append(result, errors.NewFieldInvalid("Resources.Memory", defaults.Resources.Memory))
+	}
+	if defaults.Resources.MaxCPU < 0 {
+		result = append(result, errors.NewFieldInvalid("Resources.MaxCPU", defaults.Resources.MaxCPU))
+	}
+	if defaults.Resources.MaxMemory < 0 {
+		result = append(result, errors.NewFieldInvalid("Resources.MaxMemory", defaults.Resources.MaxMemory))
+	}
+	if defaults.Resources.MaxCPU > 0 && defaults.Resources.CPU > defaults.Resources.MaxCPU {
+		result = append(result, errors.NewFieldInvalid("Resources.CPU", defaults.Resources.CPU))
+	}
+	if defaults.Resources.MaxMemory > 0 && defaults.Resources.Memory > defaults.Resources.MaxMemory {
+		result = append(result, errors.NewFieldInvalid("Resources.Memory", defaults.Resources.Memory))
+	}
+	for i, registry := range defaults.AllowedRegistries {
+		if len(registry) == 0 {
+			result = append(result, errors.NewFieldInvalid(fmt.Sprintf("AllowedRegistries[%d]", i), registry))
+		}
+	}
+	return result
+}
+
+// ValidateProjectDefaultsUpdate tests that an update to a ProjectDefaults only changes
+// mutable fields, and that the resulting ProjectDefaults is itself valid.
+func ValidateProjectDefaultsUpdate(older, defaults *api.ProjectDefaults) errors.ErrorList {
+	result := errors.ErrorList{}
+	if defaults.ID != older.ID {
+		result = append(result, errors.NewFieldInvalid("ID", defaults.ID))
+	}
+	result = append(result, ValidateProjectDefaults(defaults)...)
+	return result
+}
+
+// validateProjectAnnotations restricts project annotations to the known keys used by the
+// console, and bounds the size of each value.
+func validateProjectAnnotations(annotations map[string]string) errors.ErrorList {
+	result := errors.ErrorList{}
+	for key, value := range annotations {
+		switch key {
+		case api.ProjectRequesterAnnotation, api.ProjectDisplayOrderAnnotation, api.ProjectContactAnnotation:
+		default:
+			result = append(result, errors.NewFieldNotSupported("Annotations", key))
+			continue
+		}
+		if len(value) > maxAnnotationValueLength {
+			result = append(result, errors.NewFieldInvalid(fmt.Sprintf("Annotations[%s]", key), value))
+		}
+		if !validateNoNewLineOrTab(value) {
+			result = append(result, errors.NewFieldInvalid(fmt.Sprintf("Annotations[%s]", key), value))
+		}
+	}
+	if order, ok := annotations[api.ProjectDisplayOrderAnnotation]; ok {
+		if _, err := strconv.Atoi(order); err != nil {
+			result = append(result, errors.NewFieldInvalid(fmt.Sprintf("Annotations[%s]", api.ProjectDisplayOrderAnnotation), order))
+		}
+	}
 	return result
 }
 
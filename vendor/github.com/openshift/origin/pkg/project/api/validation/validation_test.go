@@ -5,6 +5,7 @@ import (
 
 	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	"github.com/openshift/origin/pkg/project/api"
+	validationutil "github.com/openshift/origin/pkg/util/validation"
 )
 
 func TestValidateProject(t *testing.T) {
@@ -77,9 +78,7 @@ func TestValidateProject(t *testing.T) {
 
 	for _, tc := range testCases {
 		errs := ValidateProject(&tc.project)
-		if len(errs) != tc.numErrs {
-			t.Errorf("Unexpected error list for case %q: %+v", tc.name, errs)
-		}
+		validationutil.ExpectCount(t, tc.name, errs, tc.numErrs)
 	}
 
 	project := api.Project{
@@ -88,7 +87,50 @@ func TestValidateProject(t *testing.T) {
 		Description: "This is a description",
 	}
 	errs := ValidateProject(&project)
-	if len(errs) != 0 {
-		t.Errorf("Unexpected non-zero error list: %#v", errs)
+	validationutil.ExpectValid(t, "valid project", errs)
+}
+
+func TestValidateProjectDefaults(t *testing.T) {
+	testCases := []struct {
+		name     string
+		defaults api.ProjectDefaults
+		numErrs  int
+	}{
+		{
+			name: "missing id",
+			defaults: api.ProjectDefaults{
+				Resources: api.DefaultResources{CPU: 100, MaxCPU: 200},
+			},
+			numErrs: 1,
+		},
+		{
+			name: "negative max",
+			defaults: api.ProjectDefaults{
+				JSONBase:  kubeapi.JSONBase{ID: "foo"},
+				Resources: api.DefaultResources{MaxCPU: -1, MaxMemory: -1},
+			},
+			numErrs: 2,
+		},
+		{
+			name: "default exceeds max",
+			defaults: api.ProjectDefaults{
+				JSONBase:  kubeapi.JSONBase{ID: "foo"},
+				Resources: api.DefaultResources{CPU: 200, MaxCPU: 100},
+			},
+			numErrs: 1,
+		},
+		{
+			name: "valid limits",
+			defaults: api.ProjectDefaults{
+				JSONBase:  kubeapi.JSONBase{ID: "foo"},
+				Resources: api.DefaultResources{CPU: 100, MaxCPU: 200, Memory: 512, MaxMemory: 1024},
+			},
+			numErrs: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		errs := ValidateProjectDefaults(&tc.defaults)
+		validationutil.ExpectCount(t, tc.name, errs, tc.numErrs)
 	}
 }
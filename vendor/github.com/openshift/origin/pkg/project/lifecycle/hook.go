@@ -0,0 +1,128 @@
+// Package lifecycle calls an external HTTP hook synchronously around Project create and
+// delete, so systems that provision project-scoped resources - DNS entries, billing
+// accounts, LDAP groups - run in lockstep with the project instead of racing the
+// fire-and-forget delivery pkg/webhook/notify makes after the fact.
+package lifecycle
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+)
+
+// SignatureHeader carries the hex-encoded HMAC-SHA1 signature of the request body, so a
+// receiver can verify a call actually came from this server and wasn't tampered with in
+// transit.
+const SignatureHeader = "X-Origin-Signature"
+
+// FailurePolicy controls what a Hook does when every delivery attempt fails.
+type FailurePolicy string
+
+const (
+	// FailurePolicyDeny fails the project operation the Hook was called for.
+	FailurePolicyDeny FailurePolicy = "Deny"
+	// FailurePolicyIgnore lets the project operation proceed regardless.
+	FailurePolicyIgnore FailurePolicy = "Ignore"
+)
+
+// Event describes a single project lifecycle transition delivered to a Hook's URL.
+type Event struct {
+	// Type is "project.create" or "project.delete".
+	Type string `json:"type"`
+	// ID is the ID of the project the event is about.
+	ID string `json:"id"`
+	// Timestamp is when the event was generated.
+	Timestamp util.Time `json:"timestamp"`
+}
+
+// Hook calls an external URL synchronously for each project create and delete, retrying
+// failed deliveries before applying FailurePolicy.
+type Hook struct {
+	URL           string
+	Secret        string
+	MaxAttempts   int
+	FailurePolicy FailurePolicy
+
+	client *http.Client
+}
+
+// New returns a Hook that calls url for each project lifecycle event, signing deliveries
+// with secret (if non-empty) and retrying up to maxAttempts times before applying policy. A
+// maxAttempts of zero or less defaults to 3.
+func New(url, secret string, maxAttempts int, policy FailurePolicy) *Hook {
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+	return &Hook{
+		URL:           url,
+		Secret:        secret,
+		MaxAttempts:   maxAttempts,
+		FailurePolicy: policy,
+		client:        &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Call delivers event to the Hook's URL, retrying with a linear backoff up to MaxAttempts
+// times. If every attempt fails, Call returns an error when FailurePolicy is
+// FailurePolicyDeny, so the caller can abort the operation the event describes; with
+// FailurePolicyIgnore it returns nil instead, letting the operation proceed.
+func (h *Hook) Call(event Event) error {
+	err := h.deliver(event)
+	if err == nil {
+		return nil
+	}
+	if h.FailurePolicy == FailurePolicyDeny {
+		return fmt.Errorf("project lifecycle hook failed: %v", err)
+	}
+	return nil
+}
+
+// deliver POSTs event to h.URL, retrying with a linear backoff up to MaxAttempts times.
+func (h *Hook) deliver(event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < h.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Duration(attempt) * time.Second)
+		}
+
+		req, err := http.NewRequest("POST", h.URL, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if len(h.Secret) > 0 {
+			req.Header.Set(SignatureHeader, sign(h.Secret, body))
+		}
+
+		resp, err := h.client.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		lastErr = fmt.Errorf("received status %s", resp.Status)
+	}
+	return fmt.Errorf("giving up after %d attempts: %v", h.MaxAttempts, lastErr)
+}
+
+// sign returns the hex-encoded HMAC-SHA1 signature of body using secret as the key.
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
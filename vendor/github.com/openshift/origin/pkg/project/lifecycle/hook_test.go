@@ -0,0 +1,73 @@
+package lifecycle
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestCallSucceeds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	h := New(server.URL, "", 1, FailurePolicyDeny)
+	if err := h.Call(Event{Type: "project.create", ID: "proj-1"}); err != nil {
+		t.Errorf("expected success, got %v", err)
+	}
+}
+
+func TestCallRetriesUntilSuccess(t *testing.T) {
+	var mu sync.Mutex
+	attempts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts++
+		n := attempts
+		mu.Unlock()
+		if n < 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	h := New(server.URL, "", 3, FailurePolicyDeny)
+	if err := h.Call(Event{Type: "project.delete", ID: "proj-1"}); err != nil {
+		t.Errorf("expected delivery to eventually succeed, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+}
+
+func TestCallDenyPolicyReturnsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	h := New(server.URL, "", 1, FailurePolicyDeny)
+	if err := h.Call(Event{Type: "project.create", ID: "proj-1"}); err == nil {
+		t.Errorf("expected an error from a denying hook that always fails")
+	}
+}
+
+func TestCallIgnorePolicySwallowsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	h := New(server.URL, "", 1, FailurePolicyIgnore)
+	if err := h.Call(Event{Type: "project.create", ID: "proj-1"}); err != nil {
+		t.Errorf("expected a failing hook under FailurePolicyIgnore to return nil, got %v", err)
+	}
+}
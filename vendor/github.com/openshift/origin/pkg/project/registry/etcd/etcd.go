@@ -1,8 +1,6 @@
 package etcd
 
 import (
-	"errors"
-
 	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	etcderr "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors/etcd"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
@@ -14,6 +12,8 @@ import (
 const (
 	// ProjectPath is the path to project resources in etcd
 	ProjectPath string = "/projects"
+	// ProjectDefaultsPath is the path to projectDefaults resources in etcd
+	ProjectDefaultsPath string = "/projectDefaults"
 )
 
 // Etcd implements ProjectRegistry and ProjectRepositoryRegistry backed by etcd.
@@ -72,7 +72,8 @@ func (r *Etcd) CreateProject(ctx kubeapi.Context, project *api.Project) error {
 
 // UpdateProject updates an existing project
 func (r *Etcd) UpdateProject(ctx kubeapi.Context, project *api.Project) error {
-	return errors.New("not supported")
+	err := r.SetObj(makeProjectKey(ctx, project.ID), project)
+	return etcderr.InterpretUpdateError(err, "project", project.ID)
 }
 
 // DeleteProject deletes an existing project
@@ -80,3 +81,50 @@ func (r *Etcd) DeleteProject(ctx kubeapi.Context, id string) error {
 	err := r.Delete(makeProjectKey(ctx, id), false)
 	return etcderr.InterpretDeleteError(err, "project", id)
 }
+
+// makeProjectDefaultsListKey constructs etcd paths to projectDefaults directories
+func makeProjectDefaultsListKey(ctx kubeapi.Context) string {
+	return ProjectDefaultsPath
+}
+
+// makeProjectDefaultsKey constructs etcd paths to projectDefaults items
+func makeProjectDefaultsKey(ctx kubeapi.Context, id string) string {
+	return makeProjectDefaultsListKey(ctx) + "/" + id
+}
+
+// ListProjectDefaults retrieves a list of projectDefaults that match selector.
+func (r *Etcd) ListProjectDefaults(ctx kubeapi.Context, selector labels.Selector) (*api.ProjectDefaultsList, error) {
+	list := api.ProjectDefaultsList{}
+	err := r.ExtractList(makeProjectDefaultsListKey(ctx), &list.Items, &list.ResourceVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// GetProjectDefaults retrieves a specific projectDefaults
+func (r *Etcd) GetProjectDefaults(ctx kubeapi.Context, id string) (*api.ProjectDefaults, error) {
+	var defaults api.ProjectDefaults
+	if err := r.ExtractObj(makeProjectDefaultsKey(ctx, id), &defaults, false); err != nil {
+		return nil, etcderr.InterpretGetError(err, "projectDefaults", id)
+	}
+	return &defaults, nil
+}
+
+// CreateProjectDefaults creates a new projectDefaults
+func (r *Etcd) CreateProjectDefaults(ctx kubeapi.Context, defaults *api.ProjectDefaults) error {
+	err := r.CreateObj(makeProjectDefaultsKey(ctx, defaults.ID), defaults, 0)
+	return etcderr.InterpretCreateError(err, "projectDefaults", defaults.ID)
+}
+
+// UpdateProjectDefaults updates an existing projectDefaults
+func (r *Etcd) UpdateProjectDefaults(ctx kubeapi.Context, defaults *api.ProjectDefaults) error {
+	err := r.SetObj(makeProjectDefaultsKey(ctx, defaults.ID), defaults)
+	return etcderr.InterpretUpdateError(err, "projectDefaults", defaults.ID)
+}
+
+// DeleteProjectDefaults deletes an existing projectDefaults
+func (r *Etcd) DeleteProjectDefaults(ctx kubeapi.Context, id string) error {
+	err := r.Delete(makeProjectDefaultsKey(ctx, id), false)
+	return etcderr.InterpretDeleteError(err, "projectDefaults", id)
+}
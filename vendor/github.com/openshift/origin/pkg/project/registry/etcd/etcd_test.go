@@ -227,9 +227,9 @@ func TestEtcdUpdateProject(t *testing.T) {
 	ctx := kubeapi.NewContext()
 	fakeClient := tools.NewFakeEtcdClient(t)
 	registry := NewTestEtcd(fakeClient)
-	err := registry.UpdateProject(ctx, &api.Project{})
-	if err == nil {
-		t.Error("Unexpected non-error")
+	err := registry.UpdateProject(ctx, &api.Project{JSONBase: kubeapi.JSONBase{ID: "foo"}})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
 	}
 }
 
@@ -0,0 +1,200 @@
+package overview
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/apiserver"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	"github.com/openshift/origin/pkg/project/api"
+	routeapi "github.com/openshift/origin/pkg/route/api"
+	"github.com/openshift/origin/pkg/util/parallel"
+)
+
+// maxRecentBuilds bounds how many of a project's most recent builds are included in the
+// overview, so a project with a long build history doesn't inflate the response.
+const maxRecentBuilds = 10
+
+// listDeadline bounds how long the overview waits on its five parallel List calls, so one
+// slow or wedged registry can't hang the whole request indefinitely.
+const listDeadline = 10 * time.Second
+
+// ServiceLister is the subset of the service registry the overview endpoint needs.
+type ServiceLister interface {
+	ListServices(ctx kubeapi.Context, selector labels.Selector) (*kubeapi.ServiceList, error)
+}
+
+// RouteLister is the subset of the route registry the overview endpoint needs.
+type RouteLister interface {
+	ListRoutes(ctx kubeapi.Context, selector labels.Selector) (*routeapi.RouteList, error)
+}
+
+// DeploymentConfigLister is the subset of the deployment config registry the overview endpoint needs.
+type DeploymentConfigLister interface {
+	ListDeploymentConfigs(ctx kubeapi.Context, selector labels.Selector) (*deployapi.DeploymentConfigList, error)
+}
+
+// DeploymentLister is the subset of the deployment registry the overview endpoint needs.
+type DeploymentLister interface {
+	ListDeployments(ctx kubeapi.Context, selector labels.Selector) (*deployapi.DeploymentList, error)
+}
+
+// BuildLister is the subset of the build registry the overview endpoint needs.
+type BuildLister interface {
+	ListBuilds(ctx kubeapi.Context, selector labels.Selector) (*buildapi.BuildList, error)
+}
+
+// REST implements a read-only RESTStorage that assembles a project's console overview — its
+// services with their routes, deployment configs with their latest deployment status, and
+// recent builds — from a single Get call. The five registries are read concurrently, since
+// none depends on another, so the call takes as long as the slowest read rather than the sum
+// of all five.
+type REST struct {
+	services          ServiceLister
+	routes            RouteLister
+	deploymentConfigs DeploymentConfigLister
+	deployments       DeploymentLister
+	builds            BuildLister
+}
+
+// NewREST returns a new REST.
+func NewREST(services ServiceLister, routes RouteLister, deploymentConfigs DeploymentConfigLister, deployments DeploymentLister, builds BuildLister) apiserver.RESTStorage {
+	return &REST{
+		services:          services,
+		routes:            routes,
+		deploymentConfigs: deploymentConfigs,
+		deployments:       deployments,
+		builds:            builds,
+	}
+}
+
+// New returns a new ProjectOverview.
+func (r *REST) New() runtime.Object {
+	return &api.ProjectOverview{}
+}
+
+// Get assembles the overview for the project named by id.
+func (r *REST) Get(ctx kubeapi.Context, id string) (runtime.Object, error) {
+	var (
+		services          *kubeapi.ServiceList
+		routes            *routeapi.RouteList
+		deploymentConfigs *deployapi.DeploymentConfigList
+		deployments       *deployapi.DeploymentList
+		builds            *buildapi.BuildList
+	)
+
+	err := parallel.Run(listDeadline,
+		func() (err error) { services, err = r.services.ListServices(ctx, labels.Everything()); return },
+		func() (err error) { routes, err = r.routes.ListRoutes(ctx, labels.Everything()); return },
+		func() (err error) {
+			deploymentConfigs, err = r.deploymentConfigs.ListDeploymentConfigs(ctx, labels.Everything())
+			return
+		},
+		func() (err error) { deployments, err = r.deployments.ListDeployments(ctx, labels.Everything()); return },
+		func() (err error) { builds, err = r.builds.ListBuilds(ctx, labels.Everything()); return },
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.ProjectOverview{
+		JSONBase:          kubeapi.JSONBase{ID: id},
+		Services:          serviceOverviews(id, services.Items, routes.Items),
+		DeploymentConfigs: deploymentConfigOverviews(id, deploymentConfigs.Items, deployments.Items),
+		RecentBuilds:      recentBuilds(id, builds.Items),
+	}, nil
+}
+
+// serviceOverviews pairs each of namespace's Services with the Routes that target it.
+func serviceOverviews(namespace string, services []kubeapi.Service, routes []routeapi.Route) []api.ServiceOverview {
+	overviews := []api.ServiceOverview{}
+	for _, service := range services {
+		if service.Namespace != namespace {
+			continue
+		}
+		overview := api.ServiceOverview{Service: service}
+		for _, route := range routes {
+			if route.Namespace == namespace && route.ServiceName == service.ID {
+				overview.Routes = append(overview.Routes, route)
+			}
+		}
+		overviews = append(overviews, overview)
+	}
+	return overviews
+}
+
+// deploymentConfigOverviews pairs each of namespace's DeploymentConfigs with the state of its
+// most recently created Deployment, identified by the highest Version.
+func deploymentConfigOverviews(namespace string, configs []deployapi.DeploymentConfig, deployments []deployapi.Deployment) []api.DeploymentConfigOverview {
+	latestByConfig := map[string]deployapi.Deployment{}
+	for _, deployment := range deployments {
+		if deployment.Namespace != namespace {
+			continue
+		}
+		if current, ok := latestByConfig[deployment.ConfigID]; !ok || deployment.Version > current.Version {
+			latestByConfig[deployment.ConfigID] = deployment
+		}
+	}
+
+	overviews := []api.DeploymentConfigOverview{}
+	for _, config := range configs {
+		if config.Namespace != namespace {
+			continue
+		}
+		overview := api.DeploymentConfigOverview{DeploymentConfig: config}
+		if latest, ok := latestByConfig[config.ID]; ok {
+			overview.LatestDeploymentStatus = latest.State
+		}
+		overviews = append(overviews, overview)
+	}
+	return overviews
+}
+
+// recentBuilds returns up to maxRecentBuilds of namespace's Builds, newest first.
+func recentBuilds(namespace string, builds []buildapi.Build) []buildapi.Build {
+	matched := make([]buildapi.Build, 0, len(builds))
+	for _, build := range builds {
+		if build.Namespace == namespace {
+			matched = append(matched, build)
+		}
+	}
+	sort.Sort(sort.Reverse(byCreationTimestamp(matched)))
+	if len(matched) > maxRecentBuilds {
+		matched = matched[:maxRecentBuilds]
+	}
+	return matched
+}
+
+type byCreationTimestamp []buildapi.Build
+
+func (b byCreationTimestamp) Len() int      { return len(b) }
+func (b byCreationTimestamp) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+func (b byCreationTimestamp) Less(i, j int) bool {
+	return b[i].CreationTimestamp.Time.Before(b[j].CreationTimestamp.Time)
+}
+
+// List is not supported for the overview; it is only ever fetched for one project at a time.
+func (r *REST) List(ctx kubeapi.Context, selector, fields labels.Selector) (runtime.Object, error) {
+	return nil, fmt.Errorf("projectOverview can't be listed")
+}
+
+// Create is not supported for the overview.
+func (r *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
+	return nil, fmt.Errorf("projectOverview can't be created")
+}
+
+// Update is not supported for the overview.
+func (r *REST) Update(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
+	return nil, fmt.Errorf("projectOverview can't be updated")
+}
+
+// Delete is not supported for the overview.
+func (r *REST) Delete(ctx kubeapi.Context, id string) (<-chan runtime.Object, error) {
+	return nil, fmt.Errorf("projectOverview can't be deleted")
+}
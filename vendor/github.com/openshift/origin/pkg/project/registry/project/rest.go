@@ -10,18 +10,55 @@ import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
 
+	originapi "github.com/openshift/origin/pkg/api"
 	"github.com/openshift/origin/pkg/project/api"
 	"github.com/openshift/origin/pkg/project/api/validation"
+	"github.com/openshift/origin/pkg/project/lifecycle"
+	"github.com/openshift/origin/pkg/util/apierrors"
+	"github.com/openshift/origin/pkg/webhook/notify"
 )
 
 // REST implements the RESTStorage interface in terms of an Registry.
 type REST struct {
 	registry Registry
+
+	// maxProjectsPerRequester bounds how many projects a single requester, identified by the
+	// ProjectRequesterAnnotation on the project being created, may own. Zero means unlimited.
+	maxProjectsPerRequester int
+
+	// notifier delivers a "project.created" event whenever a project is created. May be
+	// nil, in which case no notifications are sent.
+	notifier *notify.Notifier
+
+	// lifecycleHook is called synchronously before a project is created or deleted, letting
+	// external provisioning systems (DNS, billing, LDAP groups) run in lockstep with the
+	// project rather than racing notifier's fire-and-forget delivery. May be nil, in which
+	// case Create and Delete proceed unconditionally.
+	lifecycleHook *lifecycle.Hook
 }
 
-// NewStorage returns a new REST.
+// NewREST returns a new REST.
 func NewREST(registry Registry) apiserver.RESTStorage {
-	return &REST{registry}
+	return &REST{registry: registry}
+}
+
+// NewRESTWithRequesterQuota returns a new REST that additionally rejects Create when the
+// requester named by the ProjectRequesterAnnotation already owns maxProjectsPerRequester
+// projects. A maxProjectsPerRequester of zero means unlimited, equivalent to NewREST.
+func NewRESTWithRequesterQuota(registry Registry, maxProjectsPerRequester int) apiserver.RESTStorage {
+	return &REST{registry: registry, maxProjectsPerRequester: maxProjectsPerRequester}
+}
+
+// NewRESTWithRequesterQuotaAndNotifier is NewRESTWithRequesterQuota plus a Notifier that
+// receives a "project.created" event whenever Create succeeds.
+func NewRESTWithRequesterQuotaAndNotifier(registry Registry, maxProjectsPerRequester int, notifier *notify.Notifier) apiserver.RESTStorage {
+	return &REST{registry: registry, maxProjectsPerRequester: maxProjectsPerRequester, notifier: notifier}
+}
+
+// NewRESTWithRequesterQuotaNotifierAndLifecycleHook is NewRESTWithRequesterQuotaAndNotifier
+// plus a lifecycle.Hook called synchronously around Create and Delete.
+func NewRESTWithRequesterQuotaNotifierAndLifecycleHook(registry Registry, maxProjectsPerRequester int, notifier *notify.Notifier, lifecycleHook *lifecycle.Hook) apiserver.RESTStorage {
+	return &REST{registry: registry, maxProjectsPerRequester: maxProjectsPerRequester, notifier: notifier, lifecycleHook: lifecycleHook}
 }
 
 // New returns a new Project for use with Create and Update.
@@ -52,7 +89,7 @@ func (s *REST) Get(ctx kubeapi.Context, id string) (runtime.Object, error) {
 func (s *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
 	project, ok := obj.(*api.Project)
 	if !ok {
-		return nil, fmt.Errorf("not a project: %#v", obj)
+		return nil, apierrors.NewBadRequest("project", fmt.Sprintf("not a project: %#v", obj))
 	}
 
 	// TODO decide if we should set namespace == name, think longer term we need some type of reservation here
@@ -68,23 +105,104 @@ func (s *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.O
 		return nil, errors.NewInvalid("project", project.ID, errs)
 	}
 
+	if err := s.checkRequesterQuota(ctx, project); err != nil {
+		return nil, err
+	}
+
 	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		if s.lifecycleHook != nil {
+			if err := s.lifecycleHook.Call(lifecycle.Event{Type: "project.create", ID: project.ID, Timestamp: util.Now()}); err != nil {
+				return nil, err
+			}
+		}
 		if err := s.registry.CreateProject(ctx, project); err != nil {
 			return nil, err
 		}
+		if s.notifier != nil {
+			s.notifier.Notify(notify.Event{Type: "project.created", ID: project.ID, Timestamp: util.Now()})
+		}
 		return s.Get(ctx, project.ID)
 	}), nil
 }
 
-// Update is not supported for Projects, as they are immutable.
+// checkRequesterQuota rejects the Create if the requester named by the project's
+// ProjectRequesterAnnotation already owns maxProjectsPerRequester projects.
+func (s *REST) checkRequesterQuota(ctx kubeapi.Context, project *api.Project) error {
+	if s.maxProjectsPerRequester <= 0 {
+		return nil
+	}
+	requester, ok := project.Annotations[api.ProjectRequesterAnnotation]
+	if !ok || len(requester) == 0 {
+		return nil
+	}
+
+	existing, err := s.registry.ListProjects(ctx, labels.Everything())
+	if err != nil {
+		return err
+	}
+	owned := 0
+	for _, p := range existing.Items {
+		if p.Annotations[api.ProjectRequesterAnnotation] == requester {
+			owned++
+		}
+	}
+	if owned >= s.maxProjectsPerRequester {
+		return apierrors.NewForbidden("project", requester, fmt.Sprintf("requester %q already owns %d project(s), which is the maximum allowed", requester, s.maxProjectsPerRequester))
+	}
+	return nil
+}
+
+// Update modifies an existing Project. Only DisplayName, Description, Labels, and
+// Annotations may change; a Project's ID and Namespace are immutable.
 func (s *REST) Update(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
-	// TODO handle update of display name, labels, etc.
-	return nil, fmt.Errorf("Projects may not be changed.")
+	project, ok := obj.(*api.Project)
+	if !ok {
+		return nil, apierrors.NewBadRequest("project", fmt.Sprintf("not a project: %#v", obj))
+	}
+
+	older, err := s.registry.GetProject(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if errs := validation.ValidateProjectUpdate(older, project); len(errs) > 0 {
+		return nil, errors.NewInvalid("project", project.ID, errs)
+	}
+
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		if err := s.registry.UpdateProject(ctx, project); err != nil {
+			return nil, err
+		}
+		return s.Get(ctx, project.ID)
+	}), nil
 }
 
-// Delete asynchronously deletes a Project specified by its id.
+// Delete asynchronously deletes a Project specified by its id. It implements
+// apiserver.RESTStorage and is equivalent to calling DeleteWithOptions with nil options.
 func (s *REST) Delete(ctx kubeapi.Context, id string) (<-chan runtime.Object, error) {
+	return s.DeleteWithOptions(ctx, id, nil)
+}
+
+// DeleteWithOptions deletes the Project specified by its id, honoring options.
+//
+// Cascading (options.ShouldCascade(), the default) preserves the original Delete behavior:
+// s.lifecycleHook, if set, runs before the project record is removed, giving external
+// provisioning systems (DNS, billing, LDAP groups) a chance to deprovision whatever they set
+// up for the project.
+//
+// Requesting Cascade=false skips the lifecycle hook, so the project record is removed but
+// whatever the hook would have deprovisioned is left in place -- orphaned -- for an operator
+// to clean up by hand.
+//
+// GracePeriodSeconds is accepted for forward compatibility but has no effect yet; see
+// api.DeleteOptions.
+func (s *REST) DeleteWithOptions(ctx kubeapi.Context, id string, options *originapi.DeleteOptions) (<-chan runtime.Object, error) {
 	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		if options.ShouldCascade() && s.lifecycleHook != nil {
+			if err := s.lifecycleHook.Call(lifecycle.Event{Type: "project.delete", ID: id, Timestamp: util.Now()}); err != nil {
+				return nil, err
+			}
+		}
 		return &kubeapi.Status{Status: kubeapi.StatusSuccess}, s.registry.DeleteProject(ctx, id)
 	}), nil
 }
@@ -167,6 +167,54 @@ func TestCreateProjectOK(t *testing.T) {
 	}
 }
 
+func TestCreateProjectOverRequesterQuota(t *testing.T) {
+	mockRegistry := test.NewProjectRegistry()
+	mockRegistry.Projects = &api.ProjectList{
+		Items: []api.Project{
+			{
+				JSONBase:    kubeapi.JSONBase{ID: "foo"},
+				Annotations: map[string]string{api.ProjectRequesterAnnotation: "bob"},
+			},
+		},
+	}
+	storage := REST{registry: mockRegistry, maxProjectsPerRequester: 1}
+
+	channel, err := storage.Create(nil, &api.Project{
+		JSONBase:    kubeapi.JSONBase{ID: "bar"},
+		Annotations: map[string]string{api.ProjectRequesterAnnotation: "bob"},
+	})
+	if channel != nil {
+		t.Errorf("Expected nil channel, got %v", channel)
+	}
+	if err == nil {
+		t.Fatal("Unexpected nil err")
+	}
+}
+
+func TestCreateProjectUnderRequesterQuota(t *testing.T) {
+	mockRegistry := test.NewProjectRegistry()
+	mockRegistry.Projects = &api.ProjectList{
+		Items: []api.Project{
+			{
+				JSONBase:    kubeapi.JSONBase{ID: "foo"},
+				Annotations: map[string]string{api.ProjectRequesterAnnotation: "alice"},
+			},
+		},
+	}
+	storage := REST{registry: mockRegistry, maxProjectsPerRequester: 1}
+
+	channel, err := storage.Create(nil, &api.Project{
+		JSONBase:    kubeapi.JSONBase{ID: "bar"},
+		Annotations: map[string]string{api.ProjectRequesterAnnotation: "bob"},
+	})
+	if channel == nil {
+		t.Errorf("Expected non-nil channel, got %v", channel)
+	}
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
 func TestGetProjectError(t *testing.T) {
 	mockRegistry := test.NewProjectRegistry()
 	mockRegistry.Err = fmt.Errorf("bad")
@@ -200,18 +248,49 @@ func TestGetProjectOK(t *testing.T) {
 	}
 }
 
-func TestUpdateProject(t *testing.T) {
-	storage := REST{}
-	channel, err := storage.Update(nil, &api.Project{})
+func TestUpdateProjectOK(t *testing.T) {
+	mockRegistry := test.NewProjectRegistry()
+	mockRegistry.Project = &api.Project{
+		JSONBase: kubeapi.JSONBase{ID: "foo", Namespace: "foo"},
+	}
+	storage := REST{registry: mockRegistry}
+
+	channel, err := storage.Update(nil, &api.Project{
+		JSONBase:    kubeapi.JSONBase{ID: "foo", Namespace: "foo"},
+		DisplayName: "Foo",
+		Annotations: map[string]string{api.ProjectContactAnnotation: "foo@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	select {
+	case result := <-channel:
+		project, ok := result.(*api.Project)
+		if !ok {
+			t.Errorf("Expected project type, got: %#v", result)
+		}
+		if project.DisplayName != "Foo" {
+			t.Errorf("Unexpected project: %#v", project)
+		}
+	case <-time.After(50 * time.Millisecond):
+		t.Errorf("Timed out waiting for result")
+	}
+}
+
+func TestUpdateProjectChangesID(t *testing.T) {
+	mockRegistry := test.NewProjectRegistry()
+	mockRegistry.Project = &api.Project{
+		JSONBase: kubeapi.JSONBase{ID: "foo"},
+	}
+	storage := REST{registry: mockRegistry}
+
+	channel, err := storage.Update(nil, &api.Project{JSONBase: kubeapi.JSONBase{ID: "bar"}})
 	if channel != nil {
 		t.Errorf("Unexpected non-nil channel: %#v", channel)
 	}
 	if err == nil {
 		t.Fatal("Unexpected nil err")
 	}
-	if strings.Index(err.Error(), "Projects may not be changed.") == -1 {
-		t.Errorf("Expected 'may not be changed' error, got: %#v", err)
-	}
 }
 
 func TestDeleteProject(t *testing.T) {
@@ -0,0 +1,22 @@
+package projectdefaults
+
+import (
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/openshift/origin/pkg/project/api"
+)
+
+// Registry is an interface for things that know how to store ProjectDefaults objects.
+type Registry interface {
+	// ListProjectDefaults obtains a list of ProjectDefaults that match a selector.
+	ListProjectDefaults(ctx kubeapi.Context, selector labels.Selector) (*api.ProjectDefaultsList, error)
+	// GetProjectDefaults retrieves a specific ProjectDefaults.
+	GetProjectDefaults(ctx kubeapi.Context, id string) (*api.ProjectDefaults, error)
+	// CreateProjectDefaults creates a new ProjectDefaults.
+	CreateProjectDefaults(ctx kubeapi.Context, defaults *api.ProjectDefaults) error
+	// UpdateProjectDefaults updates a ProjectDefaults.
+	UpdateProjectDefaults(ctx kubeapi.Context, defaults *api.ProjectDefaults) error
+	// DeleteProjectDefaults deletes a ProjectDefaults.
+	DeleteProjectDefaults(ctx kubeapi.Context, id string) error
+}
@@ -0,0 +1,101 @@
+package projectdefaults
+
+import (
+	"fmt"
+
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/apiserver"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+
+	"github.com/openshift/origin/pkg/project/api"
+	"github.com/openshift/origin/pkg/project/api/validation"
+	"github.com/openshift/origin/pkg/util/apierrors"
+)
+
+// REST implements the RESTStorage interface in terms of a Registry.
+type REST struct {
+	registry Registry
+}
+
+// NewREST returns a new REST.
+func NewREST(registry Registry) apiserver.RESTStorage {
+	return &REST{registry}
+}
+
+// New returns a new ProjectDefaults for use with Create and Update.
+func (s *REST) New() runtime.Object {
+	return &api.ProjectDefaults{}
+}
+
+// List retrieves a list of ProjectDefaults that match selector.
+func (s *REST) List(ctx kubeapi.Context, selector, fields labels.Selector) (runtime.Object, error) {
+	defaults, err := s.registry.ListProjectDefaults(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+	return defaults, nil
+}
+
+// Get retrieves a ProjectDefaults by id.
+func (s *REST) Get(ctx kubeapi.Context, id string) (runtime.Object, error) {
+	defaults, err := s.registry.GetProjectDefaults(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return defaults, nil
+}
+
+// Create registers the given ProjectDefaults.
+func (s *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
+	defaults, ok := obj.(*api.ProjectDefaults)
+	if !ok {
+		return nil, apierrors.NewBadRequest("projectDefaults", fmt.Sprintf("not a projectDefaults: %#v", obj))
+	}
+
+	defaults.CreationTimestamp = util.Now()
+
+	if errs := validation.ValidateProjectDefaults(defaults); len(errs) > 0 {
+		return nil, errors.NewInvalid("projectDefaults", defaults.ID, errs)
+	}
+
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		if err := s.registry.CreateProjectDefaults(ctx, defaults); err != nil {
+			return nil, err
+		}
+		return s.Get(ctx, defaults.ID)
+	}), nil
+}
+
+// Update modifies an existing ProjectDefaults. Its ID is immutable.
+func (s *REST) Update(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
+	defaults, ok := obj.(*api.ProjectDefaults)
+	if !ok {
+		return nil, apierrors.NewBadRequest("projectDefaults", fmt.Sprintf("not a projectDefaults: %#v", obj))
+	}
+
+	older, err := s.registry.GetProjectDefaults(ctx, defaults.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if errs := validation.ValidateProjectDefaultsUpdate(older, defaults); len(errs) > 0 {
+		return nil, errors.NewInvalid("projectDefaults", defaults.ID, errs)
+	}
+
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		if err := s.registry.UpdateProjectDefaults(ctx, defaults); err != nil {
+			return nil, err
+		}
+		return s.Get(ctx, defaults.ID)
+	}), nil
+}
+
+// Delete asynchronously deletes a ProjectDefaults specified by its id.
+func (s *REST) Delete(ctx kubeapi.Context, id string) (<-chan runtime.Object, error) {
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		return &kubeapi.Status{Status: kubeapi.StatusSuccess}, s.registry.DeleteProjectDefaults(ctx, id)
+	}), nil
+}
@@ -0,0 +1,147 @@
+package resourceusage
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/apiserver"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	"github.com/openshift/origin/pkg/project/api"
+)
+
+// PodLister is the subset of the pod registry the resource usage endpoint needs.
+type PodLister interface {
+	ListPods(ctx kubeapi.Context, selector labels.Selector) (*kubeapi.PodList, error)
+}
+
+// BuildLister is the subset of the build registry the resource usage endpoint needs.
+type BuildLister interface {
+	ListBuilds(ctx kubeapi.Context, selector labels.Selector) (*buildapi.BuildList, error)
+}
+
+// DeploymentLister is the subset of the deployment registry the resource usage endpoint needs.
+type DeploymentLister interface {
+	ListDeployments(ctx kubeapi.Context, selector labels.Selector) (*deployapi.DeploymentList, error)
+}
+
+// REST implements a read-only RESTStorage that aggregates pod, build, and deployment counts
+// for a project, identified by its namespace. Results are cached for ttl so that repeated
+// chargeback or console polling doesn't relist every resource in the cluster on every call.
+type REST struct {
+	pods        PodLister
+	builds      BuildLister
+	deployments DeploymentLister
+	ttl         time.Duration
+
+	lock  sync.Mutex
+	items map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	usage   *api.ProjectResourceUsage
+	expires time.Time
+}
+
+// NewREST returns a new REST that caches computed usage for ttl.
+func NewREST(pods PodLister, builds BuildLister, deployments DeploymentLister, ttl time.Duration) apiserver.RESTStorage {
+	return &REST{
+		pods:        pods,
+		builds:      builds,
+		deployments: deployments,
+		ttl:         ttl,
+		items:       make(map[string]cacheEntry),
+	}
+}
+
+// New returns a new ProjectResourceUsage.
+func (r *REST) New() runtime.Object {
+	return &api.ProjectResourceUsage{}
+}
+
+// Get computes, or returns a cached copy of, the resource usage for the project named by id.
+func (r *REST) Get(ctx kubeapi.Context, id string) (runtime.Object, error) {
+	r.lock.Lock()
+	entry, ok := r.items[id]
+	r.lock.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.usage, nil
+	}
+
+	usage, err := r.computeUsage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	r.items[id] = cacheEntry{usage: usage, expires: time.Now().Add(r.ttl)}
+	r.lock.Unlock()
+	return usage, nil
+}
+
+func (r *REST) computeUsage(ctx kubeapi.Context, namespace string) (*api.ProjectResourceUsage, error) {
+	pods, err := r.pods.ListPods(ctx, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	podCount := 0
+	for _, pod := range pods.Items {
+		if pod.Namespace == namespace {
+			podCount++
+		}
+	}
+
+	builds, err := r.builds.ListBuilds(ctx, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	buildCounts := map[string]int{}
+	for _, build := range builds.Items {
+		if build.Namespace == namespace {
+			buildCounts[string(build.Status)]++
+		}
+	}
+
+	deployments, err := r.deployments.ListDeployments(ctx, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	deploymentCounts := map[string]int{}
+	for _, deployment := range deployments.Items {
+		if deployment.Namespace == namespace {
+			deploymentCounts[string(deployment.State)]++
+		}
+	}
+
+	return &api.ProjectResourceUsage{
+		JSONBase:         kubeapi.JSONBase{ID: namespace},
+		PodCount:         podCount,
+		BuildCounts:      buildCounts,
+		DeploymentCounts: deploymentCounts,
+	}, nil
+}
+
+// List is not supported for resource usage; it is only ever fetched for one project at a time.
+func (r *REST) List(ctx kubeapi.Context, selector, fields labels.Selector) (runtime.Object, error) {
+	return nil, fmt.Errorf("projectResourceUsage can't be listed")
+}
+
+// Create is not supported for resource usage.
+func (r *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
+	return nil, fmt.Errorf("projectResourceUsage can't be created")
+}
+
+// Update is not supported for resource usage.
+func (r *REST) Update(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
+	return nil, fmt.Errorf("projectResourceUsage can't be updated")
+}
+
+// Delete is not supported for resource usage.
+func (r *REST) Delete(ctx kubeapi.Context, id string) (<-chan runtime.Object, error) {
+	return nil, fmt.Errorf("projectResourceUsage can't be deleted")
+}
@@ -0,0 +1,115 @@
+// Package sql implements project.Registry on top of database/sql, storing each Project as
+// a codec-encoded blob keyed by ID -- the same model tools.EtcdHelper uses against etcd. It
+// lets a small, single-node install run against a local SQL database instead of standing up
+// an etcd cluster. This package does not import a driver; the caller opens db with whichever
+// database/sql driver it has blank-imported and applies SchemaSQL to it before calling New.
+package sql
+
+import (
+	"database/sql"
+
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	kubeerr "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/project/api"
+)
+
+// SchemaSQL creates the table this registry reads and writes. Run it once against a fresh
+// database before passing the *sql.DB to New.
+const SchemaSQL = `CREATE TABLE IF NOT EXISTS projects (id TEXT PRIMARY KEY, data TEXT NOT NULL)`
+
+// SQL implements project.Registry backed by a database/sql connection.
+type SQL struct {
+	db    *sql.DB
+	codec runtime.Codec
+}
+
+// New returns a new SQL registry backed by db, which must already have SchemaSQL applied.
+func New(db *sql.DB, codec runtime.Codec) *SQL {
+	return &SQL{db: db, codec: codec}
+}
+
+// ListProjects retrieves a list of projects that match selector.
+func (r *SQL) ListProjects(ctx kubeapi.Context, selector labels.Selector) (*api.ProjectList, error) {
+	rows, err := r.db.Query("SELECT data FROM projects")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := &api.ProjectList{}
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var project api.Project
+		if err := r.codec.DecodeInto([]byte(data), &project); err != nil {
+			return nil, err
+		}
+		if selector.Matches(labels.Set(project.Labels)) {
+			list.Items = append(list.Items, project)
+		}
+	}
+	return list, rows.Err()
+}
+
+// GetProject retrieves a specific project.
+func (r *SQL) GetProject(ctx kubeapi.Context, id string) (*api.Project, error) {
+	var data string
+	err := r.db.QueryRow("SELECT data FROM projects WHERE id = ?", id).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, kubeerr.NewNotFound("project", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var project api.Project
+	if err := r.codec.DecodeInto([]byte(data), &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// CreateProject creates a new project.
+func (r *SQL) CreateProject(ctx kubeapi.Context, project *api.Project) error {
+	if _, err := r.GetProject(ctx, project.ID); err == nil {
+		return kubeerr.NewAlreadyExists("project", project.ID)
+	}
+	data, err := r.codec.Encode(project)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec("INSERT INTO projects (id, data) VALUES (?, ?)", project.ID, string(data))
+	return err
+}
+
+// UpdateProject updates an existing project.
+func (r *SQL) UpdateProject(ctx kubeapi.Context, project *api.Project) error {
+	data, err := r.codec.Encode(project)
+	if err != nil {
+		return err
+	}
+	result, err := r.db.Exec("UPDATE projects SET data = ? WHERE id = ?", string(data), project.ID)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return kubeerr.NewNotFound("project", project.ID)
+	}
+	return nil
+}
+
+// DeleteProject deletes an existing project.
+func (r *SQL) DeleteProject(ctx kubeapi.Context, id string) error {
+	result, err := r.db.Exec("DELETE FROM projects WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return kubeerr.NewNotFound("project", id)
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package sql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+
+	"github.com/openshift/origin/pkg/api/latest"
+	"github.com/openshift/origin/pkg/project/api"
+)
+
+// memDriver is a minimal database/sql driver backed by an in-process map, just enough to
+// exercise the fixed set of statements this package issues. It is not a general-purpose SQL
+// engine -- there is no vendored one in this tree -- so memStmt.Exec and memStmt.Query only
+// understand the exact query strings sql.go uses.
+type memDriver struct {
+	rows map[string]string
+}
+
+func (d *memDriver) Open(name string) (driver.Conn, error) {
+	return &memConn{d}, nil
+}
+
+type memConn struct {
+	d *memDriver
+}
+
+func (c *memConn) Prepare(query string) (driver.Stmt, error) {
+	return &memStmt{c.d, query}, nil
+}
+func (c *memConn) Close() error              { return nil }
+func (c *memConn) Begin() (driver.Tx, error) { return nil, sql.ErrTxDone }
+
+type memStmt struct {
+	d     *memDriver
+	query string
+}
+
+func (s *memStmt) Close() error  { return nil }
+func (s *memStmt) NumInput() int { return -1 }
+
+func (s *memStmt) Exec(args []driver.Value) (driver.Result, error) {
+	switch s.query {
+	case "INSERT INTO projects (id, data) VALUES (?, ?)":
+		s.d.rows[args[0].(string)] = args[1].(string)
+	case "UPDATE projects SET data = ? WHERE id = ?":
+		id := args[1].(string)
+		if _, ok := s.d.rows[id]; !ok {
+			return driver.RowsAffected(0), nil
+		}
+		s.d.rows[id] = args[0].(string)
+		return driver.RowsAffected(1), nil
+	case "DELETE FROM projects WHERE id = ?":
+		id := args[0].(string)
+		if _, ok := s.d.rows[id]; !ok {
+			return driver.RowsAffected(0), nil
+		}
+		delete(s.d.rows, id)
+		return driver.RowsAffected(1), nil
+	default:
+		return nil, sql.ErrNoRows
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s *memStmt) Query(args []driver.Value) (driver.Rows, error) {
+	switch s.query {
+	case "SELECT data FROM projects":
+		var data []string
+		for _, v := range s.d.rows {
+			data = append(data, v)
+		}
+		return &memRows{data: data}, nil
+	case "SELECT data FROM projects WHERE id = ?":
+		id := args[0].(string)
+		data, ok := s.d.rows[id]
+		if !ok {
+			return &memRows{}, nil
+		}
+		return &memRows{data: []string{data}}, nil
+	default:
+		return &memRows{}, nil
+	}
+}
+
+type memRows struct {
+	data []string
+	pos  int
+}
+
+func (r *memRows) Columns() []string { return []string{"data"} }
+func (r *memRows) Close() error      { return nil }
+func (r *memRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.data) {
+		return io.EOF
+	}
+	dest[0] = r.data[r.pos]
+	r.pos++
+	return nil
+}
+
+func newTestRegistry(t *testing.T) *SQL {
+	sql.Register(t.Name(), &memDriver{rows: map[string]string{}})
+	db, err := sql.Open(t.Name(), "")
+	if err != nil {
+		t.Fatalf("unexpected error opening db: %v", err)
+	}
+	return New(db, latest.Codec)
+}
+
+func TestSQLCreateAndGetProject(t *testing.T) {
+	registry := newTestRegistry(t)
+	ctx := kubeapi.NewDefaultContext()
+
+	project := &api.Project{JSONBase: kubeapi.JSONBase{ID: "foo"}, DisplayName: "Foo"}
+	if err := registry.CreateProject(ctx, project); err != nil {
+		t.Fatalf("unexpected error creating project: %v", err)
+	}
+
+	got, err := registry.GetProject(ctx, "foo")
+	if err != nil {
+		t.Fatalf("unexpected error getting project: %v", err)
+	}
+	if got.ID != "foo" || got.DisplayName != "Foo" {
+		t.Errorf("unexpected project: %#v", got)
+	}
+}
+
+func TestSQLCreateDuplicateProjectFails(t *testing.T) {
+	registry := newTestRegistry(t)
+	ctx := kubeapi.NewDefaultContext()
+
+	project := &api.Project{JSONBase: kubeapi.JSONBase{ID: "foo"}}
+	if err := registry.CreateProject(ctx, project); err != nil {
+		t.Fatalf("unexpected error creating project: %v", err)
+	}
+	if err := registry.CreateProject(ctx, project); err == nil {
+		t.Fatal("expected an error creating a duplicate project")
+	}
+}
+
+func TestSQLGetMissingProjectFails(t *testing.T) {
+	registry := newTestRegistry(t)
+	ctx := kubeapi.NewDefaultContext()
+
+	if _, err := registry.GetProject(ctx, "missing"); err == nil {
+		t.Fatal("expected an error getting a missing project")
+	}
+}
+
+func TestSQLUpdateAndDeleteProject(t *testing.T) {
+	registry := newTestRegistry(t)
+	ctx := kubeapi.NewDefaultContext()
+
+	project := &api.Project{JSONBase: kubeapi.JSONBase{ID: "foo"}, DisplayName: "Foo"}
+	if err := registry.CreateProject(ctx, project); err != nil {
+		t.Fatalf("unexpected error creating project: %v", err)
+	}
+
+	project.DisplayName = "Bar"
+	if err := registry.UpdateProject(ctx, project); err != nil {
+		t.Fatalf("unexpected error updating project: %v", err)
+	}
+	got, err := registry.GetProject(ctx, "foo")
+	if err != nil {
+		t.Fatalf("unexpected error getting project: %v", err)
+	}
+	if got.DisplayName != "Bar" {
+		t.Errorf("expected updated display name, got %q", got.DisplayName)
+	}
+
+	if err := registry.DeleteProject(ctx, "foo"); err != nil {
+		t.Fatalf("unexpected error deleting project: %v", err)
+	}
+	if _, err := registry.GetProject(ctx, "foo"); err == nil {
+		t.Fatal("expected an error getting a deleted project")
+	}
+}
+
+func TestSQLListProjectsFiltersBySelector(t *testing.T) {
+	registry := newTestRegistry(t)
+	ctx := kubeapi.NewDefaultContext()
+
+	if err := registry.CreateProject(ctx, &api.Project{JSONBase: kubeapi.JSONBase{ID: "a"}, Labels: map[string]string{"env": "prod"}}); err != nil {
+		t.Fatalf("unexpected error creating project: %v", err)
+	}
+	if err := registry.CreateProject(ctx, &api.Project{JSONBase: kubeapi.JSONBase{ID: "b"}, Labels: map[string]string{"env": "dev"}}); err != nil {
+		t.Fatalf("unexpected error creating project: %v", err)
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{"env": "prod"})
+	list, err := registry.ListProjects(ctx, selector)
+	if err != nil {
+		t.Fatalf("unexpected error listing projects: %v", err)
+	}
+	if len(list.Items) != 1 || list.Items[0].ID != "a" {
+		t.Errorf("expected only project 'a', got %#v", list.Items)
+	}
+}
@@ -0,0 +1,57 @@
+package test
+
+import (
+	"sync"
+
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/openshift/origin/pkg/project/api"
+)
+
+type ProjectDefaultsRegistry struct {
+	Err                 error
+	ProjectDefaults     *api.ProjectDefaults
+	ProjectDefaultsList *api.ProjectDefaultsList
+	sync.Mutex
+}
+
+func NewProjectDefaultsRegistry() *ProjectDefaultsRegistry {
+	return &ProjectDefaultsRegistry{}
+}
+
+func (r *ProjectDefaultsRegistry) ListProjectDefaults(ctx kubeapi.Context, selector labels.Selector) (*api.ProjectDefaultsList, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.ProjectDefaultsList, r.Err
+}
+
+func (r *ProjectDefaultsRegistry) GetProjectDefaults(ctx kubeapi.Context, id string) (*api.ProjectDefaults, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.ProjectDefaults, r.Err
+}
+
+func (r *ProjectDefaultsRegistry) CreateProjectDefaults(ctx kubeapi.Context, defaults *api.ProjectDefaults) error {
+	r.Lock()
+	defer r.Unlock()
+
+	r.ProjectDefaults = defaults
+	return r.Err
+}
+
+func (r *ProjectDefaultsRegistry) UpdateProjectDefaults(ctx kubeapi.Context, defaults *api.ProjectDefaults) error {
+	r.Lock()
+	defer r.Unlock()
+
+	r.ProjectDefaults = defaults
+	return r.Err
+}
+
+func (r *ProjectDefaultsRegistry) DeleteProjectDefaults(ctx kubeapi.Context, id string) error {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.Err
+}
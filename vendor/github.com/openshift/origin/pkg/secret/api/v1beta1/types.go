@@ -0,0 +1,24 @@
+package v1beta1
+
+import (
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+)
+
+// A Secret holds credential material -- registry push/pull credentials, hook
+// credentials -- referenced by ID from BuildInput and deployment strategies, so those
+// resources can mount the credential material into a pod instead of embedding it in
+// env values.
+type Secret struct {
+	api.JSONBase `json:",inline" yaml:",inline"`
+	Labels       map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+
+	// Data holds the secret's contents keyed by filename. Each entry is mounted as a
+	// file of that name into pods that reference this Secret.
+	Data map[string]string `json:"data,omitempty" yaml:"data,omitempty"`
+}
+
+// A SecretList is a collection of Secrets.
+type SecretList struct {
+	api.JSONBase `json:",inline" yaml:",inline"`
+	Items        []Secret `json:"items,omitempty" yaml:"items,omitempty"`
+}
@@ -0,0 +1,20 @@
+package validation
+
+import (
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	secretapi "github.com/openshift/origin/pkg/secret/api"
+)
+
+// ValidateSecret tests required fields on a Secret.
+func ValidateSecret(secret *secretapi.Secret) errors.ErrorList {
+	result := errors.ErrorList{}
+
+	if len(secret.ID) == 0 {
+		result = append(result, errors.NewFieldRequired("ID", secret.ID))
+	}
+	if len(secret.Data) == 0 {
+		result = append(result, errors.NewFieldRequired("Data", secret.Data))
+	}
+
+	return result
+}
@@ -0,0 +1,73 @@
+package etcd
+
+import (
+	etcderr "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors/etcd"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
+
+	"github.com/openshift/origin/pkg/secret/api"
+)
+
+// Etcd implements secret.Registry backed by etcd.
+type Etcd struct {
+	tools.EtcdHelper
+}
+
+// New creates an etcd registry.
+func New(helper tools.EtcdHelper) *Etcd {
+	return &Etcd{
+		EtcdHelper: helper,
+	}
+}
+
+func makeSecretKey(id string) string {
+	return "/secrets/" + id
+}
+
+// ListSecrets obtains a list of Secrets.
+func (r *Etcd) ListSecrets(selector labels.Selector) (*api.SecretList, error) {
+	secrets := api.SecretList{}
+	err := r.ExtractList("/secrets", &secrets.Items, &secrets.ResourceVersion)
+	if err != nil {
+		return nil, err
+	}
+	filtered := []api.Secret{}
+	for _, item := range secrets.Items {
+		if selector.Matches(labels.Set(item.Labels)) {
+			filtered = append(filtered, item)
+		}
+	}
+
+	secrets.Items = filtered
+	return &secrets, err
+}
+
+// GetSecret gets a specific Secret specified by its ID.
+func (r *Etcd) GetSecret(id string) (*api.Secret, error) {
+	var secret api.Secret
+	key := makeSecretKey(id)
+	err := r.ExtractObj(key, &secret, false)
+	if err != nil {
+		return nil, etcderr.InterpretGetError(err, "secret", id)
+	}
+	return &secret, nil
+}
+
+// CreateSecret creates a new Secret.
+func (r *Etcd) CreateSecret(secret *api.Secret) error {
+	err := r.CreateObj(makeSecretKey(secret.ID), secret, 0)
+	return etcderr.InterpretCreateError(err, "secret", secret.ID)
+}
+
+// UpdateSecret replaces an existing Secret.
+func (r *Etcd) UpdateSecret(secret *api.Secret) error {
+	err := r.SetObj(makeSecretKey(secret.ID), secret)
+	return etcderr.InterpretUpdateError(err, "secret", secret.ID)
+}
+
+// DeleteSecret deletes a Secret specified by its ID.
+func (r *Etcd) DeleteSecret(id string) error {
+	key := makeSecretKey(id)
+	err := r.Delete(key, false)
+	return etcderr.InterpretDeleteError(err, "secret", id)
+}
@@ -0,0 +1,165 @@
+package etcd
+
+import (
+	"fmt"
+	"testing"
+
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
+	"github.com/coreos/go-etcd/etcd"
+
+	"github.com/openshift/origin/pkg/api/latest"
+	"github.com/openshift/origin/pkg/secret/api"
+)
+
+func NewTestEtcd(client tools.EtcdClient) *Etcd {
+	return New(tools.EtcdHelper{client, latest.Codec, latest.ResourceVersioner})
+}
+
+func TestEtcdListEmptySecrets(t *testing.T) {
+	fakeClient := tools.NewFakeEtcdClient(t)
+	key := "/secrets"
+	fakeClient.Data[key] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Nodes: []*etcd.Node{},
+			},
+		},
+		E: nil,
+	}
+	registry := NewTestEtcd(fakeClient)
+	secrets, err := registry.ListSecrets(labels.Everything())
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if len(secrets.Items) != 0 {
+		t.Errorf("Unexpected secrets list: %#v", secrets)
+	}
+}
+
+func TestEtcdListErrorSecrets(t *testing.T) {
+	fakeClient := tools.NewFakeEtcdClient(t)
+	key := "/secrets"
+	fakeClient.Data[key] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: nil,
+		},
+		E: fmt.Errorf("some error"),
+	}
+	registry := NewTestEtcd(fakeClient)
+	secrets, err := registry.ListSecrets(labels.Everything())
+	if err == nil {
+		t.Error("unexpected nil error")
+	}
+
+	if secrets != nil {
+		t.Errorf("Unexpected non-nil secrets: %#v", secrets)
+	}
+}
+
+func TestEtcdListEverythingSecrets(t *testing.T) {
+	fakeClient := tools.NewFakeEtcdClient(t)
+	key := "/secrets"
+	fakeClient.Data[key] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Nodes: []*etcd.Node{
+					{
+						Value: runtime.EncodeOrDie(latest.Codec, &api.Secret{JSONBase: kubeapi.JSONBase{ID: "foo"}}),
+					},
+					{
+						Value: runtime.EncodeOrDie(latest.Codec, &api.Secret{JSONBase: kubeapi.JSONBase{ID: "bar"}}),
+					},
+				},
+			},
+		},
+		E: nil,
+	}
+	registry := NewTestEtcd(fakeClient)
+	secrets, err := registry.ListSecrets(labels.Everything())
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if len(secrets.Items) != 2 || secrets.Items[0].ID != "foo" || secrets.Items[1].ID != "bar" {
+		t.Errorf("Unexpected secrets list: %#v", secrets)
+	}
+}
+
+func TestEtcdGetSecrets(t *testing.T) {
+	fakeClient := tools.NewFakeEtcdClient(t)
+	fakeClient.Set("/secrets/foo", runtime.EncodeOrDie(latest.Codec, &api.Secret{JSONBase: kubeapi.JSONBase{ID: "foo"}}), 0)
+	registry := NewTestEtcd(fakeClient)
+	secret, err := registry.GetSecret("foo")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if secret.ID != "foo" {
+		t.Errorf("Unexpected secret: %#v", secret)
+	}
+}
+
+func TestEtcdCreateSecret(t *testing.T) {
+	fakeClient := tools.NewFakeEtcdClient(t)
+	fakeClient.TestIndex = true
+	registry := NewTestEtcd(fakeClient)
+	err := registry.CreateSecret(&api.Secret{JSONBase: kubeapi.JSONBase{ID: "foo"}})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	resp, err := fakeClient.Get("/secrets/foo", false, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var secret api.Secret
+	err = latest.Codec.DecodeInto([]byte(resp.Node.Value), &secret)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if secret.ID != "foo" {
+		t.Errorf("Unexpected secret: %#v %s", secret, resp.Node.Value)
+	}
+}
+
+func TestEtcdUpdateOkSecret(t *testing.T) {
+	fakeClient := tools.NewFakeEtcdClient(t)
+	registry := NewTestEtcd(fakeClient)
+	err := registry.UpdateSecret(&api.Secret{JSONBase: kubeapi.JSONBase{ID: "foo"}, Data: map[string]string{"dockercfg": "creds"}})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	resp, err := fakeClient.Get("/secrets/foo", false, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var secret api.Secret
+	err = latest.Codec.DecodeInto([]byte(resp.Node.Value), &secret)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if secret.Data["dockercfg"] != "creds" {
+		t.Errorf("Unexpected secret: %#v", secret)
+	}
+}
+
+func TestEtcdDeleteSecret(t *testing.T) {
+	fakeClient := tools.NewFakeEtcdClient(t)
+	fakeClient.Set("/secrets/foo", runtime.EncodeOrDie(latest.Codec, &api.Secret{JSONBase: kubeapi.JSONBase{ID: "foo"}}), 0)
+	registry := NewTestEtcd(fakeClient)
+	err := registry.DeleteSecret("foo")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(fakeClient.DeletedKeys) != 1 {
+		t.Errorf("Expected 1 delete, found %#v", fakeClient.DeletedKeys)
+	}
+	if fakeClient.DeletedKeys[0] != "/secrets/foo" {
+		t.Errorf("Unexpected key: %s, expected %s", fakeClient.DeletedKeys[0], "/secrets/foo")
+	}
+}
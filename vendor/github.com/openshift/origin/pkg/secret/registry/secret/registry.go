@@ -0,0 +1,15 @@
+package secret
+
+import (
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	api "github.com/openshift/origin/pkg/secret/api"
+)
+
+// Registry is an interface for things that know how to store Secrets.
+type Registry interface {
+	ListSecrets(selector labels.Selector) (*api.SecretList, error)
+	GetSecret(id string) (*api.Secret, error)
+	CreateSecret(secret *api.Secret) error
+	UpdateSecret(secret *api.Secret) error
+	DeleteSecret(id string) error
+}
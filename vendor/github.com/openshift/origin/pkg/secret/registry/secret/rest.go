@@ -0,0 +1,102 @@
+package secret
+
+import (
+	"fmt"
+
+	"code.google.com/p/go-uuid/uuid"
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	kubeerrors "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/apiserver"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/golang/glog"
+
+	secretapi "github.com/openshift/origin/pkg/secret/api"
+	"github.com/openshift/origin/pkg/secret/api/validation"
+)
+
+// REST is an implementation of RESTStorage for the api server.
+type REST struct {
+	registry Registry
+}
+
+func NewREST(registry Registry) apiserver.RESTStorage {
+	return &REST{
+		registry: registry,
+	}
+}
+
+// New creates a new Secret for use with Create and Update
+func (s *REST) New() runtime.Object {
+	return &secretapi.Secret{}
+}
+
+// List obtains a list of Secrets that match selector.
+func (s *REST) List(ctx kubeapi.Context, selector, fields labels.Selector) (runtime.Object, error) {
+	secrets, err := s.registry.ListSecrets(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
+
+// Get obtains the Secret specified by its id.
+func (s *REST) Get(ctx kubeapi.Context, id string) (runtime.Object, error) {
+	secret, err := s.registry.GetSecret(id)
+	if err != nil {
+		return nil, err
+	}
+	return secret, err
+}
+
+// Delete asynchronously deletes the Secret specified by its id.
+func (s *REST) Delete(ctx kubeapi.Context, id string) (<-chan runtime.Object, error) {
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		return &kubeapi.Status{Status: kubeapi.StatusSuccess}, s.registry.DeleteSecret(id)
+	}), nil
+}
+
+// Create registers a given new Secret instance to s.registry.
+func (s *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
+	secret, ok := obj.(*secretapi.Secret)
+	if !ok {
+		return nil, fmt.Errorf("not a secret: %#v", obj)
+	}
+
+	glog.Infof("Creating secret with ID: %v", secret.ID)
+
+	if len(secret.ID) == 0 {
+		secret.ID = uuid.NewUUID().String()
+	}
+
+	if errs := validation.ValidateSecret(secret); len(errs) > 0 {
+		return nil, kubeerrors.NewInvalid("secret", secret.ID, errs)
+	}
+
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		err := s.registry.CreateSecret(secret)
+		if err != nil {
+			return nil, err
+		}
+		return secret, nil
+	}), nil
+}
+
+// Update replaces a given Secret instance with an existing instance in s.registry.
+func (s *REST) Update(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
+	secret, ok := obj.(*secretapi.Secret)
+	if !ok {
+		return nil, fmt.Errorf("not a secret: %#v", obj)
+	}
+	if len(secret.ID) == 0 {
+		return nil, fmt.Errorf("id is unspecified: %#v", secret)
+	}
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		err := s.registry.UpdateSecret(secret)
+		if err != nil {
+			return nil, err
+		}
+		return secret, nil
+	}), nil
+}
@@ -0,0 +1,227 @@
+package secret
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/openshift/origin/pkg/secret/api"
+	"github.com/openshift/origin/pkg/secret/registry/test"
+)
+
+func TestListSecretsError(t *testing.T) {
+	mockRegistry := test.NewSecretRegistry()
+	mockRegistry.Err = fmt.Errorf("test error")
+
+	storage := REST{
+		registry: mockRegistry,
+	}
+
+	secrets, err := storage.List(nil, nil, nil)
+	if err != mockRegistry.Err {
+		t.Errorf("Expected %#v, Got %#v", mockRegistry.Err, err)
+	}
+
+	if secrets != nil {
+		t.Errorf("Unexpected non-nil secrets list: %#v", secrets)
+	}
+}
+
+func TestListSecretsEmptyList(t *testing.T) {
+	mockRegistry := test.NewSecretRegistry()
+	mockRegistry.Secrets = &api.SecretList{
+		Items: []api.Secret{},
+	}
+
+	storage := REST{
+		registry: mockRegistry,
+	}
+
+	secrets, err := storage.List(nil, labels.Everything(), labels.Everything())
+	if err != nil {
+		t.Errorf("Unexpected non-nil error: %#v", err)
+	}
+
+	if len(secrets.(*api.SecretList).Items) != 0 {
+		t.Errorf("Unexpected non-zero secrets list: %#v", secrets)
+	}
+}
+
+func TestCreateSecretBadObject(t *testing.T) {
+	storage := REST{}
+
+	channel, err := storage.Create(nil, &api.SecretList{})
+	if channel != nil {
+		t.Errorf("Expected nil, got %v", channel)
+	}
+	if strings.Index(err.Error(), "not a secret") == -1 {
+		t.Errorf("Expected 'not a secret' error, got '%v'", err.Error())
+	}
+}
+
+func TestCreateRegistrySaveError(t *testing.T) {
+	mockRegistry := test.NewSecretRegistry()
+	mockRegistry.Err = fmt.Errorf("test error")
+	storage := REST{registry: mockRegistry}
+
+	channel, err := storage.Create(nil, &api.Secret{
+		JSONBase: kubeapi.JSONBase{ID: "foo"},
+		Data:     map[string]string{"dockercfg": "creds"},
+	})
+	if channel == nil {
+		t.Errorf("Expected nil channel, got %v", channel)
+	}
+	if err != nil {
+		t.Errorf("Unexpected non-nil error: %#v", err)
+	}
+
+	select {
+	case result := <-channel:
+		status, ok := result.(*kubeapi.Status)
+		if !ok {
+			t.Errorf("Expected status type, got: %#v", result)
+		}
+		if status.Status != kubeapi.StatusFailure || status.Message != "foo" {
+			t.Errorf("Expected failure status, got %#v", status)
+		}
+	case <-time.After(50 * time.Millisecond):
+		t.Errorf("Timed out waiting for result")
+	default:
+	}
+}
+
+func TestCreateSecretOK(t *testing.T) {
+	mockRegistry := test.NewSecretRegistry()
+	storage := REST{registry: mockRegistry}
+
+	channel, err := storage.Create(nil, &api.Secret{
+		JSONBase: kubeapi.JSONBase{ID: "foo"},
+		Data:     map[string]string{"dockercfg": "creds"},
+	})
+	if channel == nil {
+		t.Errorf("Expected nil channel, got %v", channel)
+	}
+	if err != nil {
+		t.Errorf("Unexpected non-nil error: %#v", err)
+	}
+
+	select {
+	case result := <-channel:
+		secret, ok := result.(*api.Secret)
+		if !ok {
+			t.Errorf("Expected secret type, got: %#v", result)
+		}
+		if secret.ID != "foo" {
+			t.Errorf("Unexpected secret: %#v", secret)
+		}
+	case <-time.After(50 * time.Millisecond):
+		t.Errorf("Timed out waiting for result")
+	default:
+	}
+}
+
+func TestGetSecretError(t *testing.T) {
+	mockRegistry := test.NewSecretRegistry()
+	mockRegistry.Err = fmt.Errorf("bad")
+	storage := REST{registry: mockRegistry}
+
+	secret, err := storage.Get(nil, "foo")
+	if secret != nil {
+		t.Errorf("Unexpected non-nil secret: %#v", secret)
+	}
+	if err != mockRegistry.Err {
+		t.Errorf("Expected %#v, got %#v", mockRegistry.Err, err)
+	}
+}
+
+func TestGetSecretOK(t *testing.T) {
+	mockRegistry := test.NewSecretRegistry()
+	mockRegistry.Secret = &api.Secret{
+		JSONBase: kubeapi.JSONBase{ID: "foo"},
+	}
+	storage := REST{registry: mockRegistry}
+
+	secret, err := storage.Get(nil, "foo")
+	if secret == nil {
+		t.Error("Unexpected nil secret")
+	}
+	if err != nil {
+		t.Errorf("Unexpected non-nil error: %v", err)
+	}
+	if secret.(*api.Secret).ID != "foo" {
+		t.Errorf("Unexpected secret: %#v", secret)
+	}
+}
+
+func TestUpdateSecretBadObject(t *testing.T) {
+	storage := REST{}
+
+	channel, err := storage.Update(nil, &api.SecretList{})
+	if channel != nil {
+		t.Errorf("Expected nil, got %v", channel)
+	}
+	if strings.Index(err.Error(), "not a secret:") == -1 {
+		t.Errorf("Expected 'not a secret' error, got %v", err)
+	}
+}
+
+func TestUpdateSecretMissingID(t *testing.T) {
+	storage := REST{}
+
+	channel, err := storage.Update(nil, &api.Secret{})
+	if channel != nil {
+		t.Errorf("Expected nil, got %v", channel)
+	}
+	if strings.Index(err.Error(), "id is unspecified:") == -1 {
+		t.Errorf("Expected 'id is unspecified' error, got %v", err)
+	}
+}
+
+func TestUpdateSecretOK(t *testing.T) {
+	mockRegistry := test.NewSecretRegistry()
+	storage := REST{registry: mockRegistry}
+
+	channel, err := storage.Update(nil, &api.Secret{
+		JSONBase: kubeapi.JSONBase{ID: "bar"},
+	})
+	if err != nil {
+		t.Errorf("Unexpected non-nil error: %#v", err)
+	}
+	result := <-channel
+	secret, ok := result.(*api.Secret)
+	if !ok {
+		t.Errorf("Expected Secret, got %#v", result)
+	}
+	if secret.ID != "bar" {
+		t.Errorf("Unexpected secret returned: %#v", secret)
+	}
+}
+
+func TestDeleteSecret(t *testing.T) {
+	mockRegistry := test.NewSecretRegistry()
+	storage := REST{registry: mockRegistry}
+	channel, err := storage.Delete(nil, "foo")
+	if channel == nil {
+		t.Error("Unexpected nil channel")
+	}
+	if err != nil {
+		t.Errorf("Unexpected non-nil error: %#v", err)
+	}
+
+	select {
+	case result := <-channel:
+		status, ok := result.(*kubeapi.Status)
+		if !ok {
+			t.Errorf("Expected status type, got: %#v", result)
+		}
+		if status.Status != kubeapi.StatusSuccess {
+			t.Errorf("Expected status=success, got: %#v", status)
+		}
+	case <-time.After(50 * time.Millisecond):
+		t.Errorf("Timed out waiting for result")
+	default:
+	}
+}
@@ -0,0 +1,56 @@
+package test
+
+import (
+	"sync"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/openshift/origin/pkg/secret/api"
+)
+
+type SecretRegistry struct {
+	Err     error
+	Secret  *api.Secret
+	Secrets *api.SecretList
+	sync.Mutex
+}
+
+func NewSecretRegistry() *SecretRegistry {
+	return &SecretRegistry{}
+}
+
+func (r *SecretRegistry) ListSecrets(selector labels.Selector) (*api.SecretList, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.Secrets, r.Err
+}
+
+func (r *SecretRegistry) GetSecret(id string) (*api.Secret, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.Secret, r.Err
+}
+
+func (r *SecretRegistry) CreateSecret(secret *api.Secret) error {
+	r.Lock()
+	defer r.Unlock()
+
+	r.Secret = secret
+	return r.Err
+}
+
+func (r *SecretRegistry) UpdateSecret(secret *api.Secret) error {
+	r.Lock()
+	defer r.Unlock()
+
+	r.Secret = secret
+	return r.Err
+}
+
+func (r *SecretRegistry) DeleteSecret(id string) error {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.Err
+}
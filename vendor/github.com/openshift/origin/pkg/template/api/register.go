@@ -7,7 +7,13 @@ import (
 func init() {
 	api.Scheme.AddKnownTypes("",
 		&Template{},
+		&TemplateInstance{},
+		&TemplateInstanceList{},
+		&TemplateDiff{},
 	)
 }
 
-func (*Template) IsAnAPIObject() {}
+func (*Template) IsAnAPIObject()             {}
+func (*TemplateInstance) IsAnAPIObject()     {}
+func (*TemplateInstanceList) IsAnAPIObject() {}
+func (*TemplateDiff) IsAnAPIObject()         {}
@@ -15,6 +15,12 @@ type Template struct {
 	// Optional: Description describes the Template.
 	Description string `json:"description" yaml:"description"`
 
+	// Optional: Version identifies this revision of the Template, ie. "v2" or "1.0.1". It
+	// has no meaning to the processor beyond being recorded on the resulting Config and
+	// used to label a TemplateInstance, so that successive upgrades of the same Template
+	// can be told apart.
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+
 	// Required: Items is an array of Kubernetes resources of Service,
 	// Pod and/or ReplicationController kind.
 	// TODO: Handle unregistered types. Define custom []runtime.Object
@@ -51,4 +57,130 @@ type Parameter struct {
 	// of the Parameter ${Name} expression during the Template to Config
 	// transformation.
 	Value string `json:"value,omitempty" yaml:"value,omitempty"`
+
+	// Optional: Secret indicates the Parameter's Value carries sensitive data, such as a
+	// generated password, and should be redacted wherever it is recorded outside of the
+	// generated Config, such as in a TemplateInstance.
+	Secret bool `json:"secret,omitempty" yaml:"secret,omitempty"`
+}
+
+// redactedParameterValue replaces the Value of a Secret Parameter when it is recorded in a
+// TemplateInstance.
+const redactedParameterValue = "<redacted>"
+
+// TemplateInstance records the result of processing and creating objects from a Template, so
+// that everything a single instantiation produced can later be found, audited, or removed
+// together.
+type TemplateInstance struct {
+	kubeapi.JSONBase `json:",inline" yaml:",inline"`
+
+	// Template is the name of the Template this instance was created from.
+	Template string `json:"template" yaml:"template"`
+
+	// TemplateVersion is the Version of the Template this instance was created from, if any.
+	TemplateVersion string `json:"templateVersion,omitempty" yaml:"templateVersion,omitempty"`
+
+	// Objects lists the objects created by this instantiation.
+	Objects []TemplateInstanceObject `json:"objects,omitempty" yaml:"objects,omitempty"`
+
+	// Parameters records the parameter values used for this instantiation. The Value of any
+	// Parameter marked Secret on the Template is redacted.
+	Parameters []Parameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+}
+
+// TemplateInstanceObject identifies a single object created by a TemplateInstance.
+type TemplateInstanceObject struct {
+	// Kind is the kind of the created object, ie. "Pod", "Service".
+	Kind string `json:"kind" yaml:"kind"`
+
+	// ID is the identifier of the created object.
+	ID string `json:"id" yaml:"id"`
+}
+
+// TemplateInstanceList is a list of TemplateInstance objects.
+type TemplateInstanceList struct {
+	kubeapi.JSONBase `json:",inline" yaml:",inline"`
+	Items            []TemplateInstance `json:"items,omitempty" yaml:"items,omitempty"`
+}
+
+// NewTemplateInstance builds a TemplateInstance recording the objects created from processing
+// template, redacting the values of any Parameters marked Secret.
+func NewTemplateInstance(template *Template, objects []TemplateInstanceObject) *TemplateInstance {
+	parameters := make([]Parameter, len(template.Parameters))
+	for i, param := range template.Parameters {
+		parameters[i] = param
+		if parameters[i].Secret {
+			parameters[i].Value = redactedParameterValue
+		}
+	}
+	return &TemplateInstance{
+		Template:        template.Name,
+		TemplateVersion: template.Version,
+		Objects:         objects,
+		Parameters:      parameters,
+	}
+}
+
+// TemplateDiff summarizes the difference between the objects a new version of a Template
+// would produce and the objects recorded by the most recent TemplateInstance of that same
+// Template, so an upgrade can be reviewed before it is applied.
+//
+// Comparison is by object Kind and ID only; TemplateInstance does not retain full object
+// bodies, so an object present in both Added and Removed's complement (ie. Changed) may or
+// may not actually differ in content.
+type TemplateDiff struct {
+	kubeapi.JSONBase `json:",inline" yaml:",inline"`
+
+	// Template is the name of the Template that was diffed.
+	Template string `json:"template" yaml:"template"`
+
+	// Added lists objects the new Template version would create that do not appear in the
+	// previous TemplateInstance.
+	Added []TemplateInstanceObject `json:"added,omitempty" yaml:"added,omitempty"`
+
+	// Changed lists objects that appear in both the new Template version and the previous
+	// TemplateInstance. See the TemplateDiff doc comment for the caveat on this category.
+	Changed []TemplateInstanceObject `json:"changed,omitempty" yaml:"changed,omitempty"`
+
+	// Removed lists objects present in the previous TemplateInstance that the new Template
+	// version would no longer create.
+	Removed []TemplateInstanceObject `json:"removed,omitempty" yaml:"removed,omitempty"`
+}
+
+// NewTemplateDiff computes the TemplateDiff between the objects a new Template version
+// produced (newObjects) and those recorded by the previous instantiation of that Template,
+// if any.
+func NewTemplateDiff(template *Template, newObjects []TemplateInstanceObject, previous *TemplateInstance) *TemplateDiff {
+	oldObjects := map[TemplateInstanceObject]bool{}
+	if previous != nil {
+		for _, obj := range previous.Objects {
+			oldObjects[obj] = true
+		}
+	}
+
+	diff := &TemplateDiff{Template: template.Name}
+	seen := map[TemplateInstanceObject]bool{}
+	for _, obj := range newObjects {
+		seen[obj] = true
+		if oldObjects[obj] {
+			diff.Changed = append(diff.Changed, obj)
+		} else {
+			diff.Added = append(diff.Added, obj)
+		}
+	}
+	for _, obj := range previous.objectsOrEmpty() {
+		if !seen[obj] {
+			diff.Removed = append(diff.Removed, obj)
+		}
+	}
+	return diff
+}
+
+// objectsOrEmpty returns the TemplateInstance's Objects, or nil if the TemplateInstance
+// itself is nil, so callers do not need a separate nil check.
+func (t *TemplateInstance) objectsOrEmpty() []TemplateInstanceObject {
+	if t == nil {
+		return nil
+	}
+	return t.Objects
 }
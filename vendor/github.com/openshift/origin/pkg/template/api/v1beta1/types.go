@@ -15,6 +15,9 @@ type Template struct {
 	// Optional: Description describes the Template.
 	Description string `json:"description" yaml:"description"`
 
+	// Optional: Version identifies this revision of the Template.
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+
 	// Required: Items is an array of Kubernetes resources of Service,
 	// Pod and/or ReplicationController kind.
 	// TODO: Handle unregistered types. Define custom []runtime.Object
@@ -51,4 +54,39 @@ type Parameter struct {
 	// of the Parameter ${Name} expression during the Template to Config
 	// transformation.
 	Value string `json:"value,omitempty" yaml:"value,omitempty"`
+
+	// Optional: Secret indicates the Parameter's Value carries sensitive data and should be
+	// redacted wherever it is recorded outside of the generated Config.
+	Secret bool `json:"secret,omitempty" yaml:"secret,omitempty"`
+}
+
+// TemplateInstance records the result of processing and creating objects from a Template.
+type TemplateInstance struct {
+	kubeapi.JSONBase `json:",inline" yaml:",inline"`
+	Template         string                   `json:"template" yaml:"template"`
+	TemplateVersion  string                   `json:"templateVersion,omitempty" yaml:"templateVersion,omitempty"`
+	Objects          []TemplateInstanceObject `json:"objects,omitempty" yaml:"objects,omitempty"`
+	Parameters       []Parameter              `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+}
+
+// TemplateInstanceObject identifies a single object created by a TemplateInstance.
+type TemplateInstanceObject struct {
+	Kind string `json:"kind" yaml:"kind"`
+	ID   string `json:"id" yaml:"id"`
+}
+
+// TemplateInstanceList is a list of TemplateInstance objects.
+type TemplateInstanceList struct {
+	kubeapi.JSONBase `json:",inline" yaml:",inline"`
+	Items            []TemplateInstance `json:"items,omitempty" yaml:"items,omitempty"`
+}
+
+// TemplateDiff summarizes the difference between a new Template version and the previous
+// TemplateInstance of that Template.
+type TemplateDiff struct {
+	kubeapi.JSONBase `json:",inline" yaml:",inline"`
+	Template         string                   `json:"template" yaml:"template"`
+	Added            []TemplateInstanceObject `json:"added,omitempty" yaml:"added,omitempty"`
+	Changed          []TemplateInstanceObject `json:"changed,omitempty" yaml:"changed,omitempty"`
+	Removed          []TemplateInstanceObject `json:"removed,omitempty" yaml:"removed,omitempty"`
 }
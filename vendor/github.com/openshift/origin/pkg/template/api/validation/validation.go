@@ -2,12 +2,19 @@ package validation
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/validation"
 
+	"github.com/golang/glog"
+
+	"github.com/openshift/origin/pkg/capabilities"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	"github.com/openshift/origin/pkg/limits"
 	routeapi "github.com/openshift/origin/pkg/route/api"
 	routevalidation "github.com/openshift/origin/pkg/route/api/validation"
 	"github.com/openshift/origin/pkg/template/api"
@@ -27,26 +34,51 @@ func ValidateParameter(param *api.Parameter) (errs errors.ErrorList) {
 	return
 }
 
-// ValidateTemplate tests if required fields in the Template are set.
-func ValidateTemplate(template *api.Template) (errs errors.ErrorList) {
+// ValidateTemplate tests if required fields in the Template are set, and that instantiating
+// it in targetNamespace wouldn't create any item outside targetNamespace.
+func ValidateTemplate(template *api.Template, targetNamespace string) (errs errors.ErrorList) {
 	if len(template.ID) == 0 {
 		errs = append(errs, errors.NewFieldRequired("id", template.ID))
 	}
+	if len(template.Items) > limits.Get().MaxTemplateItems {
+		errs = append(errs, errors.NewFieldTooMany("items", len(template.Items)))
+	}
 	for i, item := range template.Items {
 		err := errors.ErrorList{}
 		switch obj := item.Object.(type) {
 		case *kubeapi.ReplicationController:
 			err = validation.ValidateReplicationController(obj)
+			manifest := &obj.DesiredState.PodTemplate.DesiredState.Manifest
+			err = append(err, validateHostDirPolicy(manifest)...)
+			err = append(err, validateManifestVersionPolicy(manifest)...)
+			err = append(err, validateManifestLimits(manifest)...)
+			err = append(err, validateLabelsSize("labels", obj.Labels)...)
+			err = append(err, validateCrossNamespacePolicy(obj.Namespace, targetNamespace)...)
 		case *kubeapi.Pod:
 			err = validation.ValidatePod(obj)
+			err = append(err, validateHostDirPolicy(&obj.DesiredState.Manifest)...)
+			err = append(err, validateManifestVersionPolicy(&obj.DesiredState.Manifest)...)
+			err = append(err, validateManifestLimits(&obj.DesiredState.Manifest)...)
+			err = append(err, validateLabelsSize("labels", obj.Labels)...)
+			err = append(err, validateCrossNamespacePolicy(obj.Namespace, targetNamespace)...)
 		case *kubeapi.Service:
 			err = validation.ValidateService(obj)
+			err = append(err, validateLabelsSize("labels", obj.Labels)...)
+			err = append(err, validateCrossNamespacePolicy(obj.Namespace, targetNamespace)...)
 		case *routeapi.Route:
 			err = routevalidation.ValidateRoute(obj)
+			err = append(err, validateCrossNamespacePolicy(obj.Namespace, targetNamespace)...)
+		case *deployapi.Deployment:
+			manifest := &obj.ControllerTemplate.PodTemplate.DesiredState.Manifest
+			err = append(err, validateHostDirPolicy(manifest)...)
+			err = append(err, validateManifestVersionPolicy(manifest)...)
+			err = append(err, validateManifestLimits(manifest)...)
+			err = append(err, validateCrossNamespacePolicy(obj.Namespace, targetNamespace)...)
 		default:
 			// Pass-through unknown types.
 		}
-		// ignore namespace validation errors in templates
+		// ignore the upstream namespace format/existence errors in templates; cross-namespace
+		// policy is enforced separately above, by validateCrossNamespacePolicy
 		err = filter(err, "namespace")
 		errs = append(errs, err.PrefixIndex(i).Prefix("items")...)
 	}
@@ -57,6 +89,108 @@ func ValidateTemplate(template *api.Template) (errs errors.ErrorList) {
 	return
 }
 
+// ValidateTemplateInstance tests if required fields in the TemplateInstance are set.
+func ValidateTemplateInstance(instance *api.TemplateInstance) (errs errors.ErrorList) {
+	if len(instance.Template) == 0 {
+		errs = append(errs, errors.NewFieldRequired("template", instance.Template))
+	}
+	return
+}
+
+// validateHostDirPolicy rejects HostDir volumes in manifest unless the cluster's
+// capabilities allow them, since a HostDir volume lets a container escape into the node's
+// filesystem and is unsafe to allow from arbitrary, self-service templates.
+func validateHostDirPolicy(manifest *kubeapi.ContainerManifest) errors.ErrorList {
+	result := errors.ErrorList{}
+	if capabilities.Get().AllowHostDir {
+		return result
+	}
+	for i, volume := range manifest.Volumes {
+		if volume.Source != nil && volume.Source.HostDir != nil {
+			result = append(result, errors.NewFieldInvalid(volumeFieldName(i, "source.hostDir"), volume.Source.HostDir.Path))
+		}
+	}
+	return result
+}
+
+// volumeFieldName builds the field path reported for a rejected volume.
+func volumeFieldName(index int, suffix string) string {
+	return "volumes[" + strconv.Itoa(index) + "]." + suffix
+}
+
+// validateCrossNamespacePolicy rejects a template item whose author-specified namespace
+// differs from the namespace the template is being instantiated into, unless the cluster's
+// capabilities allow it. Templates have no notion of who is instantiating them, so letting an
+// item pick an arbitrary namespace would let a self-service template create or overwrite
+// objects the caller has no access to.
+func validateCrossNamespacePolicy(itemNamespace, targetNamespace string) errors.ErrorList {
+	result := errors.ErrorList{}
+	if len(itemNamespace) == 0 || itemNamespace == targetNamespace {
+		return result
+	}
+	if capabilities.Get().AllowCrossNamespaceItems {
+		return result
+	}
+	result = append(result, errors.NewFieldInvalid("crossNamespace", itemNamespace))
+	return result
+}
+
+// deprecatedManifestVersions are ContainerManifest versions still accepted by upstream
+// validation but which templates should stop relying on.
+var deprecatedManifestVersions = map[string]bool{
+	"v1beta1": true,
+}
+
+// validateManifestVersionPolicy warns when a template item uses a deprecated manifest
+// version and rejects it outright once the operator's configured end-of-support date has
+// passed, so consumers can migrate before the version is removed rather than being broken
+// by a sudden cutover.
+func validateManifestVersionPolicy(manifest *kubeapi.ContainerManifest) errors.ErrorList {
+	result := errors.ErrorList{}
+	version := strings.ToLower(manifest.Version)
+	if !deprecatedManifestVersions[version] {
+		return result
+	}
+	eol := capabilities.Get().ManifestVersionEndOfSupport
+	if eol != nil && time.Now().After(eol.Time) {
+		result = append(result, errors.NewFieldNotSupported("version", manifest.Version))
+		return result
+	}
+	glog.Warningf("template item uses deprecated manifest version %q; migrate to a newer version before it is removed", manifest.Version)
+	return result
+}
+
+// validateManifestLimits rejects manifests that declare more containers, or containers
+// that declare more environment variables, than the operator's configured limits allow,
+// keeping a single template item from producing an object too large for etcd to store.
+func validateManifestLimits(manifest *kubeapi.ContainerManifest) errors.ErrorList {
+	result := errors.ErrorList{}
+	l := limits.Get()
+	if len(manifest.Containers) > l.MaxContainersPerPod {
+		result = append(result, errors.NewFieldTooMany("containers", len(manifest.Containers)))
+	}
+	for i, container := range manifest.Containers {
+		if len(container.Env) > l.MaxEnvVarsPerContainer {
+			result = append(result, errors.NewFieldTooMany("containers["+strconv.Itoa(i)+"].env", len(container.Env)))
+		}
+	}
+	return result
+}
+
+// validateLabelsSize rejects a label map whose combined key and value lengths exceed the
+// operator's configured limit.
+func validateLabelsSize(field string, labelMap map[string]string) errors.ErrorList {
+	result := errors.ErrorList{}
+	size := 0
+	for k, v := range labelMap {
+		size += len(k) + len(v)
+	}
+	if size > limits.Get().MaxLabelsSize {
+		result = append(result, errors.NewFieldTooLong(field, size))
+	}
+	return result
+}
+
 func filter(errs errors.ErrorList, prefix string) errors.ErrorList {
 	if errs == nil {
 		return errs
@@ -2,10 +2,14 @@ package validation
 
 import (
 	"testing"
+	"time"
 
 	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
 
+	"github.com/openshift/origin/pkg/capabilities"
+	"github.com/openshift/origin/pkg/limits"
 	"github.com/openshift/origin/pkg/template/api"
 )
 
@@ -76,7 +80,7 @@ func TestValidateTemplate(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		errs := ValidateTemplate(test.template)
+		errs := ValidateTemplate(test.template, "")
 		if len(errs) != 0 && test.isValidExpected {
 			t.Errorf("Unexpected non-empty error list: %#v", errs)
 		}
@@ -85,3 +89,150 @@ func TestValidateTemplate(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateTemplateHostDirPolicy(t *testing.T) {
+	defer capabilities.SetForTests(capabilities.Get())
+
+	pod := &kubeapi.Pod{
+		JSONBase: kubeapi.JSONBase{ID: "pod1"},
+		DesiredState: kubeapi.PodState{
+			Manifest: kubeapi.ContainerManifest{
+				Version: "v1beta1",
+				Volumes: []kubeapi.Volume{
+					{Name: "vol", Source: &kubeapi.VolumeSource{HostDir: &kubeapi.HostDir{Path: "/etc"}}},
+				},
+			},
+		},
+	}
+	template := &api.Template{
+		JSONBase: kubeapi.JSONBase{ID: "templateId"},
+		Items:    []runtime.EmbeddedObject{{Object: pod}},
+	}
+
+	capabilities.SetForTests(capabilities.Capabilities{AllowHostDir: false})
+	if errs := ValidateTemplate(template, ""); len(errs) == 0 {
+		t.Errorf("Expected a HostDir volume to be rejected when not allowed")
+	}
+
+	capabilities.SetForTests(capabilities.Capabilities{AllowHostDir: true})
+	if errs := ValidateTemplate(template, ""); len(errs) != 0 {
+		t.Errorf("Expected a HostDir volume to be permitted when allowed, got: %#v", errs)
+	}
+}
+
+func TestValidateTemplateManifestVersionPolicy(t *testing.T) {
+	defer capabilities.SetForTests(capabilities.Get())
+
+	pod := &kubeapi.Pod{
+		JSONBase: kubeapi.JSONBase{ID: "pod1"},
+		DesiredState: kubeapi.PodState{
+			Manifest: kubeapi.ContainerManifest{
+				Version: "v1beta1",
+			},
+		},
+	}
+	template := &api.Template{
+		JSONBase: kubeapi.JSONBase{ID: "templateId"},
+		Items:    []runtime.EmbeddedObject{{Object: pod}},
+	}
+
+	capabilities.SetForTests(capabilities.Capabilities{})
+	if errs := ValidateTemplate(template, ""); len(errs) != 0 {
+		t.Errorf("Expected a deprecated manifest version to only warn when no end-of-support date is set, got: %#v", errs)
+	}
+
+	future := util.Time{Time: time.Now().Add(time.Hour)}
+	capabilities.SetForTests(capabilities.Capabilities{ManifestVersionEndOfSupport: &future})
+	if errs := ValidateTemplate(template, ""); len(errs) != 0 {
+		t.Errorf("Expected a deprecated manifest version to be permitted before the end-of-support date, got: %#v", errs)
+	}
+
+	past := util.Time{Time: time.Now().Add(-time.Hour)}
+	capabilities.SetForTests(capabilities.Capabilities{ManifestVersionEndOfSupport: &past})
+	if errs := ValidateTemplate(template, ""); len(errs) == 0 {
+		t.Errorf("Expected a deprecated manifest version to be rejected after the end-of-support date")
+	}
+}
+
+func TestValidateTemplateCrossNamespacePolicy(t *testing.T) {
+	defer capabilities.SetForTests(capabilities.Get())
+
+	newTemplate := func(itemNamespace string) *api.Template {
+		pod := &kubeapi.Pod{
+			JSONBase: kubeapi.JSONBase{ID: "pod1", Namespace: itemNamespace},
+			DesiredState: kubeapi.PodState{
+				Manifest: kubeapi.ContainerManifest{Version: "v1beta2"},
+			},
+		}
+		return &api.Template{
+			JSONBase: kubeapi.JSONBase{ID: "templateId"},
+			Items:    []runtime.EmbeddedObject{{Object: pod}},
+		}
+	}
+
+	capabilities.SetForTests(capabilities.Capabilities{AllowCrossNamespaceItems: false})
+
+	if errs := ValidateTemplate(newTemplate(""), "myproject"); len(errs) != 0 {
+		t.Errorf("Expected an item with no namespace to be valid, got: %#v", errs)
+	}
+
+	if errs := ValidateTemplate(newTemplate("myproject"), "myproject"); len(errs) != 0 {
+		t.Errorf("Expected an item namespace matching the target to be valid, got: %#v", errs)
+	}
+
+	if errs := ValidateTemplate(newTemplate("otherproject"), "myproject"); len(errs) == 0 {
+		t.Errorf("Expected an item namespace differing from the target to be rejected")
+	}
+
+	capabilities.SetForTests(capabilities.Capabilities{AllowCrossNamespaceItems: true})
+	if errs := ValidateTemplate(newTemplate("otherproject"), "myproject"); len(errs) != 0 {
+		t.Errorf("Expected a mismatched item namespace to be permitted when allowed, got: %#v", errs)
+	}
+}
+
+func TestValidateTemplateLimits(t *testing.T) {
+	defer limits.SetForTests(limits.Get())
+
+	pod := &kubeapi.Pod{
+		JSONBase: kubeapi.JSONBase{ID: "pod1"},
+		Labels:   map[string]string{"key": "value"},
+		DesiredState: kubeapi.PodState{
+			Manifest: kubeapi.ContainerManifest{
+				Version: "v1beta2",
+				Containers: []kubeapi.Container{
+					{Name: "a", Image: "img", Env: []kubeapi.EnvVar{{Name: "FOO", Value: "bar"}}},
+					{Name: "b", Image: "img"},
+				},
+			},
+		},
+	}
+	template := &api.Template{
+		JSONBase: kubeapi.JSONBase{ID: "templateId"},
+		Items:    []runtime.EmbeddedObject{{Object: pod}},
+	}
+
+	limits.SetForTests(limits.Limits{MaxContainersPerPod: 10, MaxEnvVarsPerContainer: 10, MaxLabelsSize: 1024, MaxTemplateItems: 10})
+	if errs := ValidateTemplate(template, ""); len(errs) != 0 {
+		t.Errorf("Expected template within limits to be valid, got: %#v", errs)
+	}
+
+	limits.SetForTests(limits.Limits{MaxContainersPerPod: 1, MaxEnvVarsPerContainer: 10, MaxLabelsSize: 1024, MaxTemplateItems: 10})
+	if errs := ValidateTemplate(template, ""); len(errs) == 0 {
+		t.Errorf("Expected too many containers to be rejected")
+	}
+
+	limits.SetForTests(limits.Limits{MaxContainersPerPod: 10, MaxEnvVarsPerContainer: 0, MaxLabelsSize: 1024, MaxTemplateItems: 10})
+	if errs := ValidateTemplate(template, ""); len(errs) == 0 {
+		t.Errorf("Expected too many environment variables to be rejected")
+	}
+
+	limits.SetForTests(limits.Limits{MaxContainersPerPod: 10, MaxEnvVarsPerContainer: 10, MaxLabelsSize: 1, MaxTemplateItems: 10})
+	if errs := ValidateTemplate(template, ""); len(errs) == 0 {
+		t.Errorf("Expected oversized labels to be rejected")
+	}
+
+	limits.SetForTests(limits.Limits{MaxContainersPerPod: 10, MaxEnvVarsPerContainer: 10, MaxLabelsSize: 1024, MaxTemplateItems: 0})
+	if errs := ValidateTemplate(template, ""); len(errs) == 0 {
+		t.Errorf("Expected too many template items to be rejected")
+	}
+}
@@ -0,0 +1,104 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/apiserver"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/template/api"
+	"github.com/openshift/origin/pkg/template/api/validation"
+	. "github.com/openshift/origin/pkg/template/generator"
+	"github.com/openshift/origin/pkg/template/registry/templateinstance"
+)
+
+// DiffStorage implements RESTStorage for computing the difference between a new version of
+// a Template and the most recent TemplateInstance recorded for a Template of the same name.
+type DiffStorage struct {
+	instances templateinstance.Registry
+}
+
+// NewDiffStorage creates new RESTStorage for diffing Template upgrades against instances
+// records in the given registry.
+func NewDiffStorage(instances templateinstance.Registry) *DiffStorage {
+	return &DiffStorage{instances: instances}
+}
+
+func (s *DiffStorage) New() runtime.Object {
+	return &api.Template{}
+}
+
+func (s *DiffStorage) List(ctx kubeapi.Context, selector, fields labels.Selector) (runtime.Object, error) {
+	return nil, errors.New("template.DiffStorage.List() is not implemented.")
+}
+
+func (s *DiffStorage) Get(ctx kubeapi.Context, id string) (runtime.Object, error) {
+	return nil, errors.New("template.DiffStorage.Get() is not implemented.")
+}
+
+// Create processes the submitted Template and diffs the objects it would produce against the
+// most recent TemplateInstance recorded for a Template with the same name, so an upgrade can
+// be reviewed before it is applied.
+func (s *DiffStorage) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
+	template, ok := obj.(*api.Template)
+	if !ok {
+		return nil, errors.New("Not a template config.")
+	}
+	targetNamespace, _ := kubeapi.NamespaceFrom(ctx)
+	if errs := validation.ValidateTemplate(template, targetNamespace); len(errs) > 0 {
+		return nil, errors.New(fmt.Sprintf("Invalid template config: %#v", errs))
+	}
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		generators := map[string]Generator{
+			"expression": NewExpressionValueGenerator(rand.New(rand.NewSource(time.Now().UnixNano()))),
+		}
+		processor := NewTemplateProcessor(generators)
+		cfg, err := processor.Process(template)
+		if err != nil {
+			return nil, err
+		}
+		newObjects := templateInstanceObjects(cfg)
+		previous, err := s.latestInstance(ctx, template.Name)
+		if err != nil {
+			return nil, err
+		}
+		return api.NewTemplateDiff(template, newObjects, previous), nil
+	}), nil
+}
+
+// latestInstance returns the most recently created TemplateInstance recorded for the named
+// Template, or nil if none has been recorded.
+func (s *DiffStorage) latestInstance(ctx kubeapi.Context, name string) (*api.TemplateInstance, error) {
+	if s.instances == nil {
+		return nil, nil
+	}
+	list, err := s.instances.ListTemplateInstances(ctx, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var latest *api.TemplateInstance
+	for i, instance := range list.Items {
+		if instance.Template != name {
+			continue
+		}
+		if latest == nil || instance.CreationTimestamp.After(latest.CreationTimestamp.Time) {
+			latest = &list.Items[i]
+		}
+	}
+	return latest, nil
+}
+
+func (s *DiffStorage) Update(ctx kubeapi.Context, template runtime.Object) (<-chan runtime.Object, error) {
+	return nil, errors.New("template.DiffStorage.Update() is not implemented.")
+}
+
+func (s *DiffStorage) Delete(ctx kubeapi.Context, id string) (<-chan runtime.Object, error) {
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		return nil, errors.New("template.DiffStorage.Delete() is not implemented.")
+	}), nil
+}
@@ -11,7 +11,12 @@ import (
 // ExpressionValueGenerator implements Generator interface. It generates
 // random string based on the input expression. The input expression is
 // a string, which may contain "[a-zA-Z0-9]{length}" constructs,
-// defining range and length of the result random characters.
+// defining range and length of the result random characters. In addition
+// to explicit ranges, the shorthand classes \w (all characters), \d
+// (digits), \a (letters and digits), \l (lowercase letters), \u
+// (uppercase letters) and \s (symbols) may be used, and combined within
+// the same brackets (eg. "[\\l\\u\\d]{8}"), to satisfy downstream
+// password policies that require a mix of character classes.
 //
 // Examples:
 //
@@ -21,6 +26,7 @@ import (
 // "[0-1]{8}"       | "01001100"
 // "0x[A-F0-9]{4}"  | "0xB3AF"
 // "[a-zA-Z0-9]{8}" | "hW4yQU5i"
+// "[\\l\\u\\d]{8}" | "wKq2Fj9x"
 //
 // TODO: Support more regexp constructs.
 type ExpressionValueGenerator struct {
@@ -28,15 +34,18 @@ type ExpressionValueGenerator struct {
 }
 
 const (
-	Alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
-	Numerals = "0123456789"
-	Ascii    = Alphabet + Numerals + "~!@#$%^&*()-_+={}[]\\|<,>.?/\"';:`"
+	LowerCaseAlphabet = "abcdefghijklmnopqrstuvwxyz"
+	UpperCaseAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	Alphabet          = LowerCaseAlphabet + UpperCaseAlphabet
+	Numerals          = "0123456789"
+	Symbols           = "~!@#$%^&*()-_+={}[]\\|<,>.?/\"';:`"
+	Ascii             = Alphabet + Numerals + Symbols
 )
 
 var (
 	rangeExp      = regexp.MustCompile(`([\\]?[a-zA-Z0-9]\-?[a-zA-Z0-9]?)`)
 	generatorsExp = regexp.MustCompile(`\[([a-zA-Z0-9\-\\]+)\](\{([0-9]+)\})`)
-	expressionExp = regexp.MustCompile(`\[(\\w|\\d|\\a)|([a-zA-Z0-9]\-[a-zA-Z0-9])+\]`)
+	expressionExp = regexp.MustCompile(`\[(\\w|\\d|\\a|\\l|\\u|\\s)|([a-zA-Z0-9]\-[a-zA-Z0-9])+\]`)
 )
 
 // NewExpressionValueGenerator creates new ExpressionValueGenerator.
@@ -94,6 +103,12 @@ func replaceWithGenerated(s *string, expression string, ranges [][]byte, length
 			alphabet += Numerals
 		case `\a`:
 			alphabet += Alphabet + Numerals
+		case `\l`:
+			alphabet += LowerCaseAlphabet
+		case `\u`:
+			alphabet += UpperCaseAlphabet
+		case `\s`:
+			alphabet += Symbols
 		default:
 			if slice, err := alphabetSlice(r[0], r[1]); err != nil {
 				return err
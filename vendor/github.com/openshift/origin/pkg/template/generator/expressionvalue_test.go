@@ -18,6 +18,10 @@ func TestExpressionValueGenerator(t *testing.T) {
 		{"[\\a]{10}", "nFWmvmjuaZ"},
 		{"admin[0-9]{2}[A-Z]{2}", "admin32VU"},
 		{"admin[0-9]{2}test[A-Z]{2}", "admin56testGS"},
+		{"[\\l]{4}", "hkof"},
+		{"[\\u]{4}", "TECW"},
+		{"[\\s]{4}", "|(/'"},
+		{"[\\l\\u\\d]{6}", "ZGXdQ9"},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,68 @@
+package etcd
+
+import (
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	etcderr "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors/etcd"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
+
+	"github.com/openshift/origin/pkg/template/api"
+)
+
+const (
+	// TemplateInstancePath is the path to templateInstance resources in etcd
+	TemplateInstancePath string = "/templateInstances"
+)
+
+// Etcd implements templateinstance.Registry backed by etcd.
+type Etcd struct {
+	tools.EtcdHelper
+}
+
+// New returns a new etcd registry.
+func New(helper tools.EtcdHelper) *Etcd {
+	return &Etcd{
+		EtcdHelper: helper,
+	}
+}
+
+// makeTemplateInstanceListKey constructs etcd paths to templateInstance directories
+func makeTemplateInstanceListKey(ctx kubeapi.Context) string {
+	return TemplateInstancePath
+}
+
+// makeTemplateInstanceKey constructs etcd paths to templateInstance items
+func makeTemplateInstanceKey(ctx kubeapi.Context, id string) string {
+	return makeTemplateInstanceListKey(ctx) + "/" + id
+}
+
+// ListTemplateInstances retrieves a list of templateInstances that match selector.
+func (r *Etcd) ListTemplateInstances(ctx kubeapi.Context, selector labels.Selector) (*api.TemplateInstanceList, error) {
+	list := api.TemplateInstanceList{}
+	err := r.ExtractList(makeTemplateInstanceListKey(ctx), &list.Items, &list.ResourceVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// GetTemplateInstance retrieves a specific templateInstance
+func (r *Etcd) GetTemplateInstance(ctx kubeapi.Context, id string) (*api.TemplateInstance, error) {
+	var instance api.TemplateInstance
+	if err := r.ExtractObj(makeTemplateInstanceKey(ctx, id), &instance, false); err != nil {
+		return nil, etcderr.InterpretGetError(err, "templateInstance", id)
+	}
+	return &instance, nil
+}
+
+// CreateTemplateInstance creates a new templateInstance
+func (r *Etcd) CreateTemplateInstance(ctx kubeapi.Context, instance *api.TemplateInstance) error {
+	err := r.CreateObj(makeTemplateInstanceKey(ctx, instance.ID), instance, 0)
+	return etcderr.InterpretCreateError(err, "templateInstance", instance.ID)
+}
+
+// DeleteTemplateInstance deletes an existing templateInstance
+func (r *Etcd) DeleteTemplateInstance(ctx kubeapi.Context, id string) error {
+	err := r.Delete(makeTemplateInstanceKey(ctx, id), false)
+	return etcderr.InterpretDeleteError(err, "templateInstance", id)
+}
@@ -0,0 +1,20 @@
+package templateinstance
+
+import (
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/openshift/origin/pkg/template/api"
+)
+
+// Registry is an interface for things that know how to store TemplateInstance objects.
+type Registry interface {
+	// ListTemplateInstances obtains a list of TemplateInstances that match a selector.
+	ListTemplateInstances(ctx kubeapi.Context, selector labels.Selector) (*api.TemplateInstanceList, error)
+	// GetTemplateInstance retrieves a specific TemplateInstance.
+	GetTemplateInstance(ctx kubeapi.Context, id string) (*api.TemplateInstance, error)
+	// CreateTemplateInstance creates a new TemplateInstance.
+	CreateTemplateInstance(ctx kubeapi.Context, instance *api.TemplateInstance) error
+	// DeleteTemplateInstance deletes a TemplateInstance.
+	DeleteTemplateInstance(ctx kubeapi.Context, id string) error
+}
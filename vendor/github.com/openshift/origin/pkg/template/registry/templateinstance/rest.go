@@ -0,0 +1,86 @@
+package templateinstance
+
+import (
+	"fmt"
+
+	"code.google.com/p/go-uuid/uuid"
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/apiserver"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+
+	"github.com/openshift/origin/pkg/template/api"
+	"github.com/openshift/origin/pkg/template/api/validation"
+)
+
+// REST implements the RESTStorage interface in terms of a Registry.
+type REST struct {
+	registry Registry
+}
+
+// NewREST returns a new REST.
+func NewREST(registry Registry) apiserver.RESTStorage {
+	return &REST{registry}
+}
+
+// New returns a new TemplateInstance for use with Create.
+func (s *REST) New() runtime.Object {
+	return &api.TemplateInstance{}
+}
+
+// List retrieves a list of TemplateInstances that match selector.
+func (s *REST) List(ctx kubeapi.Context, selector, fields labels.Selector) (runtime.Object, error) {
+	instances, err := s.registry.ListTemplateInstances(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+	return instances, nil
+}
+
+// Get retrieves a TemplateInstance by id.
+func (s *REST) Get(ctx kubeapi.Context, id string) (runtime.Object, error) {
+	instance, err := s.registry.GetTemplateInstance(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
+
+// Create registers the given TemplateInstance.
+func (s *REST) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
+	instance, ok := obj.(*api.TemplateInstance)
+	if !ok {
+		return nil, fmt.Errorf("not a templateInstance: %#v", obj)
+	}
+
+	if len(instance.ID) == 0 {
+		instance.ID = uuid.NewUUID().String()
+	}
+	instance.CreationTimestamp = util.Now()
+
+	if errs := validation.ValidateTemplateInstance(instance); len(errs) > 0 {
+		return nil, errors.NewInvalid("templateInstance", instance.ID, errs)
+	}
+
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		if err := s.registry.CreateTemplateInstance(ctx, instance); err != nil {
+			return nil, err
+		}
+		return s.Get(ctx, instance.ID)
+	}), nil
+}
+
+// Update is not supported for TemplateInstances, as they are an immutable record of a
+// past template instantiation.
+func (s *REST) Update(ctx kubeapi.Context, obj runtime.Object) (<-chan runtime.Object, error) {
+	return nil, fmt.Errorf("TemplateInstances may not be changed.")
+}
+
+// Delete asynchronously deletes a TemplateInstance specified by its id.
+func (s *REST) Delete(ctx kubeapi.Context, id string) (<-chan runtime.Object, error) {
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		return &kubeapi.Status{Status: kubeapi.StatusSuccess}, s.registry.DeleteTemplateInstance(ctx, id)
+	}), nil
+}
@@ -0,0 +1,49 @@
+package test
+
+import (
+	"sync"
+
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/openshift/origin/pkg/template/api"
+)
+
+type TemplateInstanceRegistry struct {
+	Err               error
+	TemplateInstance  *api.TemplateInstance
+	TemplateInstances *api.TemplateInstanceList
+	sync.Mutex
+}
+
+func NewTemplateInstanceRegistry() *TemplateInstanceRegistry {
+	return &TemplateInstanceRegistry{}
+}
+
+func (r *TemplateInstanceRegistry) ListTemplateInstances(ctx kubeapi.Context, selector labels.Selector) (*api.TemplateInstanceList, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.TemplateInstances, r.Err
+}
+
+func (r *TemplateInstanceRegistry) GetTemplateInstance(ctx kubeapi.Context, id string) (*api.TemplateInstance, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.TemplateInstance, r.Err
+}
+
+func (r *TemplateInstanceRegistry) CreateTemplateInstance(ctx kubeapi.Context, instance *api.TemplateInstance) error {
+	r.Lock()
+	defer r.Unlock()
+
+	r.TemplateInstance = instance
+	return r.Err
+}
+
+func (r *TemplateInstanceRegistry) DeleteTemplateInstance(ctx kubeapi.Context, id string) error {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.Err
+}
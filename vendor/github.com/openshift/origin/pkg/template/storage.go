@@ -6,25 +6,49 @@ import (
 	"math/rand"
 	"time"
 
+	"code.google.com/p/go-uuid/uuid"
 	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/apiserver"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/golang/glog"
 
 	"github.com/openshift/origin/pkg/config"
+	configapi "github.com/openshift/origin/pkg/config/api"
 	"github.com/openshift/origin/pkg/template/api"
 	"github.com/openshift/origin/pkg/template/api/validation"
 	. "github.com/openshift/origin/pkg/template/generator"
+	"github.com/openshift/origin/pkg/template/registry/templateinstance"
 )
 
 // Storage implements RESTStorage for the Template objects.
-type Storage struct{}
+type Storage struct {
+	// instances records a TemplateInstance for each template processed, if set. May be nil,
+	// in which case no record is kept.
+	instances templateinstance.Registry
+
+	// hooks are run, in order, over every object generated from a processed Template. May be
+	// nil, in which case objects are returned unmodified.
+	hooks []ObjectHook
+}
 
 // NewStorage creates new RESTStorage for the Template objects.
 func NewStorage() *Storage {
 	return &Storage{}
 }
 
+// NewStorageWithInstanceRegistry creates new RESTStorage for the Template objects that also
+// records a TemplateInstance for each successfully processed template.
+func NewStorageWithInstanceRegistry(instances templateinstance.Registry) *Storage {
+	return &Storage{instances: instances}
+}
+
+// NewStorageWithHooks creates new RESTStorage for the Template objects that runs hooks, in
+// order, over every generated object before it is returned in the Config.
+func NewStorageWithHooks(hooks []ObjectHook) *Storage {
+	return &Storage{hooks: hooks}
+}
+
 func (s *Storage) New() runtime.Object {
 	return &api.Template{}
 }
@@ -42,14 +66,15 @@ func (s *Storage) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtim
 	if !ok {
 		return nil, errors.New("Not a template config.")
 	}
-	if errs := validation.ValidateTemplate(template); len(errs) > 0 {
+	targetNamespace, _ := kubeapi.NamespaceFrom(ctx)
+	if errs := validation.ValidateTemplate(template, targetNamespace); len(errs) > 0 {
 		return nil, errors.New(fmt.Sprintf("Invalid template config: %#v", errs))
 	}
 	return apiserver.MakeAsync(func() (runtime.Object, error) {
 		generators := map[string]Generator{
 			"expression": NewExpressionValueGenerator(rand.New(rand.NewSource(time.Now().UnixNano()))),
 		}
-		processor := NewTemplateProcessor(generators)
+		processor := NewTemplateProcessorWithHooks(generators, s.hooks)
 		cfg, err := processor.Process(template)
 		if err != nil {
 			return nil, err
@@ -57,10 +82,40 @@ func (s *Storage) Create(ctx kubeapi.Context, obj runtime.Object) (<-chan runtim
 		if err := config.AddConfigLabels(cfg, labels.Set{"template": template.ID}); err != nil {
 			return nil, err
 		}
+		s.recordInstance(ctx, template, cfg)
 		return cfg, nil
 	}), nil
 }
 
+// recordInstance persists a TemplateInstance describing the objects in cfg, if this Storage
+// was configured with an instance registry. Failure to record is logged but does not fail the
+// template instantiation, since the Config has already been produced for the caller.
+func (s *Storage) recordInstance(ctx kubeapi.Context, template *api.Template, cfg *configapi.Config) {
+	if s.instances == nil {
+		return
+	}
+	instance := api.NewTemplateInstance(template, templateInstanceObjects(cfg))
+	instance.ID = string(uuid.NewUUID())
+	if err := s.instances.CreateTemplateInstance(ctx, instance); err != nil {
+		glog.Errorf("Unable to record template instance for template %q: %v", template.ID, err)
+	}
+}
+
+// templateInstanceObjects extracts the Kind/ID of each object declared by cfg, for recording
+// on a TemplateInstance or diffing against one.
+func templateInstanceObjects(cfg *configapi.Config) []api.TemplateInstanceObject {
+	objects := make([]api.TemplateInstanceObject, 0, len(cfg.Items))
+	for _, item := range cfg.Items {
+		jsonBase, err := runtime.FindJSONBase(item.Object)
+		if err != nil {
+			glog.Errorf("Unable to record template instance object: %v", err)
+			continue
+		}
+		objects = append(objects, api.TemplateInstanceObject{Kind: jsonBase.Kind(), ID: jsonBase.ID()})
+	}
+	return objects
+}
+
 func (s *Storage) Update(ctx kubeapi.Context, template runtime.Object) (<-chan runtime.Object, error) {
 	return nil, errors.New("template.Storage.Update() is not implemented.")
 }
@@ -1,6 +1,8 @@
 package template
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
 	"regexp"
 	"strings"
@@ -18,9 +20,22 @@ import (
 
 var parameterExp = regexp.MustCompile(`\$\{([a-zA-Z0-9\_]+)\}`)
 
+// ObjectHook is invoked once for each object a Template generates, after Parameter
+// substitution but before the resulting Config is returned. It lets an operator adapt public
+// Templates to local cluster conventions - for example injecting an image pull policy or
+// rewriting a registry hostname - without editing the Template itself.
+type ObjectHook interface {
+	// Process returns the (possibly modified) object to keep in the Config, or an error to
+	// abort processing.
+	Process(obj runtime.Object) (runtime.Object, error)
+}
+
 // TemplateProcessor transforms Template objects into Config objects.
 type TemplateProcessor struct {
 	Generators map[string]Generator
+
+	// Hooks are run, in order, over every object generated from a Template.
+	Hooks []ObjectHook
 }
 
 // NewTemplateProcessor creates new TemplateProcessor and initializes
@@ -29,6 +44,12 @@ func NewTemplateProcessor(generators map[string]Generator) *TemplateProcessor {
 	return &TemplateProcessor{Generators: generators}
 }
 
+// NewTemplateProcessorWithHooks creates a new TemplateProcessor that additionally runs each
+// generated object through hooks, in order, before returning the Config.
+func NewTemplateProcessorWithHooks(generators map[string]Generator, hooks []ObjectHook) *TemplateProcessor {
+	return &TemplateProcessor{Generators: generators, Hooks: hooks}
+}
+
 // Process transforms Template object into Config object. It generates
 // Parameter values using the defined set of generators first, and then it
 // substitutes all Parameter expression occurances with their corresponding
@@ -49,9 +70,30 @@ func (p *TemplateProcessor) Process(template *api.Template) (*config.Config, err
 	config.ID = template.ID
 	config.Kind = "Config"
 	config.CreationTimestamp = util.Now()
+
+	if err := p.runObjectHooks(config); err != nil {
+		return nil, err
+	}
 	return config, nil
 }
 
+// runObjectHooks passes each object in cfg through every configured hook, in order,
+// replacing the object with the result.
+func (p *TemplateProcessor) runObjectHooks(cfg *config.Config) error {
+	for i, item := range cfg.Items {
+		obj := item.Object
+		for _, hook := range p.Hooks {
+			var err error
+			obj, err = hook.Process(obj)
+			if err != nil {
+				return err
+			}
+		}
+		cfg.Items[i] = runtime.EmbeddedObject{Object: obj}
+	}
+	return nil
+}
+
 // AddParameter adds new custom parameter to the Template. It overrides
 // the existing parameter, if already defined.
 func (p *TemplateProcessor) AddParameter(t *api.Template, param api.Parameter) {
@@ -73,6 +115,67 @@ func (p *TemplateProcessor) GetParameterByName(t *api.Template, name string) *ap
 	return nil
 }
 
+// SetParameterValue sets the Value of the named Parameter. It returns an error if
+// the Template does not define a Parameter with that name, so that typos in
+// externally supplied overrides are caught rather than silently ignored.
+func (p *TemplateProcessor) SetParameterValue(t *api.Template, name, value string) error {
+	param := p.GetParameterByName(t, name)
+	if param == nil {
+		return fmt.Errorf("template.parameters: unknown parameter %q", name)
+	}
+	param.Value = value
+	return nil
+}
+
+// SetParameterValues applies the given name/value overrides to the Template's
+// Parameters. It returns an error naming the first override that does not match a
+// defined Parameter.
+func (p *TemplateProcessor) SetParameterValues(t *api.Template, values map[string]string) error {
+	for name, value := range values {
+		if err := p.SetParameterValue(t, name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetParameterValuesFromEnv looks up each of the given names using lookup (typically
+// os.LookupEnv) and applies any that are set as Parameter value overrides. Names with
+// no corresponding environment variable are skipped rather than treated as errors,
+// since a pipeline may only want to forward a subset of its environment.
+func (p *TemplateProcessor) SetParameterValuesFromEnv(t *api.Template, names []string, lookup func(string) (string, bool)) error {
+	values := make(map[string]string)
+	for _, name := range names {
+		if value, ok := lookup(name); ok {
+			values[name] = value
+		}
+	}
+	return p.SetParameterValues(t, values)
+}
+
+// ParseParameterFile parses parameter overrides in KEY=VALUE format, one per line, as
+// produced by tools like `docker run --env-file`. Blank lines and lines beginning with
+// '#' are ignored.
+func ParseParameterFile(data []byte) (map[string]string, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid parameter line %q: expected KEY=VALUE", line)
+		}
+		values[strings.TrimSpace(parts[0])] = parts[1]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
 // SubstituteParameters loops over all Environment variables defined for
 // all ReplicationController and Pod containers and substitutes all
 // Parameter expression occurances with their corresponding values.
@@ -8,7 +8,9 @@ import (
 	"testing"
 	"time"
 
+	kubeapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	_ "github.com/GoogleCloudPlatform/kubernetes/pkg/api/latest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
 
 	"github.com/openshift/origin/pkg/api/latest"
@@ -44,6 +46,117 @@ func TestAddParameter(t *testing.T) {
 	}
 }
 
+func TestSetParameterValuesUnknownName(t *testing.T) {
+	var template api.Template
+
+	jsonData, _ := ioutil.ReadFile("../../examples/guestbook/template.json")
+	json.Unmarshal(jsonData, &template)
+
+	processor := NewTemplateProcessor(nil)
+	processor.AddParameter(&template, api.Parameter{Name: "CUSTOM_PARAM", Value: "1"})
+
+	if err := processor.SetParameterValues(&template, map[string]string{"CUSTOM_PARAM": "2"}); err != nil {
+		t.Errorf("Unexpected error setting a known parameter: %v", err)
+	}
+	if p := processor.GetParameterByName(&template, "CUSTOM_PARAM"); p == nil || p.Value != "2" {
+		t.Errorf("Expected CUSTOM_PARAM to be overridden to '2', got %#v", p)
+	}
+
+	if err := processor.SetParameterValues(&template, map[string]string{"DOES_NOT_EXIST": "1"}); err == nil {
+		t.Errorf("Expected an error overriding an unknown parameter")
+	}
+}
+
+func TestSetParameterValuesFromEnv(t *testing.T) {
+	var template api.Template
+
+	jsonData, _ := ioutil.ReadFile("../../examples/guestbook/template.json")
+	json.Unmarshal(jsonData, &template)
+
+	processor := NewTemplateProcessor(nil)
+	processor.AddParameter(&template, api.Parameter{Name: "CUSTOM_PARAM", Value: "1"})
+
+	env := map[string]string{"CUSTOM_PARAM": "fromEnv"}
+	lookup := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+
+	if err := processor.SetParameterValuesFromEnv(&template, []string{"CUSTOM_PARAM", "NOT_SET"}, lookup); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if p := processor.GetParameterByName(&template, "CUSTOM_PARAM"); p == nil || p.Value != "fromEnv" {
+		t.Errorf("Expected CUSTOM_PARAM to be set from the environment, got %#v", p)
+	}
+}
+
+func TestParseParameterFile(t *testing.T) {
+	data := []byte("# comment\nCUSTOM_PARAM=1\n\nOTHER_PARAM=hello=world\n")
+
+	values, err := ParseParameterFile(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if values["CUSTOM_PARAM"] != "1" {
+		t.Errorf("Expected CUSTOM_PARAM=1, got %#v", values)
+	}
+	if values["OTHER_PARAM"] != "hello=world" {
+		t.Errorf("Expected OTHER_PARAM=hello=world, got %#v", values)
+	}
+
+	if _, err := ParseParameterFile([]byte("INVALID_LINE\n")); err == nil {
+		t.Errorf("Expected an error parsing an invalid parameter line")
+	}
+}
+
+type renamingHook struct {
+	suffix string
+}
+
+func (h renamingHook) Process(obj runtime.Object) (runtime.Object, error) {
+	if pod, ok := obj.(*kubeapi.Pod); ok {
+		pod.ID = pod.ID + h.suffix
+	}
+	return obj, nil
+}
+
+type erroringHook struct{}
+
+func (h erroringHook) Process(obj runtime.Object) (runtime.Object, error) {
+	return nil, fmt.Errorf("hook error")
+}
+
+func TestProcessRunsObjectHooks(t *testing.T) {
+	template := api.Template{
+		Items: []runtime.EmbeddedObject{
+			{Object: &kubeapi.Pod{JSONBase: kubeapi.JSONBase{ID: "pod1"}}},
+		},
+	}
+
+	processor := NewTemplateProcessorWithHooks(nil, []ObjectHook{renamingHook{suffix: "-local"}})
+	cfg, err := processor.Process(&template)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	pod, ok := cfg.Items[0].Object.(*kubeapi.Pod)
+	if !ok || pod.ID != "pod1-local" {
+		t.Errorf("Expected the hook to rename the pod, got %#v", cfg.Items[0].Object)
+	}
+}
+
+func TestProcessObjectHookError(t *testing.T) {
+	template := api.Template{
+		Items: []runtime.EmbeddedObject{
+			{Object: &kubeapi.Pod{JSONBase: kubeapi.JSONBase{ID: "pod1"}}},
+		},
+	}
+
+	processor := NewTemplateProcessorWithHooks(nil, []ObjectHook{erroringHook{}})
+	if _, err := processor.Process(&template); err == nil {
+		t.Errorf("Expected the processor to surface the hook error")
+	}
+}
+
 type FooGenerator struct {
 }
 
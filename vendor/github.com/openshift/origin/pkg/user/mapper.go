@@ -0,0 +1,111 @@
+package user
+
+import (
+	"fmt"
+
+	"code.google.com/p/go-uuid/uuid"
+
+	"github.com/openshift/origin/pkg/user/api"
+)
+
+// MappingMethod determines how a Mapper resolves an Identity that has no existing
+// UserIdentityMapping to a User.
+type MappingMethod string
+
+const (
+	// MappingMethodClaim looks for an existing User with the identity's preferred user
+	// name and associates the identity with it, creating the User if it does not already
+	// exist. This is the default, and is appropriate when OpenShift owns the user
+	// lifecycle.
+	MappingMethodClaim MappingMethod = "claim"
+
+	// MappingMethodLookup requires a User to already exist with the identity's preferred
+	// user name, and fails the mapping rather than creating one. Use this when user
+	// accounts are provisioned by an external system.
+	MappingMethodLookup MappingMethod = "lookup"
+
+	// MappingMethodGenerate always provisions a new User for an unmapped identity, using a
+	// generated user name rather than reusing the identity's preferred name, so that
+	// distinct identities can never collide on a single account.
+	MappingMethodGenerate MappingMethod = "generate"
+)
+
+// UserRegistry is the subset of the user registry a Mapper needs to resolve a preferred
+// user name to an existing User.
+type UserRegistry interface {
+	GetUser(name string) (*api.User, error)
+}
+
+// Mapper decides which User an unmapped Identity should be associated with.
+type Mapper interface {
+	// UserFor returns the User that identity should be mapped to. created is true if the
+	// User did not previously exist and was provisioned by this call.
+	UserFor(identity *api.Identity) (u *api.User, created bool, err error)
+}
+
+// NewMapper returns a Mapper that implements method, using registry to look up existing
+// Users and initializer to populate ones it provisions.
+func NewMapper(method MappingMethod, registry UserRegistry, initializer Initializer) (Mapper, error) {
+	switch method {
+	case MappingMethodClaim:
+		return &claimMapper{registry, initializer}, nil
+	case MappingMethodLookup:
+		return &lookupMapper{registry}, nil
+	case MappingMethodGenerate:
+		return &generateMapper{initializer}, nil
+	default:
+		return nil, fmt.Errorf("unsupported user mapping method %q", method)
+	}
+}
+
+// preferredUserName returns the user name an identity would be given absent any policy
+// that says otherwise, prefixed by provider so identities from different providers never
+// collide on the same preferred name.
+func preferredUserName(identity *api.Identity) string {
+	return fmt.Sprintf("%s:%s", identity.Provider, identity.Name)
+}
+
+// claimMapper implements MappingMethodClaim.
+type claimMapper struct {
+	registry    UserRegistry
+	initializer Initializer
+}
+
+func (m *claimMapper) UserFor(identity *api.Identity) (*api.User, bool, error) {
+	name := preferredUserName(identity)
+	if existing, err := m.registry.GetUser(name); err == nil {
+		return existing, false, nil
+	}
+	newUser := &api.User{Name: name}
+	if err := m.initializer.InitializeUser(identity, newUser); err != nil {
+		return nil, false, err
+	}
+	return newUser, true, nil
+}
+
+// lookupMapper implements MappingMethodLookup.
+type lookupMapper struct {
+	registry UserRegistry
+}
+
+func (m *lookupMapper) UserFor(identity *api.Identity) (*api.User, bool, error) {
+	name := preferredUserName(identity)
+	existing, err := m.registry.GetUser(name)
+	if err != nil {
+		return nil, false, fmt.Errorf("no user exists for identity %s, and the lookup mapping method does not create one", name)
+	}
+	return existing, false, nil
+}
+
+// generateMapper implements MappingMethodGenerate.
+type generateMapper struct {
+	initializer Initializer
+}
+
+func (m *generateMapper) UserFor(identity *api.Identity) (*api.User, bool, error) {
+	newUser := &api.User{Name: fmt.Sprintf("%s:%s:%s", identity.Provider, identity.Name, uuid.New())}
+	if err := m.initializer.InitializeUser(identity, newUser); err != nil {
+		return nil, false, err
+	}
+	return newUser, true, nil
+}
@@ -15,14 +15,15 @@ import (
 // Etcd implements UserIdentityMapping backed by etcd.
 type Etcd struct {
 	tools.EtcdHelper
-	initializer user.Initializer
+	mapper user.Mapper
 }
 
-// New returns a new Etcd.
-func New(helper tools.EtcdHelper, initializer user.Initializer) *Etcd {
+// New returns a new Etcd. mapper decides which User an identity with no existing mapping
+// should be associated with.
+func New(helper tools.EtcdHelper, mapper user.Mapper) *Etcd {
 	return &Etcd{
-		EtcdHelper:  helper,
-		initializer: initializer,
+		EtcdHelper: helper,
+		mapper:     mapper,
 	}
 }
 
@@ -53,16 +54,14 @@ func (r *Etcd) CreateOrUpdateUserIdentityMapping(mapping *api.UserIdentityMappin
 
 		// did not previously exist
 		if existing.Identity.Name == "" {
-			uid := uuid.New()
-			existing.User.UID = uid
-			existing.User.Name = name
-			if err := r.initializer.InitializeUser(&mapping.Identity, &existing.User); err != nil {
+			mappedUser, isNewUser, err := r.mapper.UserFor(&mapping.Identity)
+			if err != nil {
 				return in, err
 			}
-
-			// set these again to prevent bad initialization from messing up data
-			existing.User.UID = uid
-			existing.User.Name = name
+			if isNewUser {
+				mappedUser.UID = uuid.New()
+			}
+			existing.User = *mappedUser
 			existing.Identity = mapping.Identity
 
 			found = &existing
@@ -70,9 +69,6 @@ func (r *Etcd) CreateOrUpdateUserIdentityMapping(mapping *api.UserIdentityMappin
 			return &existing, nil
 		}
 
-		if existing.User.Name != name {
-			return in, fmt.Errorf("the provided user name does not match the existing mapping %s", existing.User.Name)
-		}
 		found = &existing
 
 		// TODO: should update identity based on new info as well.
@@ -0,0 +1,82 @@
+// Package apierrors gives origin REST storages one place to construct status-carrying
+// errors -- BadRequest, NotFound, Conflict, Forbidden -- instead of returning bare
+// fmt.Errorf values that the apiserver has no choice but to map to a 500. NotFound and
+// Conflict are thin wrappers over the upstream Kubernetes constructors; BadRequest and
+// Forbidden are added here because this vintage of the Kubernetes API does not define
+// StatusReasons for them.
+package apierrors
+
+import (
+	"net/http"
+
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	kerrors "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+)
+
+const (
+	// StatusReasonBadRequest means the request itself was malformed, such as a REST
+	// storage being handed an object of the wrong type.
+	StatusReasonBadRequest kapi.StatusReason = "BadRequest"
+	// StatusReasonForbidden means the requester is not allowed to perform the requested
+	// action on the named resource.
+	StatusReasonForbidden kapi.StatusReason = "Forbidden"
+)
+
+// NewBadRequest returns an error indicating the request could not be understood by the
+// server, such as when a REST storage is handed an object of the wrong type.
+func NewBadRequest(kind, message string) error {
+	return kerrors.FromObject(&kapi.Status{
+		Status:  kapi.StatusFailure,
+		Code:    http.StatusBadRequest,
+		Reason:  StatusReasonBadRequest,
+		Details: &kapi.StatusDetails{Kind: kind},
+		Message: message,
+	})
+}
+
+// NewForbidden returns an error indicating the named resource is not accessible to the
+// requester for the reason given by message.
+func NewForbidden(kind, name, message string) error {
+	return kerrors.FromObject(&kapi.Status{
+		Status:  kapi.StatusFailure,
+		Code:    http.StatusForbidden,
+		Reason:  StatusReasonForbidden,
+		Details: &kapi.StatusDetails{Kind: kind, ID: name},
+		Message: message,
+	})
+}
+
+// NewNotFound returns an error indicating the resource of the given kind and name was not
+// found.
+func NewNotFound(kind, name string) error {
+	return kerrors.NewNotFound(kind, name)
+}
+
+// NewConflict returns an error indicating the named resource could not be updated because
+// of a conflict, as described by err.
+func NewConflict(kind, name string, err error) error {
+	return kerrors.NewConflict(kind, name, err)
+}
+
+// apiStatus is implemented by any error carrying an api.Status, including the ones
+// returned by this package and by k8s.io's own errors package.
+type apiStatus interface {
+	Status() kapi.Status
+}
+
+// IsBadRequest returns true if err was created by NewBadRequest.
+func IsBadRequest(err error) bool {
+	return reasonForError(err) == StatusReasonBadRequest
+}
+
+// IsForbidden returns true if err was created by NewForbidden.
+func IsForbidden(err error) bool {
+	return reasonForError(err) == StatusReasonForbidden
+}
+
+func reasonForError(err error) kapi.StatusReason {
+	if status, ok := err.(apiStatus); ok {
+		return status.Status().Reason
+	}
+	return kapi.StatusReasonUnknown
+}
@@ -0,0 +1,46 @@
+package apierrors
+
+import (
+	"errors"
+	"testing"
+
+	kerrors "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+)
+
+func TestNewBadRequest(t *testing.T) {
+	err := NewBadRequest("project", "not a project")
+	if !IsBadRequest(err) {
+		t.Errorf("expected IsBadRequest to be true for %v", err)
+	}
+	if IsForbidden(err) {
+		t.Errorf("expected IsForbidden to be false for %v", err)
+	}
+}
+
+func TestNewForbidden(t *testing.T) {
+	err := NewForbidden("project", "foo", "quota exceeded")
+	if !IsForbidden(err) {
+		t.Errorf("expected IsForbidden to be true for %v", err)
+	}
+	if IsBadRequest(err) {
+		t.Errorf("expected IsBadRequest to be false for %v", err)
+	}
+}
+
+func TestNewNotFoundAndConflictDelegateUpstream(t *testing.T) {
+	if !kerrors.IsNotFound(NewNotFound("project", "foo")) {
+		t.Error("expected NewNotFound to be recognized by kerrors.IsNotFound")
+	}
+	if !kerrors.IsConflict(NewConflict("project", "foo", errors.New("boom"))) {
+		t.Error("expected NewConflict to be recognized by kerrors.IsConflict")
+	}
+}
+
+func TestIsBadRequestFalseForOtherErrors(t *testing.T) {
+	if IsBadRequest(errors.New("boom")) {
+		t.Error("expected IsBadRequest to be false for a plain error")
+	}
+	if IsForbidden(kerrors.NewNotFound("project", "foo")) {
+		t.Error("expected IsForbidden to be false for a NotFound error")
+	}
+}
@@ -0,0 +1,44 @@
+package clog
+
+import "testing"
+
+func TestSetLevelAndLevel(t *testing.T) {
+	if l := Level("unset-component"); l != 0 {
+		t.Errorf("expected default level 0 for an unset component, got %d", l)
+	}
+
+	SetLevel("build", 3)
+	if l := Level("build"); l != 3 {
+		t.Errorf("expected level 3, got %d", l)
+	}
+
+	SetLevel("build", 5)
+	if l := Level("build"); l != 5 {
+		t.Errorf("expected level to update to 5, got %d", l)
+	}
+}
+
+func TestLevels(t *testing.T) {
+	SetLevel("deploy", 2)
+	levels := Levels()
+	if l, ok := levels["deploy"]; !ok || l != 2 {
+		t.Errorf("expected Levels() to report deploy=2, got %#v", levels)
+	}
+
+	levels["deploy"] = 100
+	if l := Level("deploy"); l != 2 {
+		t.Errorf("expected Levels() to return a snapshot, but mutating it changed Level() to %d", l)
+	}
+}
+
+func TestLoggerV(t *testing.T) {
+	SetLevel("test-component", 2)
+	log := New("test-component")
+
+	if !log.V(0) || !log.V(2) {
+		t.Errorf("expected levels at or below the component's level to be enabled")
+	}
+	if log.V(3) {
+		t.Errorf("expected a level above the component's level to be disabled")
+	}
+}
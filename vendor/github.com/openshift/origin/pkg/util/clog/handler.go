@@ -0,0 +1,43 @@
+package clog
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// handler serves the admin log-level endpoint: GET returns the current verbosity of every
+// component that has had a level set, and POST sets a single component's level.
+type handler struct{}
+
+// NewHandler returns an http.Handler for reading and changing component log levels at
+// runtime. GET responds with a JSON object of component name to level. POST expects
+// "component" and "level" form values and sets that component's level accordingly.
+func NewHandler() http.Handler {
+	return &handler{}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case "GET":
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(Levels()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	case "POST", "PUT":
+		component := req.FormValue("component")
+		if len(component) == 0 {
+			http.Error(w, "component is required", http.StatusBadRequest)
+			return
+		}
+		level, err := strconv.Atoi(req.FormValue("level"))
+		if err != nil {
+			http.Error(w, "level must be an integer", http.StatusBadRequest)
+			return
+		}
+		SetLevel(component, level)
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,58 @@
+// Package concurrent provides concurrency-safe set utilities for controllers that share
+// state across goroutines, such as BuildController's watch and periodic-resync loops.
+// Kubernetes' own util.StringSet is a plain map and is not safe for concurrent use;
+// StringSet wraps it with a mutex for callers that need it to be.
+package concurrent
+
+import (
+	"sync"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+)
+
+// StringSet is a set of strings safe for concurrent use by multiple goroutines.
+type StringSet struct {
+	lock sync.Mutex
+	set  util.StringSet
+}
+
+// NewStringSet creates a StringSet containing items.
+func NewStringSet(items ...string) *StringSet {
+	return &StringSet{set: util.NewStringSet(items...)}
+}
+
+// Insert adds items to the set.
+func (s *StringSet) Insert(items ...string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.set.Insert(items...)
+}
+
+// Delete removes item from the set.
+func (s *StringSet) Delete(item string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.set.Delete(item)
+}
+
+// Has returns true iff item is contained in the set.
+func (s *StringSet) Has(item string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.set.Has(item)
+}
+
+// Len returns the number of items currently in the set.
+func (s *StringSet) Len() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.set)
+}
+
+// List returns a sorted snapshot of the set's contents. Because it's a snapshot, it may be
+// stale by the time the caller inspects it if another goroutine mutates the set concurrently.
+func (s *StringSet) List() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.set.List()
+}
@@ -0,0 +1,48 @@
+package concurrent
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestStringSetInsertHasDelete(t *testing.T) {
+	s := NewStringSet("a")
+	if !s.Has("a") {
+		t.Errorf("expected set to contain a")
+	}
+
+	s.Insert("b", "c")
+	if s.Len() != 3 {
+		t.Errorf("expected 3 items, got %d", s.Len())
+	}
+
+	s.Delete("b")
+	if s.Has("b") {
+		t.Errorf("expected b to be deleted")
+	}
+	if s.Len() != 2 {
+		t.Errorf("expected 2 items, got %d", s.Len())
+	}
+}
+
+// TestStringSetConcurrentAccess exercises Insert, Has, and Delete from many goroutines at
+// once. It doesn't assert on the resulting state -- the point is that -race has nothing to
+// report, since a plain util.StringSet used the same way would race.
+func TestStringSetConcurrentAccess(t *testing.T) {
+	s := NewStringSet()
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			item := string(rune('a' + i%26))
+			s.Insert(item)
+			s.Has(item)
+			s.List()
+			s.Delete(item)
+		}(i)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,68 @@
+// Package fieldproject implements response payload projection: given a decoded JSON
+// document and a list of dotted field paths, it returns a new document containing only
+// those fields, so a caller such as a dashboard that only wants a resource's name and
+// status doesn't have to receive, or parse, the rest of it.
+//
+// This API server vintage's generic REST handler (see apiserver.RESTHandler in the
+// vendored kubernetes package) always encodes whatever a RESTStorage's Get or List
+// returns in full, and offers no hook to post-process that response before it's written;
+// the "fields" query parameter it does already parse is a field *selector* used to filter
+// which objects match a List, not a projection of which fields of a matched object to
+// return. Wiring real payload projection into the generic handler would mean changing
+// that vendored code, which this package deliberately avoids. Until a future API server
+// version adds a response-processing hook, this package exists for callers that can apply
+// it directly, such as a printer or a purpose-built handler for a resource like
+// BuildConfig whose Parameters and Template make its full representation expensive to
+// return when only a handful of fields are wanted.
+package fieldproject
+
+import "strings"
+
+// Project returns a new map containing only the given dotted field paths from doc. A path
+// segment addresses a key in a nested map, so "status.phase" reaches doc["status"]["phase"].
+// A path that doesn't resolve to a value in doc is silently omitted, on the assumption that
+// a caller projecting a list of heterogeneous objects would rather see a field missing than
+// get an error for one object among many.
+func Project(doc map[string]interface{}, fields []string) map[string]interface{} {
+	result := map[string]interface{}{}
+	for _, field := range fields {
+		path := strings.Split(field, ".")
+		value, ok := lookup(doc, path)
+		if !ok {
+			continue
+		}
+		set(result, path, value)
+	}
+	return result
+}
+
+// lookup walks path through doc's nested maps and returns the value found at the end of
+// it, and whether the full path resolved.
+func lookup(doc map[string]interface{}, path []string) (interface{}, bool) {
+	value, ok := doc[path[0]]
+	if !ok {
+		return nil, false
+	}
+	if len(path) == 1 {
+		return value, true
+	}
+	nested, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	return lookup(nested, path[1:])
+}
+
+// set assigns value into doc at path, creating any intermediate maps path requires.
+func set(doc map[string]interface{}, path []string, value interface{}) {
+	if len(path) == 1 {
+		doc[path[0]] = value
+		return
+	}
+	nested, ok := doc[path[0]].(map[string]interface{})
+	if !ok {
+		nested = map[string]interface{}{}
+		doc[path[0]] = nested
+	}
+	set(nested, path[1:], value)
+}
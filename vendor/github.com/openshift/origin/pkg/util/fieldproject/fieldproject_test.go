@@ -0,0 +1,60 @@
+package fieldproject
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProjectTopLevel(t *testing.T) {
+	doc := map[string]interface{}{
+		"id":     "build-1",
+		"status": "Complete",
+		"parameters": map[string]interface{}{
+			"source": "https://example.com/repo.git",
+		},
+	}
+
+	got := Project(doc, []string{"id", "status"})
+	want := map[string]interface{}{
+		"id":     "build-1",
+		"status": "Complete",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Project() = %#v, want %#v", got, want)
+	}
+}
+
+func TestProjectNestedPath(t *testing.T) {
+	doc := map[string]interface{}{
+		"id": "deploy-1",
+		"status": map[string]interface{}{
+			"phase":   "Running",
+			"message": "waiting on pods",
+		},
+	}
+
+	got := Project(doc, []string{"id", "status.phase"})
+	want := map[string]interface{}{
+		"id": "deploy-1",
+		"status": map[string]interface{}{
+			"phase": "Running",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Project() = %#v, want %#v", got, want)
+	}
+}
+
+func TestProjectMissingFieldOmitted(t *testing.T) {
+	doc := map[string]interface{}{
+		"id": "build-1",
+	}
+
+	got := Project(doc, []string{"id", "status", "status.phase"})
+	want := map[string]interface{}{
+		"id": "build-1",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Project() = %#v, want %#v", got, want)
+	}
+}
@@ -0,0 +1,57 @@
+// Package generatename implements the generateName convention: a caller that would rather
+// not invent a unique ID itself - Build and Deployment creation through their REST storages
+// are the two cases in this tree; BuildConfig instantiation and template processing do not
+// use it - sets a base name and leaves the ID empty, and the server fills the ID in by
+// appending a short random suffix, retrying if that happens to collide with an existing
+// object.
+package generatename
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	kerrors "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+)
+
+// suffixLength is the number of random characters appended to the base name.
+const suffixLength = 5
+
+// charset excludes vowels and characters easily confused with one another, so a generated
+// suffix that ends up in a log or a URL is easy to read aloud or retype.
+const charset = "bcdfghjklmnpqrstvwxz2456789"
+
+// maxAttempts bounds how many collisions Retry will tolerate before giving up, so a caller
+// can't be stuck retrying forever against a registry that always reports a conflict.
+const maxAttempts = 8
+
+// Generate returns base followed by a "-" and suffixLength random characters.
+func Generate(base string) (string, error) {
+	raw := make([]byte, suffixLength)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("unable to generate a name for %q: %v", base, err)
+	}
+	suffix := make([]byte, suffixLength)
+	for i, b := range raw {
+		suffix[i] = charset[int(b)%len(charset)]
+	}
+	return fmt.Sprintf("%s-%s", base, suffix), nil
+}
+
+// Retry calls tryCreate with successively generated names derived from base until tryCreate
+// succeeds, returns an error other than AlreadyExists, or maxAttempts collisions have
+// occurred, whichever happens first.
+func Retry(base string, tryCreate func(name string) error) error {
+	var err error
+	for i := 0; i < maxAttempts; i++ {
+		var name string
+		name, err = Generate(base)
+		if err != nil {
+			return err
+		}
+		err = tryCreate(name)
+		if err == nil || !kerrors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+	return fmt.Errorf("unable to generate a unique name for %q after %d attempts: %v", base, maxAttempts, err)
+}
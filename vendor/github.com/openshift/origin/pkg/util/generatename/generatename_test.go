@@ -0,0 +1,68 @@
+package generatename
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	kerrors "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+)
+
+func TestGenerate(t *testing.T) {
+	name, err := Generate("build")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(name, "build-") {
+		t.Errorf("expected %q to start with \"build-\"", name)
+	}
+	if len(name) != len("build-")+suffixLength {
+		t.Errorf("expected a %d character suffix, got %q", suffixLength, name)
+	}
+}
+
+func TestRetrySucceedsAfterCollisions(t *testing.T) {
+	attempts := 0
+	err := Retry("build", func(name string) error {
+		attempts++
+		if attempts < 3 {
+			return kerrors.NewAlreadyExists("build", name)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRetryStopsOnOtherError(t *testing.T) {
+	attempts := 0
+	wantErr := fmt.Errorf("boom")
+	err := Retry("build", func(name string) error {
+		attempts++
+		return wantErr
+	})
+	if err != wantErr {
+		t.Errorf("expected the underlying error to be returned unchanged, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected exactly 1 attempt, got %d", attempts)
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	attempts := 0
+	err := Retry("build", func(name string) error {
+		attempts++
+		return kerrors.NewAlreadyExists("build", name)
+	})
+	if err == nil {
+		t.Fatalf("expected an error after exhausting retries")
+	}
+	if attempts != maxAttempts {
+		t.Errorf("expected %d attempts, got %d", maxAttempts, attempts)
+	}
+}
@@ -0,0 +1,78 @@
+// Package gzipcodec wraps a runtime.Codec to gzip-compress encoded objects before they
+// reach storage, and transparently decompress them again on the way back out. It exists
+// so registries can shrink large objects (templates with many items, builds carrying log
+// snippets) on disk and over watch, without any caller needing to know the difference.
+package gzipcodec
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+)
+
+// gzipMagic is the first two bytes of every gzip stream (RFC 1952).
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// codec wraps another runtime.Codec, compressing on Encode and transparently detecting
+// and decompressing gzipped input on Decode/DecodeInto. Uncompressed input, including
+// anything written before compression was turned on, decodes exactly as it always did.
+type codec struct {
+	runtime.Codec
+}
+
+// NewCodec returns a runtime.Codec that gzip-compresses everything c encodes, and
+// decompresses anything gzip-compressed that it's asked to decode.
+func NewCodec(c runtime.Codec) runtime.Codec {
+	return &codec{c}
+}
+
+// Encode encodes obj with the wrapped codec, then gzip-compresses the result.
+func (c *codec) Encode(obj runtime.Object) ([]byte, error) {
+	data, err := c.Codec.Encode(obj)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode gunzips data if it's gzip-compressed, then decodes it with the wrapped codec.
+func (c *codec) Decode(data []byte) (runtime.Object, error) {
+	data, err := maybeGunzip(data)
+	if err != nil {
+		return nil, err
+	}
+	return c.Codec.Decode(data)
+}
+
+// DecodeInto gunzips data if it's gzip-compressed, then decodes it with the wrapped codec.
+func (c *codec) DecodeInto(data []byte, obj runtime.Object) error {
+	data, err := maybeGunzip(data)
+	if err != nil {
+		return err
+	}
+	return c.Codec.DecodeInto(data, obj)
+}
+
+// maybeGunzip returns data unchanged unless it starts with the gzip magic number, in
+// which case it returns the decompressed contents.
+func maybeGunzip(data []byte) ([]byte, error) {
+	if len(data) < len(gzipMagic) || !bytes.Equal(data[:len(gzipMagic)], gzipMagic) {
+		return data, nil
+	}
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
@@ -0,0 +1,51 @@
+package gzipcodec
+
+import (
+	"testing"
+
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/openshift/origin/pkg/api/latest"
+)
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	c := NewCodec(latest.Codec)
+	pod := &kapi.Pod{JSONBase: kapi.JSONBase{ID: "foo"}}
+
+	data, err := c.Encode(pod)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytesHaveGzipMagic(data) {
+		t.Fatalf("expected encoded output to be gzip-compressed")
+	}
+
+	decoded := &kapi.Pod{}
+	if err := c.DecodeInto(data, decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded.ID != "foo" {
+		t.Errorf("unexpected object: %#v", decoded)
+	}
+}
+
+func TestDecodeUncompressedFallsThrough(t *testing.T) {
+	c := NewCodec(latest.Codec)
+	pod := &kapi.Pod{JSONBase: kapi.JSONBase{ID: "foo"}}
+
+	data, err := latest.Codec.Encode(pod)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	decoded := &kapi.Pod{}
+	if err := c.DecodeInto(data, decoded); err != nil {
+		t.Fatalf("unexpected error decoding uncompressed data: %v", err)
+	}
+	if decoded.ID != "foo" {
+		t.Errorf("unexpected object: %#v", decoded)
+	}
+}
+
+func bytesHaveGzipMagic(data []byte) bool {
+	return len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b
+}
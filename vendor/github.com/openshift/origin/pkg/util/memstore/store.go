@@ -0,0 +1,299 @@
+// Package memstore provides a thread-safe, in-memory, watchable object store that
+// registries can use in place of etcd. It's meant for standalone/demo servers and
+// hermetic integration tests, where a real etcd isn't available or desired.
+package memstore
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+
+	"github.com/openshift/origin/pkg/util/watchbuffer"
+)
+
+// watchBufferSize bounds how many events a single watcher of this store may lag behind
+// before it's considered stalled and disconnected, protecting the store's mux from a
+// slow watcher backing up delivery to every other watcher sharing it.
+const watchBufferSize = 100
+
+// watchHistorySize bounds how many past events a store retains for Watch to replay. It
+// lets a watcher that specifies a resourceVersion just behind the current one catch up
+// without missing events, while keeping a long-running store from retaining its entire
+// history in memory.
+const watchHistorySize = 100
+
+// versionedEvent pairs an event with the store version it was recorded at, so Watch can
+// find every event a caller hasn't seen yet.
+type versionedEvent struct {
+	version uint64
+	event   watch.Event
+}
+
+// IndexFunc computes the secondary index value for obj, e.g. its status or owner.
+type IndexFunc func(obj runtime.Object) string
+
+// index tracks, for one named index, which ids currently produce each value.
+type index struct {
+	fn      IndexFunc
+	byValue map[string][]string
+}
+
+// Store holds objects of a single kind, keyed by ID. All methods are safe for
+// concurrent use. Every Create/Update/Delete is fanned out to current watchers
+// via a watch.Mux, mirroring the events tools.EtcdHelper's callers see today.
+type Store struct {
+	kind string
+
+	lock    sync.Mutex
+	items   map[string]runtime.Object
+	mux     *watch.Mux
+	indexes map[string]*index
+	version uint64
+	history []versionedEvent
+}
+
+// NewStore creates an empty Store. kind is used in NotFound/AlreadyExists errors,
+// the same way callers already pass a kind string to the etcd error helpers.
+func NewStore(kind string) *Store {
+	return &Store{
+		kind:    kind,
+		items:   map[string]runtime.Object{},
+		mux:     watch.NewMux(0),
+		indexes: map[string]*index{},
+	}
+}
+
+// Get retrieves the object stored under id.
+func (s *Store) Get(id string) (runtime.Object, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	obj, ok := s.items[id]
+	if !ok {
+		return nil, errors.NewNotFound(s.kind, id)
+	}
+	return obj, nil
+}
+
+// List returns every object currently in the store, in no particular order.
+func (s *Store) List() []runtime.Object {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	items := make([]runtime.Object, 0, len(s.items))
+	for _, obj := range s.items {
+		items = append(items, obj)
+	}
+	return items
+}
+
+// ResourceVersion returns the version of the most recent Create/Update/Delete accepted by
+// the store, or zero for a store that has never been modified. A caller that lists the
+// store and then calls Watch with this value is guaranteed to see every subsequent change
+// exactly once.
+func (s *Store) ResourceVersion() uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.version
+}
+
+// record bumps the store's version and appends event to its replay history, trimming the
+// oldest entry once the history is full. Callers must hold s.lock.
+func (s *Store) record(eventType watch.EventType, obj runtime.Object) watch.Event {
+	s.version++
+	event := watch.Event{Type: eventType, Object: obj}
+	s.history = append(s.history, versionedEvent{version: s.version, event: event})
+	if len(s.history) > watchHistorySize {
+		s.history = s.history[len(s.history)-watchHistorySize:]
+	}
+	return event
+}
+
+// Create adds obj under id, failing if id is already present.
+func (s *Store) Create(id string, obj runtime.Object) error {
+	s.lock.Lock()
+	if _, exists := s.items[id]; exists {
+		s.lock.Unlock()
+		return errors.NewAlreadyExists(s.kind, id)
+	}
+	s.items[id] = obj
+	s.indexAdd(id, obj)
+	event := s.record(watch.Added, obj)
+	s.lock.Unlock()
+	s.mux.Action(event.Type, event.Object)
+	return nil
+}
+
+// Update replaces the object stored under id, failing if id isn't present.
+func (s *Store) Update(id string, obj runtime.Object) error {
+	s.lock.Lock()
+	old, exists := s.items[id]
+	if !exists {
+		s.lock.Unlock()
+		return errors.NewNotFound(s.kind, id)
+	}
+	s.indexRemove(id, old)
+	s.items[id] = obj
+	s.indexAdd(id, obj)
+	event := s.record(watch.Modified, obj)
+	s.lock.Unlock()
+	s.mux.Action(event.Type, event.Object)
+	return nil
+}
+
+// Delete removes the object stored under id, failing if id isn't present.
+func (s *Store) Delete(id string) error {
+	s.lock.Lock()
+	obj, exists := s.items[id]
+	if !exists {
+		s.lock.Unlock()
+		return errors.NewNotFound(s.kind, id)
+	}
+	delete(s.items, id)
+	s.indexRemove(id, obj)
+	event := s.record(watch.Deleted, obj)
+	s.lock.Unlock()
+	s.mux.Action(event.Type, event.Object)
+	return nil
+}
+
+// AddIndex registers a named secondary index computed by fn and backfills it from every
+// object already in the store. Once registered, the index is kept up to date by every
+// subsequent Create/Update/Delete, so ListByIndex never needs to rescan the whole store.
+func (s *Store) AddIndex(name string, fn IndexFunc) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	idx := &index{fn: fn, byValue: map[string][]string{}}
+	for id, obj := range s.items {
+		value := fn(obj)
+		idx.byValue[value] = append(idx.byValue[value], id)
+	}
+	s.indexes[name] = idx
+}
+
+// ListByIndex returns every object whose value for the named index equals value, in time
+// proportional to the size of the result rather than the size of the whole store. It
+// returns an error if name wasn't registered with AddIndex.
+func (s *Store) ListByIndex(name, value string) ([]runtime.Object, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	idx, ok := s.indexes[name]
+	if !ok {
+		return nil, fmt.Errorf("index %q is not registered on this store", name)
+	}
+	ids := idx.byValue[value]
+	items := make([]runtime.Object, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, s.items[id])
+	}
+	return items, nil
+}
+
+func (s *Store) indexAdd(id string, obj runtime.Object) {
+	for _, idx := range s.indexes {
+		value := idx.fn(obj)
+		idx.byValue[value] = append(idx.byValue[value], id)
+	}
+}
+
+func (s *Store) indexRemove(id string, obj runtime.Object) {
+	for _, idx := range s.indexes {
+		value := idx.fn(obj)
+		ids := idx.byValue[value]
+		for i, existing := range ids {
+			if existing == id {
+				idx.byValue[value] = append(ids[:i], ids[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// Watch returns a watch.Interface that first replays every event recorded since
+// resourceVersion, then delivers events live, so a caller that lists the store, notes its
+// ResourceVersion, and watches from that version sees every subsequent change exactly once
+// with no gap. resourceVersion of zero watches only events that occur from now on, matching
+// the historical behavior of this method. If resourceVersion is older than the oldest event
+// still retained, an error is returned; the caller should re-list and watch again from the
+// fresher version. The returned watch is buffered so a consumer that falls behind is
+// disconnected instead of stalling delivery to every other watcher of this store.
+func (s *Store) Watch(resourceVersion uint64) (watch.Interface, error) {
+	s.lock.Lock()
+	if resourceVersion == 0 {
+		resourceVersion = s.version
+	}
+	if len(s.history) > 0 && resourceVersion < s.history[0].version-1 {
+		s.lock.Unlock()
+		return nil, fmt.Errorf("resourceVersion %d is too old for %s, no longer in history", resourceVersion, s.kind)
+	}
+	replay := make([]watch.Event, 0, len(s.history))
+	for _, e := range s.history {
+		if e.version > resourceVersion {
+			replay = append(replay, e.event)
+		}
+	}
+	live := s.mux.Watch()
+	s.lock.Unlock()
+
+	return watchbuffer.New(newReplayWatch(replay, live), watchBufferSize), nil
+}
+
+// replayWatch is a watch.Interface that delivers a fixed slice of past events before
+// relaying whatever live emits, so a watcher that missed events between a list and the
+// start of its watch can catch up without a gap.
+type replayWatch struct {
+	live     watch.Interface
+	result   chan watch.Event
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func newReplayWatch(replay []watch.Event, live watch.Interface) watch.Interface {
+	w := &replayWatch{
+		live:   live,
+		result: make(chan watch.Event, len(replay)+1),
+		stop:   make(chan struct{}),
+	}
+	go w.run(replay)
+	return w
+}
+
+func (w *replayWatch) run(replay []watch.Event) {
+	defer close(w.result)
+	for _, event := range replay {
+		select {
+		case w.result <- event:
+		case <-w.stop:
+			return
+		}
+	}
+	for {
+		select {
+		case event, ok := <-w.live.ResultChan():
+			if !ok {
+				return
+			}
+			select {
+			case w.result <- event:
+			case <-w.stop:
+				return
+			}
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// ResultChan implements watch.Interface.
+func (w *replayWatch) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+// Stop implements watch.Interface.
+func (w *replayWatch) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+		w.live.Stop()
+	})
+}
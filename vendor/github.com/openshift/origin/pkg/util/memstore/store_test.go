@@ -0,0 +1,167 @@
+package memstore
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+)
+
+func TestCreateGetList(t *testing.T) {
+	s := NewStore("thing")
+	if _, err := s.Get("a"); err == nil {
+		t.Errorf("expected an error getting a missing item")
+	}
+
+	obj := &api.Pod{JSONBase: api.JSONBase{ID: "a"}}
+	if err := s.Create("a", obj); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Create("a", obj); err == nil {
+		t.Errorf("expected an error creating a duplicate item")
+	}
+
+	got, err := s.Get("a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.(*api.Pod).ID != "a" {
+		t.Errorf("unexpected object returned: %#v", got)
+	}
+
+	if items := s.List(); len(items) != 1 {
+		t.Errorf("expected 1 item, got %d", len(items))
+	}
+}
+
+func TestUpdateDelete(t *testing.T) {
+	s := NewStore("thing")
+	obj := &api.Pod{JSONBase: api.JSONBase{ID: "a"}}
+
+	if err := s.Update("a", obj); err == nil {
+		t.Errorf("expected an error updating a missing item")
+	}
+	if err := s.Delete("a"); err == nil {
+		t.Errorf("expected an error deleting a missing item")
+	}
+
+	if err := s.Create("a", obj); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Update("a", obj); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Delete("a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.Get("a"); err == nil {
+		t.Errorf("expected an error getting a deleted item")
+	}
+}
+
+func TestIndex(t *testing.T) {
+	s := NewStore("thing")
+	s.AddIndex("host", func(obj runtime.Object) string {
+		return obj.(*api.Pod).CurrentState.Host
+	})
+
+	a := &api.Pod{JSONBase: api.JSONBase{ID: "a"}, CurrentState: api.PodState{Host: "node1"}}
+	b := &api.Pod{JSONBase: api.JSONBase{ID: "b"}, CurrentState: api.PodState{Host: "node1"}}
+	c := &api.Pod{JSONBase: api.JSONBase{ID: "c"}, CurrentState: api.PodState{Host: "node2"}}
+	for _, obj := range []*api.Pod{a, b, c} {
+		if err := s.Create(obj.ID, obj); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	items, err := s.ListByIndex("host", "node1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2 {
+		t.Errorf("expected 2 items on node1, got %d", len(items))
+	}
+
+	moved := &api.Pod{JSONBase: api.JSONBase{ID: "a"}, CurrentState: api.PodState{Host: "node2"}}
+	if err := s.Update("a", moved); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if items, err = s.ListByIndex("host", "node1"); err != nil || len(items) != 1 {
+		t.Errorf("expected 1 item left on node1, got %d items (err=%v)", len(items), err)
+	}
+	if items, err = s.ListByIndex("host", "node2"); err != nil || len(items) != 2 {
+		t.Errorf("expected 2 items on node2, got %d items (err=%v)", len(items), err)
+	}
+
+	if err := s.Delete("b"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if items, err = s.ListByIndex("host", "node1"); err != nil || len(items) != 0 {
+		t.Errorf("expected 0 items left on node1, got %d items (err=%v)", len(items), err)
+	}
+
+	if _, err := s.ListByIndex("missing", "node1"); err == nil {
+		t.Errorf("expected an error listing by an unregistered index")
+	}
+}
+
+func TestWatch(t *testing.T) {
+	s := NewStore("thing")
+	w, err := s.Watch(0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer w.Stop()
+
+	obj := &api.Pod{JSONBase: api.JSONBase{ID: "a"}}
+	if err := s.Create("a", obj); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	event := <-w.ResultChan()
+	if event.Type != watch.Added {
+		t.Errorf("expected an Added event, got %v", event.Type)
+	}
+}
+
+func TestWatchFromResourceVersionReplaysMissedEvents(t *testing.T) {
+	s := NewStore("thing")
+
+	a := &api.Pod{JSONBase: api.JSONBase{ID: "a"}}
+	if err := s.Create("a", a); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rv := s.ResourceVersion()
+
+	b := &api.Pod{JSONBase: api.JSONBase{ID: "b"}}
+	if err := s.Create("b", b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	w, err := s.Watch(rv)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer w.Stop()
+
+	event := <-w.ResultChan()
+	if event.Type != watch.Added || event.Object.(*api.Pod).ID != "b" {
+		t.Errorf("expected a replayed Added event for pod b, got %#v", event)
+	}
+}
+
+func TestWatchTooOldResourceVersion(t *testing.T) {
+	s := NewStore("thing")
+	for i := 0; i < watchHistorySize+2; i++ {
+		id := fmt.Sprintf("pod-%d", i)
+		if err := s.Create(id, &api.Pod{JSONBase: api.JSONBase{ID: id}}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if _, err := s.Watch(1); err == nil {
+		t.Error("expected an error watching from a resourceVersion older than the retained history")
+	}
+}
@@ -0,0 +1,46 @@
+// Package netutil holds shared validators for IP addresses, CIDR blocks, and port ranges,
+// so individual API validation packages don't each roll their own regexes for them.
+//
+// None of the API types in this snapshot carry an IP, CIDR, or port-range field yet
+// (Service exposes only a single numeric Port, validated by util.IsValidPortNum), so
+// these aren't wired into any ValidateXxx function today. They exist so that service,
+// route, and network policy validation can adopt them without duplicating this logic
+// once such fields land.
+package netutil
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+)
+
+// IsValidIP returns true if value is a valid IPv4 or IPv6 address.
+func IsValidIP(value string) bool {
+	return net.ParseIP(value) != nil
+}
+
+// IsValidCIDR returns true if value is a valid IPv4 or IPv6 CIDR block (e.g. "10.0.0.0/8").
+func IsValidCIDR(value string) bool {
+	_, _, err := net.ParseCIDR(value)
+	return err == nil
+}
+
+// IsValidPortRange returns true if value is either a single port number or an inclusive
+// "<min>-<max>" range of port numbers, with every endpoint a valid port number and min <= max.
+func IsValidPortRange(value string) bool {
+	parts := strings.SplitN(value, "-", 2)
+	bounds := make([]int, 0, len(parts))
+	for _, part := range parts {
+		port, err := strconv.Atoi(part)
+		if err != nil || !util.IsValidPortNum(port) {
+			return false
+		}
+		bounds = append(bounds, port)
+	}
+	if len(bounds) == 2 && bounds[0] > bounds[1] {
+		return false
+	}
+	return true
+}
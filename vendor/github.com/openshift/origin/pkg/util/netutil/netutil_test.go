@@ -0,0 +1,51 @@
+package netutil
+
+import "testing"
+
+func TestIsValidIP(t *testing.T) {
+	valid := []string{"127.0.0.1", "10.0.0.1", "::1", "2001:db8::1"}
+	for _, v := range valid {
+		if !IsValidIP(v) {
+			t.Errorf("expected %q to be a valid IP", v)
+		}
+	}
+
+	invalid := []string{"", "not-an-ip", "10.0.0.256", "10.0.0"}
+	for _, v := range invalid {
+		if IsValidIP(v) {
+			t.Errorf("expected %q to be an invalid IP", v)
+		}
+	}
+}
+
+func TestIsValidCIDR(t *testing.T) {
+	valid := []string{"10.0.0.0/8", "192.168.1.0/24", "2001:db8::/32"}
+	for _, v := range valid {
+		if !IsValidCIDR(v) {
+			t.Errorf("expected %q to be a valid CIDR", v)
+		}
+	}
+
+	invalid := []string{"", "10.0.0.0", "10.0.0.0/33", "not-a-cidr"}
+	for _, v := range invalid {
+		if IsValidCIDR(v) {
+			t.Errorf("expected %q to be an invalid CIDR", v)
+		}
+	}
+}
+
+func TestIsValidPortRange(t *testing.T) {
+	valid := []string{"80", "8080-8090", "1-65535"}
+	for _, v := range valid {
+		if !IsValidPortRange(v) {
+			t.Errorf("expected %q to be a valid port range", v)
+		}
+	}
+
+	invalid := []string{"", "0", "65536", "8090-8080", "abc", "80-abc"}
+	for _, v := range invalid {
+		if IsValidPortRange(v) {
+			t.Errorf("expected %q to be an invalid port range", v)
+		}
+	}
+}
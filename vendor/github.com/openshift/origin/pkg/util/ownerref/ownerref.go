@@ -0,0 +1,38 @@
+// Package ownerref provides a small labeling convention for marking a generated object (a
+// build pod, a deployer pod) as owned by the origin resource that created it. This vintage
+// of the API has no annotations or a first-class owner reference field, so the link is
+// carried in ordinary labels instead, the same way selectors already are. The owner's UID is
+// carried alongside its ID so a stale reference can be told apart from a live one after the
+// owner is deleted and its ID reused by an unrelated object.
+package ownerref
+
+const (
+	// KindLabel names the kind of the owning resource, e.g. "Build" or "Deployment".
+	KindLabel = "owner.kind"
+	// IDLabel names the ID of the owning resource.
+	IDLabel = "owner.id"
+	// UIDLabel names the UID of the owning resource, at the time the reference was stamped.
+	UIDLabel = "owner.uid"
+)
+
+// Set stamps labels with a reference to an owner of the given kind, id, and uid, creating
+// the label map if it's nil. It returns the (possibly new) map so callers can assign it back
+// to an object's Labels field in one line.
+func Set(labels map[string]string, kind, id, uid string) map[string]string {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[KindLabel] = kind
+	labels[IDLabel] = id
+	labels[UIDLabel] = uid
+	return labels
+}
+
+// Get returns the owner kind, id, and uid recorded in labels, and whether all three were
+// present.
+func Get(labels map[string]string) (kind, id, uid string, ok bool) {
+	kind, hasKind := labels[KindLabel]
+	id, hasID := labels[IDLabel]
+	uid, hasUID := labels[UIDLabel]
+	return kind, id, uid, hasKind && hasID && hasUID
+}
@@ -0,0 +1,20 @@
+package ownerref
+
+import "testing"
+
+func TestSetGet(t *testing.T) {
+	labels := Set(nil, "Build", "build-1", "build-1-uid")
+	kind, id, uid, ok := Get(labels)
+	if !ok || kind != "Build" || id != "build-1" || uid != "build-1-uid" {
+		t.Errorf("unexpected result: kind=%s id=%s uid=%s ok=%v", kind, id, uid, ok)
+	}
+}
+
+func TestGetMissing(t *testing.T) {
+	if _, _, _, ok := Get(map[string]string{"owner.kind": "Build", "owner.id": "build-1"}); ok {
+		t.Errorf("expected ok=false when the uid label is missing")
+	}
+	if _, _, _, ok := Get(nil); ok {
+		t.Errorf("expected ok=false for nil labels")
+	}
+}
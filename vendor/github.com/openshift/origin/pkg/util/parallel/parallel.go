@@ -0,0 +1,75 @@
+// Package parallel provides a fan-out helper for running independent reads - such as the
+// per-registry List calls behind an aggregated endpoint or controller sync - concurrently
+// instead of one after another, with a shared deadline and aggregated errors so a caller sees
+// every failure instead of just the first goroutine to return one.
+package parallel
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Errors is the error returned by Run when one or more fns failed. It reports every
+// underlying error rather than just the first, so a caller can tell a single bad registry
+// apart from a systemic failure.
+type Errors []error
+
+func (e Errors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("%d calls failed: %s", len(e), strings.Join(messages, "; "))
+}
+
+// errDeadlineExceeded is returned by Run if deadline elapses before every fn has finished.
+// The goroutines for any fns still running are not interrupted; each is responsible for
+// noticing continued work is pointless, so Run itself only stops waiting.
+type errDeadlineExceeded struct {
+	completed, total int
+}
+
+func (e *errDeadlineExceeded) Error() string {
+	return fmt.Sprintf("deadline exceeded waiting for %d of %d calls", e.total-e.completed, e.total)
+}
+
+// Run executes each of fns in its own goroutine and waits for all of them to finish or for
+// deadline to elapse, whichever comes first. A deadline of zero or less means wait
+// indefinitely. Each fn is responsible for capturing its own result, typically by closing
+// over a variable in the caller's scope, so partial results are still available to the
+// caller even when Run returns an error.
+//
+// Run returns nil if every fn succeeded, an Errors listing every fn that returned an error,
+// or an error reporting that the deadline elapsed before all fns finished.
+func Run(deadline time.Duration, fns ...func() error) error {
+	done := make(chan error, len(fns))
+	for _, fn := range fns {
+		fn := fn
+		go func() { done <- fn() }()
+	}
+
+	var timeout <-chan time.Time
+	if deadline > 0 {
+		timeout = time.After(deadline)
+	}
+
+	var errs Errors
+	completed := 0
+	for completed < len(fns) {
+		select {
+		case err := <-done:
+			completed++
+			if err != nil {
+				errs = append(errs, err)
+			}
+		case <-timeout:
+			return &errDeadlineExceeded{completed: completed, total: len(fns)}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package parallel
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRunSucceeds(t *testing.T) {
+	var a, b int
+	err := Run(0,
+		func() error { a = 1; return nil },
+		func() error { b = 2; return nil },
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a != 1 || b != 2 {
+		t.Errorf("expected both fns to run, got a=%d b=%d", a, b)
+	}
+}
+
+func TestRunAggregatesErrors(t *testing.T) {
+	err := Run(0,
+		func() error { return nil },
+		func() error { return fmt.Errorf("first") },
+		func() error { return fmt.Errorf("second") },
+	)
+	errs, ok := err.(Errors)
+	if !ok {
+		t.Fatalf("expected an Errors, got %#v", err)
+	}
+	if len(errs) != 2 {
+		t.Errorf("expected 2 errors, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestRunDeadlineExceeded(t *testing.T) {
+	err := Run(time.Millisecond,
+		func() error { time.Sleep(time.Second); return nil },
+	)
+	if err == nil {
+		t.Fatalf("expected a deadline error")
+	}
+}
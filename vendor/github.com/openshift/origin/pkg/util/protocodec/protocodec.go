@@ -0,0 +1,46 @@
+// Package protocodec is a reserved extension point for a protobuf-based runtime.Codec,
+// intended to cut serialization CPU and watch bandwidth for large clusters compared to the
+// JSON/YAML codecs origin uses today. It is not implemented: this tree vendors no protobuf
+// runtime (no code.google.com/p/goprotobuf or github.com/golang/protobuf under
+// Godeps/_workspace), and none of the build, deploy, project, template, or oauth API types
+// have .proto definitions or generated marshalers, so there is no wire format to encode
+// into yet. Adding real support means vendoring a protobuf runtime, writing .proto schemas
+// for those types, and generating their marshalers -- none of which this package attempts,
+// so as not to pass off some other encoding as protobuf-interoperable.
+//
+// New returns a runtime.Codec whose methods all fail with ErrNotImplemented, so a caller
+// that mistakenly wires this in fails loudly instead of silently falling back to some other
+// format.
+package protocodec
+
+import (
+	"errors"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+)
+
+// ErrNotImplemented is returned by every Codec method; see the package doc comment.
+var ErrNotImplemented = errors.New("protocodec: protobuf serialization is not implemented in this tree")
+
+// Codec is a runtime.Codec stand-in reserved for a future protobuf implementation.
+type Codec struct{}
+
+// New returns a Codec.
+func New() *Codec {
+	return &Codec{}
+}
+
+// Encode implements runtime.Codec.
+func (c *Codec) Encode(obj runtime.Object) ([]byte, error) {
+	return nil, ErrNotImplemented
+}
+
+// Decode implements runtime.Codec.
+func (c *Codec) Decode(data []byte) (runtime.Object, error) {
+	return nil, ErrNotImplemented
+}
+
+// DecodeInto implements runtime.Codec.
+func (c *Codec) DecodeInto(data []byte, obj runtime.Object) error {
+	return ErrNotImplemented
+}
@@ -0,0 +1,25 @@
+package protocodec
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+)
+
+func TestCodecIsARuntimeCodec(t *testing.T) {
+	var _ runtime.Codec = New()
+}
+
+func TestCodecMethodsReturnErrNotImplemented(t *testing.T) {
+	c := New()
+
+	if _, err := c.Encode(nil); err != ErrNotImplemented {
+		t.Errorf("Encode: expected ErrNotImplemented, got %v", err)
+	}
+	if _, err := c.Decode(nil); err != ErrNotImplemented {
+		t.Errorf("Decode: expected ErrNotImplemented, got %v", err)
+	}
+	if err := c.DecodeInto(nil, nil); err != ErrNotImplemented {
+		t.Errorf("DecodeInto: expected ErrNotImplemented, got %v", err)
+	}
+}
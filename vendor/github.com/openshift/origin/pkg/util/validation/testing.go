@@ -0,0 +1,59 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+)
+
+// ExpectCount fails the test unless errs contains exactly count errors.
+func ExpectCount(t *testing.T, label string, errs errors.ErrorList, count int) {
+	if len(errs) != count {
+		t.Errorf("%s: expected %d errors, got %d: %#v", label, count, len(errs), errs)
+	}
+}
+
+// ExpectValid fails the test unless errs is empty.
+func ExpectValid(t *testing.T, label string, errs errors.ErrorList) {
+	if len(errs) != 0 {
+		t.Errorf("%s: unexpected error list: %#v", label, errs)
+	}
+}
+
+// ExpectInvalid fails the test unless errs is non-empty and every error is a
+// errors.ValidationError of the given type and field.
+func ExpectInvalid(t *testing.T, label string, errs errors.ErrorList, errType errors.ValidationErrorType, field string) {
+	if len(errs) == 0 {
+		t.Errorf("%s: expected a non-empty error list", label)
+		return
+	}
+	for i := range errs {
+		ve, ok := errs[i].(errors.ValidationError)
+		if !ok {
+			t.Errorf("%s: expected a ValidationError, got: %#v", label, errs[i])
+			continue
+		}
+		if ve.Type != errType {
+			t.Errorf("%s: expected error type %s, got: %v", label, errType, errs[i])
+		}
+		if ve.Field != field {
+			t.Errorf("%s: expected error field %s, got: %v", label, field, errs[i])
+		}
+	}
+}
+
+// ExpectPrefix fails the test unless every errors.ValidationError in errs has a field
+// beginning with prefix.
+func ExpectPrefix(t *testing.T, label, prefix string, errs errors.ErrorList) {
+	for i := range errs {
+		ve, ok := errs[i].(errors.ValidationError)
+		if !ok {
+			t.Errorf("%s: expected a ValidationError, got: %#v", label, errs[i])
+			continue
+		}
+		if !strings.HasPrefix(ve.Field, prefix) {
+			t.Errorf("%s: expected error field to start with %q, got: %v", label, prefix, errs[i])
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Package watchbuffer wraps a watch.Interface with a bounded, per-watcher buffer, so a
+// consumer that falls behind (a stalled console websocket, for example) can't back up the
+// watch it's reading from. Fan-out points like watch.Mux deliver events to every watcher
+// synchronously, so a watcher that never drains its channel eventually stalls delivery to
+// every other watcher sharing the same source, including controllers. Wrapping each watcher
+// returned to an external consumer with New bounds how much damage a slow one can do: once
+// its buffer fills, it is disconnected instead of stalling the source.
+package watchbuffer
+
+import (
+	"sync"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+	"github.com/golang/glog"
+)
+
+// New returns a watch.Interface backed by in, buffering up to size events for a consumer
+// that isn't keeping up. Events are drained from in as fast as they arrive, so in never
+// blocks waiting on the returned Interface's consumer. If the buffer ever fills, the
+// consumer is considered too slow: in is stopped, the returned Interface's result channel is
+// closed, and no further events are delivered.
+func New(in watch.Interface, size int) watch.Interface {
+	w := &bufferedWatch{
+		in:     in,
+		result: make(chan watch.Event, size),
+		stop:   make(chan struct{}),
+	}
+	go w.relay()
+	return w
+}
+
+type bufferedWatch struct {
+	in       watch.Interface
+	result   chan watch.Event
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// ResultChan implements watch.Interface.
+func (w *bufferedWatch) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+// Stop implements watch.Interface.
+func (w *bufferedWatch) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+		w.in.Stop()
+	})
+}
+
+func (w *bufferedWatch) relay() {
+	defer close(w.result)
+	for {
+		select {
+		case event, ok := <-w.in.ResultChan():
+			if !ok {
+				return
+			}
+			select {
+			case w.result <- event:
+			default:
+				glog.Errorf("watchbuffer: consumer fell more than %d events behind, disconnecting", cap(w.result))
+				w.Stop()
+				return
+			}
+		case <-w.stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package watchbuffer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+)
+
+func TestBufferedWatchRelaysEvents(t *testing.T) {
+	fake := watch.NewFake()
+	buffered := New(fake, 10)
+
+	go fake.Add(&api.Pod{JSONBase: api.JSONBase{ID: "foo"}})
+
+	select {
+	case event := <-buffered.ResultChan():
+		if event.Type != watch.Added {
+			t.Errorf("Expected an Added event, got %v", event.Type)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for the relayed event")
+	}
+
+	buffered.Stop()
+	if !fake.Stopped {
+		t.Errorf("Expected Stop() to stop the underlying watch")
+	}
+}
+
+func TestBufferedWatchDisconnectsSlowConsumer(t *testing.T) {
+	fake := watch.NewFake()
+	buffered := New(fake, 1)
+
+	// Push one more event than the buffer can hold without ever reading from
+	// buffered.ResultChan(), simulating a consumer that has fallen behind.
+	go func() {
+		fake.Add(&api.Pod{JSONBase: api.JSONBase{ID: "foo"}})
+		fake.Add(&api.Pod{JSONBase: api.JSONBase{ID: "bar"}})
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for !fake.Stopped {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the slow consumer to be disconnected")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Drain whatever made it into the buffer before the disconnect; the channel
+	// must still close once it's empty.
+	for range buffered.ResultChan() {
+	}
+}
@@ -0,0 +1,165 @@
+// Package multiplex implements a single HTTP endpoint that multiplexes watch events for
+// several origin resource types over one connection, each resuming from its own
+// resourceVersion, so a controller that watches N resource types doesn't need to hold open
+// N connections to the API server. It reuses the same chunked-JSON-over-HTTP wire format
+// apiserver.WatchServer already serves for a single resource's watch endpoint (see
+// Godeps/_workspace/.../pkg/apiserver/watch.go), tagging each event with the name of the
+// resource type that produced it.
+package multiplex
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/httplog"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+)
+
+// Source opens a watch on one resource type, starting at resourceVersion.
+type Source func(resourceVersion uint64) (watch.Interface, error)
+
+// taggedEvent is the wire representation of one event on the multiplexed stream: the name
+// of the resource type it came from, alongside the same {type, object} shape
+// apiserver.WatchServer emits for a single-resource watch.
+type taggedEvent struct {
+	Resource string               `json:"resource"`
+	Type     watch.EventType      `json:"type,omitempty"`
+	Object   runtime.RawExtension `json:"object,omitempty"`
+}
+
+// Handler serves a multiplexed watch of every named Source over one HTTP connection.
+type Handler struct {
+	sources map[string]Source
+	codec   runtime.Codec
+}
+
+// NewHandler returns a Handler that multiplexes the given sources, encoding each event's
+// object with codec. The map key is the name a caller uses, both to name its resume
+// resourceVersion as a query parameter and to identify the resource in each event on the
+// wire.
+func NewHandler(sources map[string]Source, codec runtime.Codec) *Handler {
+	return &Handler{sources: sources, codec: codec}
+}
+
+// ServeHTTP opens every named Source at its resume point, given by an equally named query
+// parameter (0, meaning "start now", if that parameter is absent or unparseable), merges
+// their events onto one chunked-JSON HTTP response, and keeps streaming until the client
+// disconnects.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	loggedW := httplog.LogOf(req, w)
+	w = httplog.Unlogged(w)
+
+	cn, ok := w.(http.CloseNotifier)
+	if !ok {
+		loggedW.Addf("unable to get CloseNotifier")
+		http.NotFound(w, req)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		loggedW.Addf("unable to get Flusher")
+		http.NotFound(w, req)
+		return
+	}
+
+	watchers := make(map[string]watch.Interface, len(h.sources))
+	for name, source := range h.sources {
+		resourceVersion, _ := strconv.ParseUint(req.URL.Query().Get(name), 10, 64)
+		watching, err := source(resourceVersion)
+		if err != nil {
+			for _, w := range watchers {
+				w.Stop()
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		watchers[name] = watching
+	}
+	defer func() {
+		for _, watching := range watchers {
+			watching.Stop()
+		}
+	}()
+
+	merged := merge(watchers)
+
+	w.Header().Set("Transfer-Encoding", "chunked")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	encoder := json.NewEncoder(w)
+	for {
+		select {
+		case <-cn.CloseNotify():
+			return
+		case tagged, ok := <-merged:
+			if !ok {
+				return
+			}
+			wire, err := h.encode(tagged)
+			if err != nil {
+				return
+			}
+			if err := encoder.Encode(wire); err != nil {
+				// Client disconnected.
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// resourceEvent pairs a watch.Event with the name of the resource type it came from.
+type resourceEvent struct {
+	resource string
+	event    watch.Event
+}
+
+// encode converts a resourceEvent into its tagged wire representation.
+func (h *Handler) encode(re resourceEvent) (*taggedEvent, error) {
+	obj, ok := re.event.Object.(runtime.Object)
+	if !ok {
+		return nil, fmt.Errorf("the event object cannot be safely converted to JSON: %v", reflect.TypeOf(re.event.Object).Name())
+	}
+	data, err := h.codec.Encode(obj)
+	if err != nil {
+		return nil, err
+	}
+	return &taggedEvent{
+		Resource: re.resource,
+		Type:     re.event.Type,
+		Object:   runtime.RawExtension{RawJSON: data},
+	}, nil
+}
+
+// merge fans events from every watcher into a single channel, each tagged with the name it
+// was registered under. The returned channel closes once every watcher's own channel has
+// closed.
+func merge(watchers map[string]watch.Interface) <-chan resourceEvent {
+	out := make(chan resourceEvent)
+	remaining := len(watchers)
+	if remaining == 0 {
+		close(out)
+		return out
+	}
+	done := make(chan struct{}, remaining)
+	for name, watching := range watchers {
+		go func(name string, watching watch.Interface) {
+			for event := range watching.ResultChan() {
+				out <- resourceEvent{resource: name, event: event}
+			}
+			done <- struct{}{}
+		}(name, watching)
+	}
+	go func() {
+		for i := 0; i < remaining; i++ {
+			<-done
+		}
+		close(out)
+	}()
+	return out
+}
@@ -0,0 +1,78 @@
+package multiplex
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+
+	"github.com/openshift/origin/pkg/api/latest"
+)
+
+func TestHandlerMultiplexesEachSource(t *testing.T) {
+	builds := watch.NewFake()
+	routes := watch.NewFake()
+
+	handler := NewHandler(map[string]Source{
+		"builds": func(resourceVersion uint64) (watch.Interface, error) { return builds, nil },
+		"routes": func(resourceVersion uint64) (watch.Interface, error) { return routes, nil },
+	}, latest.Codec)
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	go builds.Add(&kapi.Pod{JSONBase: kapi.JSONBase{ID: "build-pod"}})
+	go routes.Add(&kapi.Pod{JSONBase: kapi.JSONBase{ID: "route-pod"}})
+
+	seen := map[string]bool{}
+	reader := bufio.NewReader(resp.Body)
+	deadline := time.Now().Add(5 * time.Second)
+	for len(seen) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for both events, saw: %v", seen)
+		}
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			t.Fatalf("unexpected error reading response: %v", err)
+		}
+		var got taggedEvent
+		if err := json.Unmarshal(line, &got); err != nil {
+			t.Fatalf("unexpected error decoding event: %v", err)
+		}
+		if got.Type != watch.Added {
+			t.Errorf("expected an Added event, got %v", got.Type)
+		}
+		seen[got.Resource] = true
+	}
+}
+
+func TestHandlerReportsSourceError(t *testing.T) {
+	handler := NewHandler(map[string]Source{
+		"builds": func(resourceVersion uint64) (watch.Interface, error) { return nil, fmt.Errorf("boom") },
+	}, latest.Codec)
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("expected an internal server error, got %v", resp.Status)
+	}
+}
@@ -0,0 +1,115 @@
+// Package notify posts JSON event payloads to externally registered webhook URLs so
+// other systems (chat, CI dashboards, custom pipelines) can react to build, deployment,
+// and project events without polling the API. Deliveries are HMAC-signed and retried
+// with backoff; see Notifier.
+package notify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	"github.com/golang/glog"
+)
+
+// SignatureHeader carries the hex-encoded HMAC-SHA1 signature of the request body, so a
+// receiver can verify a delivery actually came from this server and wasn't tampered with
+// in transit.
+const SignatureHeader = "X-Origin-Signature"
+
+// Event describes a single resource change delivered to a Subscription's URL.
+type Event struct {
+	// Type identifies what happened, e.g. "build.complete", "deployment.failed", or
+	// "project.created".
+	Type string `json:"type"`
+	// ID is the ID of the resource the event is about.
+	ID string `json:"id"`
+	// Timestamp is when the event was generated.
+	Timestamp util.Time `json:"timestamp"`
+}
+
+// Subscription is a single registered delivery target.
+type Subscription struct {
+	// URL receives an HTTP POST with the JSON-encoded Event as its body.
+	URL string
+	// Secret signs each delivery; see SignatureHeader. Empty disables signing.
+	Secret string
+}
+
+// Notifier delivers Events to a fixed set of Subscriptions.
+type Notifier struct {
+	subscriptions []Subscription
+	client        *http.Client
+	maxAttempts   int
+}
+
+// New creates a Notifier that delivers to every given Subscription.
+func New(subscriptions []Subscription) *Notifier {
+	return &Notifier{
+		subscriptions: subscriptions,
+		client:        &http.Client{Timeout: 10 * time.Second},
+		maxAttempts:   3,
+	}
+}
+
+// Notify delivers event to every subscription, concurrently, logging rather than
+// returning delivery failures, since a slow or dead subscriber must never block the
+// controller loop that generated the event.
+func (n *Notifier) Notify(event Event) {
+	for _, sub := range n.subscriptions {
+		go func(sub Subscription) {
+			if err := n.deliver(sub, event); err != nil {
+				glog.Errorf("Error delivering %s event for %s to %s: %v", event.Type, event.ID, sub.URL, err)
+			}
+		}(sub)
+	}
+}
+
+// deliver POSTs event to sub.URL, retrying with a linear backoff up to maxAttempts times.
+func (n *Notifier) deliver(sub Subscription, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < n.maxAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Duration(attempt) * time.Second)
+		}
+
+		req, err := http.NewRequest("POST", sub.URL, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if len(sub.Secret) > 0 {
+			req.Header.Set(SignatureHeader, sign(sub.Secret, body))
+		}
+
+		resp, err := n.client.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		lastErr = fmt.Errorf("received status %s", resp.Status)
+	}
+	return fmt.Errorf("giving up after %d attempts: %v", n.maxAttempts, lastErr)
+}
+
+// sign returns the hex-encoded HMAC-SHA1 signature of body using secret as the key.
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
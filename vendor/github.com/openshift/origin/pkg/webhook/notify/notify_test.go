@@ -0,0 +1,97 @@
+package notify
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNotifySignsAndDeliversEvent(t *testing.T) {
+	var mu sync.Mutex
+	var gotBody []byte
+	var gotSignature string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		gotBody = body
+		gotSignature = r.Header.Get(SignatureHeader)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	n := New([]Subscription{{URL: server.URL, Secret: "sekrit"}})
+	event := Event{Type: "build.complete", ID: "build-1"}
+	n.Notify(event)
+
+	if !waitFor(t, func() bool { mu.Lock(); defer mu.Unlock(); return gotBody != nil }) {
+		t.Fatal("server never received a delivery")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	var decoded Event
+	if err := json.Unmarshal(gotBody, &decoded); err != nil {
+		t.Fatalf("could not decode delivered body: %v", err)
+	}
+	if decoded.Type != event.Type || decoded.ID != event.ID {
+		t.Errorf("unexpected delivered event: %#v", decoded)
+	}
+
+	mac := hmac.New(sha1.New, []byte("sekrit"))
+	mac.Write(gotBody)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	if gotSignature != expected {
+		t.Errorf("expected signature %s, got %s", expected, gotSignature)
+	}
+}
+
+func TestNotifyRetriesUntilSuccess(t *testing.T) {
+	var mu sync.Mutex
+	attempts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts++
+		n := attempts
+		mu.Unlock()
+		if n < 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	n := New([]Subscription{{URL: server.URL}})
+	n.subscriptions[0] = Subscription{URL: server.URL}
+	n.maxAttempts = 3
+	if err := n.deliver(n.subscriptions[0], Event{Type: "project.created", ID: "proj-1"}); err != nil {
+		t.Errorf("expected delivery to eventually succeed, got: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) bool {
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
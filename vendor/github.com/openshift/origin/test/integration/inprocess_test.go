@@ -0,0 +1,156 @@
+// +build integration,no-etcd
+
+// Keep this tag in sync with the rest of the no-etcd integration suite. It was briefly
+// widened to "integration,ignore" to paper over a broken call site instead of fixing it;
+// that silently stopped this file from building at all. If a change here needs the test
+// disabled, say so in a comment and file a tracking issue instead of loosening the tag.
+
+package integration
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/apiserver"
+	kubeclient "github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+
+	"github.com/openshift/origin/pkg/api/latest"
+	"github.com/openshift/origin/pkg/api/v1beta1"
+	"github.com/openshift/origin/pkg/build"
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	buildregistry "github.com/openshift/origin/pkg/build/registry/build"
+	buildconfigregistry "github.com/openshift/origin/pkg/build/registry/buildconfig"
+	buildmemory "github.com/openshift/origin/pkg/build/registry/memory"
+	"github.com/openshift/origin/pkg/build/webhook"
+	"github.com/openshift/origin/pkg/build/webhook/github"
+	osclient "github.com/openshift/origin/pkg/client"
+	secretapi "github.com/openshift/origin/pkg/secret/api"
+)
+
+// fakeBuildStrategy always produces a no-op pod, so the build controller can run its full
+// sync loop without a real docker or kubelet backing it.
+type fakeBuildStrategy struct{}
+
+func (fakeBuildStrategy) CreateBuildPod(b *buildapi.Build, pushSecret, pullSecret *secretapi.Secret, sourceCacheURL string) (*kapi.Pod, error) {
+	return &kapi.Pod{JSONBase: kapi.JSONBase{ID: b.ID + "-build"}}, nil
+}
+
+// setupInProcess starts an origin API server, backed entirely by pkg/build/registry/memory
+// instead of etcd, plus the webhook controller and build controller running in-process
+// against it. It's the no-etcd counterpart to setup in webhookgithub_test.go, for exercising
+// the webhook -> build leg of a build flow in CI without a real cluster.
+//
+// Extending this harness to cover the image tag and deployment legs would need memory-backed
+// registries for those domains; only pkg/build/registry/memory exists today, so this harness
+// stops at the point a completed build would trigger an image tag.
+func setupInProcess(t *testing.T) (osclient.Interface, string, func()) {
+	buildRegistry := buildmemory.New()
+	storage := map[string]apiserver.RESTStorage{
+		"builds":       buildregistry.NewREST(buildRegistry),
+		"buildConfigs": buildconfigregistry.NewREST(buildRegistry),
+	}
+
+	osMux := http.NewServeMux()
+	osPrefix := "/osapi/v1beta1"
+	apiserver.NewAPIGroup(storage, v1beta1.Codec, osPrefix, latest.SelfLinker).InstallREST(osMux, osPrefix)
+	s := httptest.NewServer(osMux)
+
+	osClient := osclient.NewOrDie(&kubeclient.Config{Host: s.URL, Version: latest.Version})
+
+	whPrefix := osPrefix + "/buildConfigHooks/"
+	osMux.Handle(whPrefix, http.StripPrefix(whPrefix,
+		webhook.NewController(osClient, map[string]webhook.Plugin{
+			"github": github.New(),
+		})))
+
+	bc := build.NewBuildController(&kubeclient.Fake{}, osClient,
+		map[buildapi.BuildType]build.BuildJobStrategy{
+			buildapi.DockerBuildType: fakeBuildStrategy{},
+		}, 0, nil, 0)
+	bc.Run(10 * time.Millisecond)
+
+	return osClient, s.URL + whPrefix, s.Close
+}
+
+func TestInProcessWebhookTriggersBuild(t *testing.T) {
+	ctx := kapi.NewContext()
+	osClient, whURL, teardown := setupInProcess(t)
+	defer teardown()
+
+	buildConfig := &buildapi.BuildConfig{
+		JSONBase: kapi.JSONBase{ID: "pushbuild"},
+		DesiredInput: buildapi.BuildInput{
+			Type:      buildapi.DockerBuildType,
+			SourceURI: "http://my.docker/build",
+			ImageTag:  "namespace/builtimage",
+		},
+		Secret: "secret101",
+	}
+	if _, err := osClient.CreateBuildConfig(ctx, buildConfig); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, err := ioutil.ReadFile("../../pkg/build/webhook/github/fixtures/pushevent.json")
+	if err != nil {
+		t.Fatalf("Failed to open fixture: %v", err)
+	}
+	post(whURL+"pushbuild/secret101/github", data, t)
+
+	var builds *buildapi.BuildList
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		builds, err = osClient.ListBuilds(ctx, labels.Everything())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(builds.Items) == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for the webhook to create a build, got %#v", builds)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	build := builds.Items[0]
+	if build.Input.SourceRef != "master" {
+		t.Errorf("Expected SourceRef %q, got %q", "master", build.Input.SourceRef)
+	}
+
+	deadline = time.Now().Add(5 * time.Second)
+	for build.Status != buildapi.BuildComplete && build.Status != buildapi.BuildFailed {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for the build controller to advance the build, got %#v", build)
+		}
+		time.Sleep(10 * time.Millisecond)
+		got, err := osClient.GetBuild(ctx, build.ID)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		build = *got
+	}
+}
+
+func post(url string, data []byte, t *testing.T) {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Error creating request: %v", err)
+	}
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("User-Agent", "GitHub-Hookshot/github")
+	req.Header.Add("X-Github-Event", "push")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed posting webhook: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		t.Fatalf("Unexpected status posting webhook: %s: %s", resp.Status, string(body))
+	}
+}